@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,6 +18,7 @@ import (
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/pkg/middleware/cors"
 	"github.com/leehai1107/cmm_server/pkg/recover"
+	"github.com/leehai1107/cmm_server/pkg/storage"
 	"github.com/leehai1107/cmm_server/pkg/swagger"
 	"github.com/leehai1107/cmm_server/pkg/utils/ginbuilder"
 	"github.com/leehai1107/cmm_server/pkg/utils/timeutils"
@@ -57,6 +59,7 @@ func (s *Server) Run() {
 		fx.Provide(provideGinEngine),
 		fx.Invoke(
 			registerService,
+			registerMediaHandler,
 			registerSwaggerHandler),
 		fx.Invoke(startServer),
 		fx.Invoke(banner.Print),
@@ -106,6 +109,15 @@ func registerService(
 	}
 }
 
+// registerMediaHandler serves uploaded files when they are kept on the local filesystem
+func registerMediaHandler(g *gin.Engine) {
+	cfg := config.StorageConfig()
+	if cfg.Driver != storage.DriverLocal || !strings.HasPrefix(cfg.PublicURL, "/") {
+		return
+	}
+	g.Static(cfg.PublicURL, cfg.LocalDir)
+}
+
 func registerSwaggerHandler(g *gin.Engine) {
 	swaggerAPI := g.Group("/internal/swagger")
 	swag := swagger.NewSwagger()
@@ -1,6 +1,9 @@
 package apifx
 
 import (
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/mailer"
+	"github.com/leehai1107/cmm_server/pkg/storage"
 	"github.com/leehai1107/cmm_server/service/cmm/delivery/http"
 	"github.com/leehai1107/cmm_server/service/cmm/repository"
 	"github.com/leehai1107/cmm_server/service/cmm/usecase"
@@ -11,6 +14,8 @@ import (
 var Module = fx.Provide(
 	provideRouter,
 	provideHandler,
+	provideStorage,
+	provideMailer,
 
 	// Repositories
 	provideUserRepo,
@@ -21,6 +26,25 @@ var Module = fx.Provide(
 	provideVoucherRepo,
 	providePostRepo,
 	provideTransactionRepo,
+	provideServiceRepo,
+	provideSettlementRepo,
+	provideAnalyticsRepo,
+	provideVoucherCampaignRepo,
+	provideReferralRepo,
+	provideNotificationRepo,
+	provideWithdrawalRepo,
+	provideLoyaltyRepo,
+	providePassRepo,
+	provideFloorZoneRepo,
+	provideAmenityRepo,
+	provideSearchRepo,
+	provideReviewRepo,
+	provideFollowRepo,
+	provideMediaRepo,
+	provideShopVerificationRepo,
+	provideStaffRepo,
+	provideUserTokenRepo,
+	provideLoginSecurityRepo,
 
 	// Usecases
 	provideUserUsecase,
@@ -31,12 +55,38 @@ var Module = fx.Provide(
 	provideWalletUsecase,
 	provideVoucherUsecase,
 	providePostUsecase,
+	provideSettlementUsecase,
+	provideDashboardUsecase,
+	provideReportUsecase,
+	provideVoucherCampaignUsecase,
+	provideReferralUsecase,
+	provideNotificationUsecase,
+	provideWithdrawalUsecase,
+	provideLoyaltyUsecase,
+	providePassUsecase,
+	provideFloorZoneUsecase,
+	provideSearchUsecase,
+	provideReviewUsecase,
+	provideFollowUsecase,
+	provideMediaUsecase,
+	provideShopVerificationUsecase,
+	provideStaffUsecase,
+	provideProfileUsecase,
+	provideSecurityUsecase,
 )
 
 func provideRouter(handler http.IHandler) http.Router {
 	return http.NewRouter(handler)
 }
 
+func provideStorage() (storage.Storage, error) {
+	return storage.New(config.StorageConfig())
+}
+
+func provideMailer() (mailer.Mailer, error) {
+	return mailer.New(config.MailConfig())
+}
+
 func provideHandler(
 	userUsecase usecase.IUserUsecase,
 	adminUsecase usecase.IAdminUsecase,
@@ -46,6 +96,24 @@ func provideHandler(
 	walletUsecase usecase.IWalletUsecase,
 	voucherUsecase usecase.IVoucherUsecase,
 	postUsecase usecase.IPostUsecase,
+	settlementUsecase usecase.ISettlementUsecase,
+	dashboardUsecase usecase.IDashboardUsecase,
+	reportUsecase usecase.IReportUsecase,
+	voucherCampaignUsecase usecase.IVoucherCampaignUsecase,
+	referralUsecase usecase.IReferralUsecase,
+	notificationUsecase usecase.INotificationUsecase,
+	withdrawalUsecase usecase.IWithdrawalUsecase,
+	loyaltyUsecase usecase.ILoyaltyUsecase,
+	passUsecase usecase.IPassUsecase,
+	floorZoneUsecase usecase.IFloorZoneUsecase,
+	searchUsecase usecase.ISearchUsecase,
+	reviewUsecase usecase.IReviewUsecase,
+	followUsecase usecase.IFollowUsecase,
+	mediaUsecase usecase.IMediaUsecase,
+	shopVerificationUsecase usecase.IShopVerificationUsecase,
+	staffUsecase usecase.IStaffUsecase,
+	profileUsecase usecase.IProfileUsecase,
+	securityUsecase usecase.ISecurityUsecase,
 ) http.IHandler {
 	handler := http.NewHandler(
 		userUsecase,
@@ -56,6 +124,24 @@ func provideHandler(
 		walletUsecase,
 		voucherUsecase,
 		postUsecase,
+		settlementUsecase,
+		dashboardUsecase,
+		reportUsecase,
+		voucherCampaignUsecase,
+		referralUsecase,
+		notificationUsecase,
+		withdrawalUsecase,
+		loyaltyUsecase,
+		passUsecase,
+		floorZoneUsecase,
+		searchUsecase,
+		reviewUsecase,
+		followUsecase,
+		mediaUsecase,
+		shopVerificationUsecase,
+		staffUsecase,
+		profileUsecase,
+		securityUsecase,
 	)
 	return handler
 }
@@ -93,9 +179,91 @@ func provideTransactionRepo(db *gorm.DB) repository.ITransactionRepo {
 	return repository.NewTransactionRepo(db)
 }
 
+func provideServiceRepo(db *gorm.DB) repository.IServiceRepo {
+	return repository.NewServiceRepo(db)
+}
+
+func provideSettlementRepo(db *gorm.DB) repository.ISettlementRepo {
+	return repository.NewSettlementRepo(db)
+}
+
+func provideAnalyticsRepo(db *gorm.DB) repository.IAnalyticsRepo {
+	return repository.NewAnalyticsRepo(db)
+}
+
+func provideVoucherCampaignRepo(db *gorm.DB) repository.IVoucherCampaignRepo {
+	return repository.NewVoucherCampaignRepo(db)
+}
+
+func provideReferralRepo(db *gorm.DB) repository.IReferralRepo {
+	return repository.NewReferralRepo(db)
+}
+
+func provideNotificationRepo(db *gorm.DB) repository.INotificationRepo {
+	return repository.NewNotificationRepo(db)
+}
+
+func provideWithdrawalRepo(db *gorm.DB) repository.IWithdrawalRepo {
+	return repository.NewWithdrawalRepo(db)
+}
+
+func provideLoyaltyRepo(db *gorm.DB) repository.ILoyaltyRepo {
+	return repository.NewLoyaltyRepo(db)
+}
+
+func providePassRepo(db *gorm.DB) repository.IPassRepo {
+	return repository.NewPassRepo(db)
+}
+
+func provideFloorZoneRepo(db *gorm.DB) repository.IFloorZoneRepo {
+	return repository.NewFloorZoneRepo(db)
+}
+
+func provideAmenityRepo(db *gorm.DB) repository.IAmenityRepo {
+	return repository.NewAmenityRepo(db)
+}
+
+func provideSearchRepo(db *gorm.DB) repository.ISearchRepo {
+	return repository.NewSearchRepo(db)
+}
+
+func provideReviewRepo(db *gorm.DB) repository.IReviewRepo {
+	return repository.NewReviewRepo(db)
+}
+
+func provideFollowRepo(db *gorm.DB) repository.IFollowRepo {
+	return repository.NewFollowRepo(db)
+}
+
+func provideMediaRepo(db *gorm.DB) repository.IMediaRepo {
+	return repository.NewMediaRepo(db)
+}
+
+func provideShopVerificationRepo(db *gorm.DB) repository.IShopVerificationRepo {
+	return repository.NewShopVerificationRepo(db)
+}
+
+func provideStaffRepo(db *gorm.DB) repository.IStaffRepo {
+	return repository.NewStaffRepo(db)
+}
+
+func provideUserTokenRepo(db *gorm.DB) repository.IUserTokenRepo {
+	return repository.NewUserTokenRepo(db)
+}
+
+func provideLoginSecurityRepo(db *gorm.DB) repository.ILoginSecurityRepo {
+	return repository.NewLoginSecurityRepo(db)
+}
+
 // Usecase providers
-func provideUserUsecase(repo repository.IUserRepo) usecase.IUserUsecase {
-	return usecase.NewUserUsecase(repo)
+func provideUserUsecase(
+	repo repository.IUserRepo,
+	referralUsecase usecase.IReferralUsecase,
+	userTokenRepo repository.IUserTokenRepo,
+	mailer mailer.Mailer,
+	loginSecurityRepo repository.ILoginSecurityRepo,
+) usecase.IUserUsecase {
+	return usecase.NewUserUsecase(repo, referralUsecase, userTokenRepo, mailer, loginSecurityRepo, config.MailConfig(), config.SecurityConfig())
 }
 
 func provideAdminUsecase(repo repository.IUserRepo) usecase.IAdminUsecase {
@@ -105,39 +273,204 @@ func provideAdminUsecase(repo repository.IUserRepo) usecase.IAdminUsecase {
 func provideBookingUsecase(
 	bookingRepo repository.IBookingRepo,
 	meetingRoomRepo repository.IMeetingRoomRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
 	walletRepo repository.IWalletRepo,
 	voucherRepo repository.IVoucherRepo,
 	transactionRepo repository.ITransactionRepo,
+	referralUsecase usecase.IReferralUsecase,
+	loyaltyUsecase usecase.ILoyaltyUsecase,
+	passUsecase usecase.IPassUsecase,
+	staffRepo repository.IStaffRepo,
 ) usecase.IBookingUsecase {
-	return usecase.NewBookingUsecase(bookingRepo, meetingRoomRepo, walletRepo, voucherRepo, transactionRepo)
+	return usecase.NewBookingUsecase(bookingRepo, meetingRoomRepo, coffeeShopRepo, walletRepo, voucherRepo, transactionRepo, referralUsecase, loyaltyUsecase, passUsecase, staffRepo)
 }
 
-func provideCoffeeShopUsecase(coffeeShopRepo repository.ICoffeeShopRepo) usecase.ICoffeeShopUsecase {
-	return usecase.NewCoffeeShopUsecase(coffeeShopRepo)
+func provideCoffeeShopUsecase(
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	reviewRepo repository.IReviewRepo,
+	followRepo repository.IFollowRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
+	settlementRepo repository.ISettlementRepo,
+) usecase.ICoffeeShopUsecase {
+	return usecase.NewCoffeeShopUsecase(coffeeShopRepo, reviewRepo, followRepo, mediaRepo, staffRepo, settlementRepo)
 }
 
 func provideMeetingRoomUsecase(
 	meetingRoomRepo repository.IMeetingRoomRepo,
 	coffeeShopRepo repository.ICoffeeShopRepo,
+	floorZoneRepo repository.IFloorZoneRepo,
+	bookingRepo repository.IBookingRepo,
+	amenityRepo repository.IAmenityRepo,
+	reviewRepo repository.IReviewRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
 ) usecase.IMeetingRoomUsecase {
-	return usecase.NewMeetingRoomUsecase(meetingRoomRepo, coffeeShopRepo)
+	return usecase.NewMeetingRoomUsecase(meetingRoomRepo, coffeeShopRepo, floorZoneRepo, bookingRepo, amenityRepo, reviewRepo, mediaRepo, staffRepo)
 }
 
 func provideWalletUsecase(
 	walletRepo repository.IWalletRepo,
 	transactionRepo repository.ITransactionRepo,
+	userRepo repository.IUserRepo,
+	referralUsecase usecase.IReferralUsecase,
+	notificationUsecase usecase.INotificationUsecase,
+	loyaltyUsecase usecase.ILoyaltyUsecase,
 ) usecase.IWalletUsecase {
-	return usecase.NewWalletUsecase(walletRepo, transactionRepo)
+	return usecase.NewWalletUsecase(walletRepo, transactionRepo, userRepo, referralUsecase, notificationUsecase, loyaltyUsecase, config.WalletConfig())
 }
 
-func provideVoucherUsecase(voucherRepo repository.IVoucherRepo) usecase.IVoucherUsecase {
-	return usecase.NewVoucherUsecase(voucherRepo)
+func provideVoucherUsecase(
+	voucherRepo repository.IVoucherRepo,
+	serviceRepo repository.IServiceRepo,
+	transactionRepo repository.ITransactionRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	staffRepo repository.IStaffRepo,
+) usecase.IVoucherUsecase {
+	return usecase.NewVoucherUsecase(voucherRepo, serviceRepo, transactionRepo, coffeeShopRepo, meetingRoomRepo, staffRepo)
 }
 
 func providePostUsecase(
 	postRepo repository.IPostRepo,
 	coffeeShopRepo repository.ICoffeeShopRepo,
 	userRepo repository.IUserRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
 ) usecase.IPostUsecase {
-	return usecase.NewPostUsecase(postRepo, coffeeShopRepo, userRepo)
+	return usecase.NewPostUsecase(postRepo, coffeeShopRepo, userRepo, mediaRepo, staffRepo)
+}
+
+func provideSettlementUsecase(
+	settlementRepo repository.ISettlementRepo,
+	userRepo repository.IUserRepo,
+) usecase.ISettlementUsecase {
+	return usecase.NewSettlementUsecase(settlementRepo, userRepo)
+}
+
+func provideDashboardUsecase(
+	analyticsRepo repository.IAnalyticsRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) usecase.IDashboardUsecase {
+	return usecase.NewDashboardUsecase(analyticsRepo, coffeeShopRepo, staffRepo)
+}
+
+func provideReportUsecase(analyticsRepo repository.IAnalyticsRepo) usecase.IReportUsecase {
+	return usecase.NewReportUsecase(analyticsRepo)
+}
+
+func provideVoucherCampaignUsecase(
+	campaignRepo repository.IVoucherCampaignRepo,
+	serviceRepo repository.IServiceRepo,
+	userRepo repository.IUserRepo,
+) usecase.IVoucherCampaignUsecase {
+	return usecase.NewVoucherCampaignUsecase(campaignRepo, serviceRepo, userRepo)
+}
+
+func provideReferralUsecase(
+	referralRepo repository.IReferralRepo,
+	userRepo repository.IUserRepo,
+) usecase.IReferralUsecase {
+	return usecase.NewReferralUsecase(referralRepo, userRepo, config.ReferralConfig())
+}
+
+func provideNotificationUsecase(notificationRepo repository.INotificationRepo) usecase.INotificationUsecase {
+	return usecase.NewNotificationUsecase(notificationRepo)
+}
+
+func provideWithdrawalUsecase(
+	withdrawalRepo repository.IWithdrawalRepo,
+	userRepo repository.IUserRepo,
+	notificationUsecase usecase.INotificationUsecase,
+) usecase.IWithdrawalUsecase {
+	return usecase.NewWithdrawalUsecase(withdrawalRepo, userRepo, notificationUsecase, config.WalletConfig())
+}
+
+func provideLoyaltyUsecase(
+	loyaltyRepo repository.ILoyaltyRepo,
+	transactionRepo repository.ITransactionRepo,
+) usecase.ILoyaltyUsecase {
+	return usecase.NewLoyaltyUsecase(loyaltyRepo, transactionRepo, config.LoyaltyConfig())
+}
+
+func providePassUsecase(
+	passRepo repository.IPassRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+) usecase.IPassUsecase {
+	return usecase.NewPassUsecase(passRepo, coffeeShopRepo)
+}
+
+func provideFloorZoneUsecase(
+	floorZoneRepo repository.IFloorZoneRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) usecase.IFloorZoneUsecase {
+	return usecase.NewFloorZoneUsecase(floorZoneRepo, coffeeShopRepo, staffRepo)
+}
+
+func provideSearchUsecase(searchRepo repository.ISearchRepo) usecase.ISearchUsecase {
+	return usecase.NewSearchUsecase(searchRepo)
+}
+
+func provideReviewUsecase(
+	reviewRepo repository.IReviewRepo,
+	bookingRepo repository.IBookingRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	notificationUsecase usecase.INotificationUsecase,
+) usecase.IReviewUsecase {
+	return usecase.NewReviewUsecase(reviewRepo, bookingRepo, meetingRoomRepo, coffeeShopRepo, notificationUsecase)
+}
+
+func provideFollowUsecase(
+	followRepo repository.IFollowRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) usecase.IFollowUsecase {
+	return usecase.NewFollowUsecase(followRepo, coffeeShopRepo, staffRepo)
+}
+
+func provideMediaUsecase(
+	mediaRepo repository.IMediaRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	postRepo repository.IPostRepo,
+	staffRepo repository.IStaffRepo,
+	storage storage.Storage,
+) usecase.IMediaUsecase {
+	return usecase.NewMediaUsecase(mediaRepo, coffeeShopRepo, meetingRoomRepo, postRepo, staffRepo, storage)
+}
+
+func provideShopVerificationUsecase(
+	shopVerificationRepo repository.IShopVerificationRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	notificationUsecase usecase.INotificationUsecase,
+	storage storage.Storage,
+) usecase.IShopVerificationUsecase {
+	return usecase.NewShopVerificationUsecase(shopVerificationRepo, coffeeShopRepo, notificationUsecase, storage)
+}
+
+func provideStaffUsecase(
+	staffRepo repository.IStaffRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	userRepo repository.IUserRepo,
+	notificationUsecase usecase.INotificationUsecase,
+) usecase.IStaffUsecase {
+	return usecase.NewStaffUsecase(staffRepo, coffeeShopRepo, userRepo, notificationUsecase)
+}
+
+func provideProfileUsecase(
+	userRepo repository.IUserRepo,
+	userTokenRepo repository.IUserTokenRepo,
+	storage storage.Storage,
+	mailer mailer.Mailer,
+) usecase.IProfileUsecase {
+	return usecase.NewProfileUsecase(userRepo, userTokenRepo, storage, mailer, config.MailConfig())
+}
+
+func provideSecurityUsecase(
+	loginSecurityRepo repository.ILoginSecurityRepo,
+) usecase.ISecurityUsecase {
+	return usecase.NewSecurityUsecase(loginSecurityRepo)
 }
@@ -1,7 +1,11 @@
 package apiwrapper
 
 import (
+	"encoding/csv"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/leehai1107/cmm_server/pkg/errors"
@@ -40,6 +44,41 @@ func SendError(c *gin.Context, statusCode int, errType errors.ErrorType, message
 	c.AbortWithStatusJSON(statusCode, ErrorAPIResponse(errType, message))
 }
 
+// SendCSV writes rows as a downloadable CSV file. Cells are escaped so that spreadsheet
+// applications do not run them as formulas.
+func SendCSV(c *gin.Context, filename string, header []string, rows [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(escapeCSVRow(header))
+	for _, row := range rows {
+		_ = w.Write(escapeCSVRow(row))
+	}
+	w.Flush()
+}
+
+func escapeCSVRow(row []string) []string {
+	escaped := make([]string, len(row))
+	for i, cell := range row {
+		escaped[i] = escapeCSVCell(cell)
+	}
+	return escaped
+}
+
+// escapeCSVCell prefixes a cell that a spreadsheet would read as a formula with a quote.
+// Plain numbers such as negative amounts are left as they are.
+func escapeCSVCell(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
 // Common HTTP status code helpers
 func SendBadRequest(c *gin.Context, message string) {
 	SendError(c, http.StatusBadRequest, errors.BadRequestErr, message)
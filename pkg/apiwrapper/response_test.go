@@ -0,0 +1,45 @@
+package apiwrapper
+
+import (
+	"encoding/csv"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSendCSVEscapesFormulas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	SendCSV(c, "report.csv", []string{"shop_name", "gmv"}, [][]string{
+		{"=HYPERLINK(\"http://evil\")", "120.50"},
+		{"+cmd", "-35.25"},
+		{"-1+2", "1e3"},
+		{"@SUM(A1)", "0"},
+		{"\tTab", ""},
+		{"Good Coffee", "-"},
+	})
+
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	want := [][]string{
+		{"shop_name", "gmv"},
+		{"'=HYPERLINK(\"http://evil\")", "120.50"},
+		{"'+cmd", "-35.25"},
+		{"'-1+2", "1e3"},
+		{"'@SUM(A1)", "0"},
+		{"'\tTab", ""},
+		{"Good Coffee", "'-"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("SendCSV() rows = %q, want %q", records, want)
+	}
+	if got := recorder.Header().Get("Content-Disposition"); got != `attachment; filename="report.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+}
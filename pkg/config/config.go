@@ -11,6 +11,12 @@ var (
 	dbCfg    DBCfg
 	services ServicesCfg
 	cors     CorsCfg
+	referral ReferralCfg
+	wallet   WalletCfg
+	loyalty  LoyaltyCfg
+	storage  StorageCfg
+	mail     MailCfg
+	security SecurityCfg
 )
 
 type DBCfg struct {
@@ -35,6 +41,8 @@ type ServerCfg struct {
 	GinMode        string `envconfig:"GIN_MODE" default:"debug"`
 	Logger         bool   `envconfig:"LOGGER" default:"false"`
 	CorsProduction bool   `envconfig:"CORS_PRODUCTION" default:"false"`
+	// Proxies allowed to set X-Forwarded-For; when empty the client IP is the connection address
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
 }
 
 type ServicesCfg struct{}
@@ -45,12 +53,91 @@ type CorsCfg struct {
 	Client   string `envconfig:"CLIENT" default:"http://localhost:5173/"`
 }
 
+type ReferralCfg struct {
+	ReferrerReward        float64 `envconfig:"REFERRAL_REFERRER_REWARD" default:"50000"`
+	RefereeReward         float64 `envconfig:"REFERRAL_REFEREE_REWARD" default:"20000"`
+	MaxRewardsPerReferrer int     `envconfig:"REFERRAL_MAX_REWARDS_PER_REFERRER" default:"20"`
+	MinQualifyingAmount   float64 `envconfig:"REFERRAL_MIN_QUALIFYING_AMOUNT" default:"50000"`
+}
+
+type WalletCfg struct {
+	TransferDailyLimit  float64 `envconfig:"WALLET_TRANSFER_DAILY_LIMIT" default:"5000000"`
+	TransferDailyCount  int     `envconfig:"WALLET_TRANSFER_DAILY_COUNT" default:"20"`
+	WithdrawalMinAmount float64 `envconfig:"WALLET_WITHDRAWAL_MIN_AMOUNT" default:"50000"`
+}
+
+type LoyaltyCfg struct {
+	SpendPerPoint      float64 `envconfig:"LOYALTY_SPEND_PER_POINT" default:"1000"`
+	PointValue         float64 `envconfig:"LOYALTY_POINT_VALUE" default:"10"`
+	PointsExpiryDays   int     `envconfig:"LOYALTY_POINTS_EXPIRY_DAYS" default:"365"`
+	MaxRedeemPercent   int     `envconfig:"LOYALTY_MAX_REDEEM_PERCENT" default:"50"`
+	SilverSpend        float64 `envconfig:"LOYALTY_SILVER_SPEND" default:"2000000"`
+	GoldSpend          float64 `envconfig:"LOYALTY_GOLD_SPEND" default:"10000000"`
+	SilverBonusPercent int     `envconfig:"LOYALTY_SILVER_BONUS_PERCENT" default:"10"`
+	GoldBonusPercent   int     `envconfig:"LOYALTY_GOLD_BONUS_PERCENT" default:"25"`
+	MemberCancelHours  int     `envconfig:"LOYALTY_MEMBER_CANCEL_HOURS" default:"24"`
+	SilverCancelHours  int     `envconfig:"LOYALTY_SILVER_CANCEL_HOURS" default:"12"`
+	GoldCancelHours    int     `envconfig:"LOYALTY_GOLD_CANCEL_HOURS" default:"4"`
+}
+
+type StorageCfg struct {
+	// local or s3
+	Driver    string `envconfig:"STORAGE_DRIVER" default:"local"`
+	LocalDir  string `envconfig:"STORAGE_LOCAL_DIR" default:"uploads"`
+	PublicURL string `envconfig:"STORAGE_PUBLIC_URL" default:"/internal/media"`
+	// S3-compatible object storage, e.g. AWS S3 or MinIO
+	S3Endpoint     string `envconfig:"STORAGE_S3_ENDPOINT" default:"http://localhost:9000"`
+	S3Region       string `envconfig:"STORAGE_S3_REGION" default:"us-east-1"`
+	S3Bucket       string `envconfig:"STORAGE_S3_BUCKET" default:"cmm-media"`
+	S3AccessKey    string `envconfig:"STORAGE_S3_ACCESS_KEY"`
+	S3SecretKey    string `envconfig:"STORAGE_S3_SECRET_KEY"`
+	S3PathStyle    bool   `envconfig:"STORAGE_S3_PATH_STYLE" default:"true"`
+	MaxUploadBytes int64  `envconfig:"MEDIA_MAX_UPLOAD_BYTES" default:"10485760"`
+	MaxPerOwner    int    `envconfig:"MEDIA_MAX_PER_OWNER" default:"20"`
+	ThumbnailWidth int    `envconfig:"MEDIA_THUMBNAIL_WIDTH" default:"320"`
+	// Largest width x height accepted for decoding, so small files cannot claim huge dimensions
+	MaxPixels int64 `envconfig:"MEDIA_MAX_PIXELS" default:"40000000"`
+}
+
+type MailCfg struct {
+	// smtp, file or memory
+	Driver string `envconfig:"MAIL_DRIVER" default:"file"`
+	From   string `envconfig:"MAIL_FROM" default:"CMM <no-reply@cmm.local>"`
+	// Directory the file driver writes messages to
+	FileDir      string `envconfig:"MAIL_FILE_DIR" default:"mail"`
+	SMTPHost     string `envconfig:"SMTP_HOST" default:"localhost"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+	// Base URL of the web app that the links in emails point to
+	AppURL                  string `envconfig:"MAIL_APP_URL" default:"http://localhost:5173"`
+	VerificationTTLHours    int    `envconfig:"MAIL_VERIFICATION_TTL_HOURS" default:"48"`
+	PasswordResetTTLMinutes int    `envconfig:"MAIL_PASSWORD_RESET_TTL_MINUTES" default:"30"`
+}
+
+type SecurityCfg struct {
+	// Failed logins allowed before an account or IP is locked out
+	AccountMaxFailures int `envconfig:"LOGIN_ACCOUNT_MAX_FAILURES" default:"5"`
+	IPMaxFailures      int `envconfig:"LOGIN_IP_MAX_FAILURES" default:"20"`
+	// The first lockout lasts LockoutBaseSeconds and doubles with each further failure, up to LockoutMaxMinutes
+	LockoutBaseSeconds int `envconfig:"LOGIN_LOCKOUT_BASE_SECONDS" default:"60"`
+	LockoutMaxMinutes  int `envconfig:"LOGIN_LOCKOUT_MAX_MINUTES" default:"60"`
+	// Failures are forgotten after this long without another failure or lockout
+	FailureWindowMinutes int `envconfig:"LOGIN_FAILURE_WINDOW_MINUTES" default:"15"`
+}
+
 func InitConfig() {
 	configs := []interface{}{
 		&server,
 		&services,
 		&dbCfg,
 		&cors,
+		&referral,
+		&wallet,
+		&loyalty,
+		&storage,
+		&mail,
+		&security,
 	}
 	for _, instance := range configs {
 		err := envconfig.Process("", instance)
@@ -75,3 +162,27 @@ func DBConfig() DBCfg {
 func CorsConfig() CorsCfg {
 	return cors
 }
+
+func ReferralConfig() ReferralCfg {
+	return referral
+}
+
+func WalletConfig() WalletCfg {
+	return wallet
+}
+
+func LoyaltyConfig() LoyaltyCfg {
+	return loyalty
+}
+
+func StorageConfig() StorageCfg {
+	return storage
+}
+
+func MailConfig() MailCfg {
+	return mail
+}
+
+func SecurityConfig() SecurityCfg {
+	return security
+}
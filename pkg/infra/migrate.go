@@ -4,6 +4,7 @@ import (
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // RunMigrations runs all database migrations
@@ -12,6 +13,7 @@ func RunMigrations(db *gorm.DB) error {
 
 	// List of all entities to migrate
 	models := []interface{}{
+		&entity.Service{},
 		&entity.User{},
 		&entity.Wallet{},
 		&entity.Topup{},
@@ -19,12 +21,50 @@ func RunMigrations(db *gorm.DB) error {
 		&entity.CommissionRate{},
 		&entity.MeetingRoom{},
 		&entity.Booking{},
+		&entity.VoucherCampaign{},
 		&entity.Voucher{},
+		&entity.VoucherRedemption{},
+		&entity.VoucherMeetingRoom{},
 		&entity.Transaction{},
 		&entity.ShopPost{},
 		&entity.InternalPost{},
+		&entity.Earning{},
+		&entity.Settlement{},
+		&entity.PayoutStatement{},
+		&entity.Referral{},
+		&entity.WalletTransfer{},
+		&entity.Notification{},
+		&entity.PayoutAccount{},
+		&entity.Withdrawal{},
+		&entity.WithdrawalEvent{},
+		&entity.PointEntry{},
+		&entity.PointConsumption{},
+		&entity.PassProduct{},
+		&entity.UserPass{},
+		&entity.PassUsage{},
+		&entity.FloorZone{},
+		&entity.Amenity{},
+		&entity.RoomAmenity{},
+		&entity.Review{},
+		&entity.ShopFollow{},
+		&entity.Media{},
+		&entity.ShopVerification{},
+		&entity.ShopDocument{},
+		&entity.ShopStaff{},
+		&entity.UserToken{},
+		&entity.LoginThrottle{},
+		&entity.UserLoginIP{},
+		&entity.SecurityEvent{},
 	}
 
+	// Shops created before verification existed were already live; keep them listed
+	grandfatherShops := db.Migrator().HasTable(&entity.CoffeeShop{}) &&
+		!db.Migrator().HasColumn(&entity.CoffeeShop{}, "Status")
+
+	// Users who registered before email verification existed keep signing in
+	grandfatherUsers := db.Migrator().HasTable(&entity.User{}) &&
+		!db.Migrator().HasColumn(&entity.User{}, "EmailVerifiedAt")
+
 	// Auto migrate all models
 	for _, model := range models {
 		if err := db.AutoMigrate(model); err != nil {
@@ -34,16 +74,118 @@ func RunMigrations(db *gorm.DB) error {
 		logger.Infof("Successfully migrated model: %T", model)
 	}
 
+	if grandfatherShops {
+		logger.Info("Approving coffee shops created before verification...")
+		if err := db.Model(&entity.CoffeeShop{}).Where("1 = 1").Update("status", entity.ShopApproved).Error; err != nil {
+			logger.Errorf("Failed to approve existing coffee shops: %v", err)
+			return err
+		}
+	}
+
+	if grandfatherUsers {
+		logger.Info("Marking emails of existing users as verified...")
+		if err := db.Model(&entity.User{}).Where("1 = 1").Update("email_verified_at", gorm.Expr("created_at")).Error; err != nil {
+			logger.Errorf("Failed to verify existing users: %v", err)
+			return err
+		}
+	}
+
+	// Seed reference data
+	if err := seedServices(db); err != nil {
+		logger.Errorf("Failed to seed services: %v", err)
+		return err
+	}
+	if err := seedAmenities(db); err != nil {
+		logger.Errorf("Failed to seed amenities: %v", err)
+		return err
+	}
+
 	// Add foreign key constraints
 	if err := addForeignKeys(db); err != nil {
 		logger.Errorf("Failed to add foreign keys: %v", err)
 		return err
 	}
 
+	// Add full-text search columns and indexes
+	addSearchIndexes(db)
+
 	logger.Info("Database migrations completed successfully")
 	return nil
 }
 
+// seedServices inserts the service catalog, leaving existing rows untouched
+func seedServices(db *gorm.DB) error {
+	logger.Info("Seeding service catalog...")
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.DefaultServices).Error
+}
+
+// seedAmenities inserts the amenities catalog, leaving existing rows untouched
+func seedAmenities(db *gorm.DB) error {
+	logger.Info("Seeding amenities catalog...")
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.DefaultAmenities).Error
+}
+
+// addSearchIndexes adds generated tsvector columns with GIN indexes for full-text search.
+// Text goes through the cmm_unaccent configuration, which strips diacritics so that
+// "ca phe" matches "cà phê". Failures are logged and leave search without its columns.
+func addSearchIndexes(db *gorm.DB) {
+	logger.Info("Adding full-text search indexes...")
+
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS unaccent;`).Error; err != nil {
+		logger.Warnf("Could not create extension unaccent: %v", err)
+	}
+
+	if err := db.Exec(`
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'cmm_unaccent') THEN
+				CREATE TEXT SEARCH CONFIGURATION cmm_unaccent (COPY = simple);
+				ALTER TEXT SEARCH CONFIGURATION cmm_unaccent
+					ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+			END IF;
+		END
+		$$;
+	`).Error; err != nil {
+		logger.Warnf("Could not create text search configuration cmm_unaccent: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE coffee_shops
+		ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('cmm_unaccent', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('cmm_unaccent', coalesce(location, '')), 'B') ||
+			setweight(to_tsvector('cmm_unaccent', coalesce(description, '')), 'C')
+		) STORED;
+	`).Error; err != nil {
+		logger.Warnf("Could not add search column to coffee_shops: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE meeting_rooms
+		ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			to_tsvector('cmm_unaccent', coalesce(name, ''))
+		) STORED;
+	`).Error; err != nil {
+		logger.Warnf("Could not add search column to meeting_rooms: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_posts
+		ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('cmm_unaccent', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('cmm_unaccent', coalesce(content, '')), 'B')
+		) STORED;
+	`).Error; err != nil {
+		logger.Warnf("Could not add search column to shop_posts: %v", err)
+	}
+
+	for _, table := range []string{"coffee_shops", "meeting_rooms", "shop_posts"} {
+		if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_` + table + `_search ON ` + table + ` USING GIN (search_vector);`).Error; err != nil {
+			logger.Warnf("Could not add search index to %s: %v", table, err)
+		}
+	}
+}
+
 // addForeignKeys adds foreign key constraints to the database
 func addForeignKeys(db *gorm.DB) error {
 	logger.Info("Adding foreign key constraints...")
@@ -175,6 +317,134 @@ func addForeignKeys(db *gorm.DB) error {
 		logger.Warnf("Could not add constraint fk_transactions_user: %v", err)
 	}
 
+	if err := db.Exec(`
+		ALTER TABLE transactions 
+		DROP CONSTRAINT IF EXISTS fk_transactions_service;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_transactions_service: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE transactions 
+		ADD CONSTRAINT fk_transactions_service 
+		FOREIGN KEY (service_id) REFERENCES services(id);
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_transactions_service: %v", err)
+	}
+
+	// Voucher foreign keys
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		DROP CONSTRAINT IF EXISTS fk_vouchers_service;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_vouchers_service: %v", err)
+	}
+
+	// Vouchers for any service used to store service 0; they are unrestricted now
+	if err := db.Exec(`UPDATE vouchers SET service_id = NULL WHERE service_id = 0`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		ADD CONSTRAINT fk_vouchers_service 
+		FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_vouchers_service: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		DROP CONSTRAINT IF EXISTS fk_vouchers_campaign;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_vouchers_campaign: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		ADD CONSTRAINT fk_vouchers_campaign 
+		FOREIGN KEY (campaign_id) REFERENCES voucher_campaigns(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_vouchers_campaign: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		DROP CONSTRAINT IF EXISTS fk_vouchers_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_vouchers_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE vouchers 
+		ADD CONSTRAINT fk_vouchers_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_vouchers_coffee_shop: %v", err)
+	}
+
+	// VoucherMeetingRoom foreign keys
+	if err := db.Exec(`
+		ALTER TABLE voucher_meeting_rooms 
+		DROP CONSTRAINT IF EXISTS fk_voucher_meeting_rooms_voucher;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_voucher_meeting_rooms_voucher: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_meeting_rooms 
+		ADD CONSTRAINT fk_voucher_meeting_rooms_voucher 
+		FOREIGN KEY (voucher_id) REFERENCES vouchers(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_voucher_meeting_rooms_voucher: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_meeting_rooms 
+		DROP CONSTRAINT IF EXISTS fk_voucher_meeting_rooms_meeting_room;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_voucher_meeting_rooms_meeting_room: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_meeting_rooms 
+		ADD CONSTRAINT fk_voucher_meeting_rooms_meeting_room 
+		FOREIGN KEY (meeting_room_id) REFERENCES meeting_rooms(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_voucher_meeting_rooms_meeting_room: %v", err)
+	}
+
+	// VoucherRedemption foreign keys
+	if err := db.Exec(`
+		ALTER TABLE voucher_redemptions 
+		DROP CONSTRAINT IF EXISTS fk_voucher_redemptions_voucher;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_voucher_redemptions_voucher: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_redemptions 
+		ADD CONSTRAINT fk_voucher_redemptions_voucher 
+		FOREIGN KEY (voucher_id) REFERENCES vouchers(id) ON DELETE RESTRICT;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_voucher_redemptions_voucher: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_redemptions 
+		DROP CONSTRAINT IF EXISTS fk_voucher_redemptions_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_voucher_redemptions_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE voucher_redemptions 
+		ADD CONSTRAINT fk_voucher_redemptions_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_voucher_redemptions_user: %v", err)
+	}
+
 	// ShopPost foreign keys
 	if err := db.Exec(`
 		ALTER TABLE shop_posts 
@@ -207,6 +477,698 @@ func addForeignKeys(db *gorm.DB) error {
 		logger.Warnf("Could not add constraint fk_internal_posts_created_by: %v", err)
 	}
 
+	// Earning foreign keys
+	if err := db.Exec(`
+		ALTER TABLE earnings 
+		DROP CONSTRAINT IF EXISTS fk_earnings_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_earnings_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE earnings 
+		ADD CONSTRAINT fk_earnings_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE RESTRICT;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_earnings_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE earnings 
+		DROP CONSTRAINT IF EXISTS fk_earnings_payout_statement;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_earnings_payout_statement: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE earnings 
+		ADD CONSTRAINT fk_earnings_payout_statement 
+		FOREIGN KEY (payout_statement_id) REFERENCES payout_statements(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_earnings_payout_statement: %v", err)
+	}
+
+	// PayoutStatement foreign keys
+	if err := db.Exec(`
+		ALTER TABLE payout_statements 
+		DROP CONSTRAINT IF EXISTS fk_payout_statements_settlement;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_payout_statements_settlement: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE payout_statements 
+		ADD CONSTRAINT fk_payout_statements_settlement 
+		FOREIGN KEY (settlement_id) REFERENCES settlements(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_payout_statements_settlement: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE payout_statements 
+		DROP CONSTRAINT IF EXISTS fk_payout_statements_owner;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_payout_statements_owner: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE payout_statements 
+		ADD CONSTRAINT fk_payout_statements_owner 
+		FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_payout_statements_owner: %v", err)
+	}
+
+	// Referral foreign keys
+	if err := db.Exec(`
+		ALTER TABLE users 
+		DROP CONSTRAINT IF EXISTS fk_users_referred_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_users_referred_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE users 
+		ADD CONSTRAINT fk_users_referred_by 
+		FOREIGN KEY (referred_by) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_users_referred_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE referrals 
+		DROP CONSTRAINT IF EXISTS fk_referrals_referrer;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_referrals_referrer: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE referrals 
+		ADD CONSTRAINT fk_referrals_referrer 
+		FOREIGN KEY (referrer_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_referrals_referrer: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE referrals 
+		DROP CONSTRAINT IF EXISTS fk_referrals_referee;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_referrals_referee: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE referrals 
+		ADD CONSTRAINT fk_referrals_referee 
+		FOREIGN KEY (referee_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_referrals_referee: %v", err)
+	}
+
+	// WalletTransfer foreign keys
+	if err := db.Exec(`
+		ALTER TABLE wallet_transfers 
+		DROP CONSTRAINT IF EXISTS fk_wallet_transfers_sender;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_wallet_transfers_sender: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE wallet_transfers 
+		ADD CONSTRAINT fk_wallet_transfers_sender 
+		FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_wallet_transfers_sender: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE wallet_transfers 
+		DROP CONSTRAINT IF EXISTS fk_wallet_transfers_recipient;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_wallet_transfers_recipient: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE wallet_transfers 
+		ADD CONSTRAINT fk_wallet_transfers_recipient 
+		FOREIGN KEY (recipient_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_wallet_transfers_recipient: %v", err)
+	}
+
+	// Notification foreign keys
+	if err := db.Exec(`
+		ALTER TABLE notifications 
+		DROP CONSTRAINT IF EXISTS fk_notifications_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_notifications_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE notifications 
+		ADD CONSTRAINT fk_notifications_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_notifications_user: %v", err)
+	}
+
+	// Withdrawal foreign keys
+	if err := db.Exec(`
+		ALTER TABLE payout_accounts 
+		DROP CONSTRAINT IF EXISTS fk_payout_accounts_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_payout_accounts_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE payout_accounts 
+		ADD CONSTRAINT fk_payout_accounts_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_payout_accounts_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		DROP CONSTRAINT IF EXISTS fk_withdrawals_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_withdrawals_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		ADD CONSTRAINT fk_withdrawals_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_withdrawals_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		DROP CONSTRAINT IF EXISTS fk_withdrawals_payout_account;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_withdrawals_payout_account: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		ADD CONSTRAINT fk_withdrawals_payout_account 
+		FOREIGN KEY (payout_account_id) REFERENCES payout_accounts(id);
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_withdrawals_payout_account: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		DROP CONSTRAINT IF EXISTS fk_withdrawals_processed_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_withdrawals_processed_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawals 
+		ADD CONSTRAINT fk_withdrawals_processed_by 
+		FOREIGN KEY (processed_by) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_withdrawals_processed_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawal_events 
+		DROP CONSTRAINT IF EXISTS fk_withdrawal_events_withdrawal;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_withdrawal_events_withdrawal: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawal_events 
+		ADD CONSTRAINT fk_withdrawal_events_withdrawal 
+		FOREIGN KEY (withdrawal_id) REFERENCES withdrawals(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_withdrawal_events_withdrawal: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawal_events 
+		DROP CONSTRAINT IF EXISTS fk_withdrawal_events_actor;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_withdrawal_events_actor: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE withdrawal_events 
+		ADD CONSTRAINT fk_withdrawal_events_actor 
+		FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_withdrawal_events_actor: %v", err)
+	}
+
+	// Loyalty points foreign keys
+	if err := db.Exec(`
+		ALTER TABLE point_entries 
+		DROP CONSTRAINT IF EXISTS fk_point_entries_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_point_entries_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE point_entries 
+		ADD CONSTRAINT fk_point_entries_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_point_entries_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE point_consumptions 
+		DROP CONSTRAINT IF EXISTS fk_point_consumptions_redeem_entry;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_point_consumptions_redeem_entry: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE point_consumptions 
+		ADD CONSTRAINT fk_point_consumptions_redeem_entry 
+		FOREIGN KEY (redeem_entry_id) REFERENCES point_entries(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_point_consumptions_redeem_entry: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE point_consumptions 
+		DROP CONSTRAINT IF EXISTS fk_point_consumptions_earn_entry;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_point_consumptions_earn_entry: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE point_consumptions 
+		ADD CONSTRAINT fk_point_consumptions_earn_entry 
+		FOREIGN KEY (earn_entry_id) REFERENCES point_entries(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_point_consumptions_earn_entry: %v", err)
+	}
+
+	// Pass foreign keys
+	if err := db.Exec(`
+		ALTER TABLE pass_products 
+		DROP CONSTRAINT IF EXISTS fk_pass_products_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_pass_products_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE pass_products 
+		ADD CONSTRAINT fk_pass_products_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_pass_products_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_passes 
+		DROP CONSTRAINT IF EXISTS fk_user_passes_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_user_passes_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_passes 
+		ADD CONSTRAINT fk_user_passes_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_user_passes_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_passes 
+		DROP CONSTRAINT IF EXISTS fk_user_passes_pass_product;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_user_passes_pass_product: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_passes 
+		ADD CONSTRAINT fk_user_passes_pass_product 
+		FOREIGN KEY (pass_product_id) REFERENCES pass_products(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_user_passes_pass_product: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE pass_usages 
+		DROP CONSTRAINT IF EXISTS fk_pass_usages_user_pass;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_pass_usages_user_pass: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE pass_usages 
+		ADD CONSTRAINT fk_pass_usages_user_pass 
+		FOREIGN KEY (user_pass_id) REFERENCES user_passes(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_pass_usages_user_pass: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE pass_usages 
+		DROP CONSTRAINT IF EXISTS fk_pass_usages_booking;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_pass_usages_booking: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE pass_usages 
+		ADD CONSTRAINT fk_pass_usages_booking 
+		FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_pass_usages_booking: %v", err)
+	}
+
+	// Floor zone constraints
+	if err := db.Exec(`
+		ALTER TABLE floor_zones 
+		DROP CONSTRAINT IF EXISTS fk_floor_zones_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_floor_zones_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE floor_zones 
+		ADD CONSTRAINT fk_floor_zones_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_floor_zones_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE meeting_rooms 
+		DROP CONSTRAINT IF EXISTS fk_meeting_rooms_floor_zone;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_meeting_rooms_floor_zone: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE meeting_rooms 
+		ADD CONSTRAINT fk_meeting_rooms_floor_zone 
+		FOREIGN KEY (floor_zone_id) REFERENCES floor_zones(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_meeting_rooms_floor_zone: %v", err)
+	}
+
+	// Room amenity constraints
+	if err := db.Exec(`
+		ALTER TABLE room_amenities 
+		DROP CONSTRAINT IF EXISTS fk_room_amenities_meeting_room;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_room_amenities_meeting_room: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE room_amenities 
+		ADD CONSTRAINT fk_room_amenities_meeting_room 
+		FOREIGN KEY (meeting_room_id) REFERENCES meeting_rooms(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_room_amenities_meeting_room: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE room_amenities 
+		DROP CONSTRAINT IF EXISTS fk_room_amenities_amenity;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_room_amenities_amenity: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE room_amenities 
+		ADD CONSTRAINT fk_room_amenities_amenity 
+		FOREIGN KEY (amenity_code) REFERENCES amenities(code) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_room_amenities_amenity: %v", err)
+	}
+
+	// Review constraints
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		DROP CONSTRAINT IF EXISTS fk_reviews_booking;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_reviews_booking: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		ADD CONSTRAINT fk_reviews_booking 
+		FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_reviews_booking: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		DROP CONSTRAINT IF EXISTS fk_reviews_customer;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_reviews_customer: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		ADD CONSTRAINT fk_reviews_customer 
+		FOREIGN KEY (customer_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_reviews_customer: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		DROP CONSTRAINT IF EXISTS fk_reviews_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_reviews_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		ADD CONSTRAINT fk_reviews_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_reviews_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		DROP CONSTRAINT IF EXISTS fk_reviews_meeting_room;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_reviews_meeting_room: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE reviews 
+		ADD CONSTRAINT fk_reviews_meeting_room 
+		FOREIGN KEY (meeting_room_id) REFERENCES meeting_rooms(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_reviews_meeting_room: %v", err)
+	}
+
+	// Shop follow constraints
+	if err := db.Exec(`
+		ALTER TABLE shop_follows 
+		DROP CONSTRAINT IF EXISTS fk_shop_follows_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_follows_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_follows 
+		ADD CONSTRAINT fk_shop_follows_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_follows_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_follows 
+		DROP CONSTRAINT IF EXISTS fk_shop_follows_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_follows_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_follows 
+		ADD CONSTRAINT fk_shop_follows_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_follows_coffee_shop: %v", err)
+	}
+
+	// Media
+	if err := db.Exec(`
+		ALTER TABLE media 
+		DROP CONSTRAINT IF EXISTS fk_media_uploaded_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_media_uploaded_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE media 
+		ADD CONSTRAINT fk_media_uploaded_by 
+		FOREIGN KEY (uploaded_by) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_media_uploaded_by: %v", err)
+	}
+
+	// Shop verification
+	if err := db.Exec(`
+		ALTER TABLE shop_verifications 
+		DROP CONSTRAINT IF EXISTS fk_shop_verifications_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_verifications_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_verifications 
+		ADD CONSTRAINT fk_shop_verifications_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_verifications_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_verifications 
+		DROP CONSTRAINT IF EXISTS fk_shop_verifications_reviewed_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_verifications_reviewed_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_verifications 
+		ADD CONSTRAINT fk_shop_verifications_reviewed_by 
+		FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_verifications_reviewed_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_documents 
+		DROP CONSTRAINT IF EXISTS fk_shop_documents_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_documents_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_documents 
+		ADD CONSTRAINT fk_shop_documents_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_documents_coffee_shop: %v", err)
+	}
+
+	// Shop staff
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		DROP CONSTRAINT IF EXISTS fk_shop_staffs_coffee_shop;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_staffs_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		ADD CONSTRAINT fk_shop_staffs_coffee_shop 
+		FOREIGN KEY (coffee_shop_id) REFERENCES coffee_shops(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_staffs_coffee_shop: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		DROP CONSTRAINT IF EXISTS fk_shop_staffs_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_staffs_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		ADD CONSTRAINT fk_shop_staffs_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_staffs_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		DROP CONSTRAINT IF EXISTS fk_shop_staffs_invited_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_shop_staffs_invited_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE shop_staffs 
+		ADD CONSTRAINT fk_shop_staffs_invited_by 
+		FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_shop_staffs_invited_by: %v", err)
+	}
+
+	// User tokens
+	if err := db.Exec(`
+		ALTER TABLE user_tokens 
+		DROP CONSTRAINT IF EXISTS fk_user_tokens_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_user_tokens_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_tokens 
+		ADD CONSTRAINT fk_user_tokens_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_user_tokens_user: %v", err)
+	}
+
+	// Login security
+	if err := db.Exec(`
+		ALTER TABLE user_login_ips 
+		DROP CONSTRAINT IF EXISTS fk_user_login_ips_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_user_login_ips_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE user_login_ips 
+		ADD CONSTRAINT fk_user_login_ips_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_user_login_ips_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE security_events 
+		DROP CONSTRAINT IF EXISTS fk_security_events_user;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_security_events_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE security_events 
+		ADD CONSTRAINT fk_security_events_user 
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_security_events_user: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE security_events 
+		DROP CONSTRAINT IF EXISTS fk_security_events_reviewed_by;
+	`).Error; err != nil {
+		logger.Warnf("Could not drop constraint fk_security_events_reviewed_by: %v", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE security_events 
+		ADD CONSTRAINT fk_security_events_reviewed_by 
+		FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL;
+	`).Error; err != nil {
+		logger.Warnf("Could not add constraint fk_security_events_reviewed_by: %v", err)
+	}
+
 	logger.Info("Foreign key constraints added successfully")
 	return nil
 }
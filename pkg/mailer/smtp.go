@@ -0,0 +1,107 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpTimeout bounds a delivery when the context has no deadline
+const smtpTimeout = 30 * time.Second
+
+// SMTPOptions configures an SMTP server. Port 465 uses implicit TLS; other ports upgrade
+// with STARTTLS when the server offers it.
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	options SMTPOptions
+}
+
+func NewSMTPMailer(options SMTPOptions) Mailer {
+	return &smtpMailer{
+		options: options,
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	data, err := buildMessage(m.options.From, msg)
+	if err != nil {
+		return err
+	}
+	fromAddr, _ := mail.ParseAddress(m.options.From)
+	toAddr, _ := mail.ParseAddress(msg.To)
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+
+	conn, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.options.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.options.Host}); err != nil {
+			return err
+		}
+	}
+	if m.options.Username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted connection to a remote host
+		auth := smtp.PlainAuth("", m.options.Username, m.options.Password, m.options.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(fromAddr.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(toAddr.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (m *smtpMailer) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(m.options.Host, strconv.Itoa(m.options.Port))
+	if m.options.Port == 465 {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: m.options.Host}}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", addr)
+}
@@ -0,0 +1,112 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+// Email templates
+const (
+	TemplateVerifyEmail   = "verify_email"
+	TemplateResetPassword = "reset_password"
+	TemplateChangeEmail   = "change_email"
+)
+
+// DefaultLanguage is used when a template has no translation for the requested language
+const DefaultLanguage = "vi"
+
+// Each template file defines "subject", "text" and "html" and is rendered inside layout.html
+//
+//go:embed templates
+var templateFS embed.FS
+
+// templates maps language to template name to the parsed template
+var templates = mustParseTemplates()
+
+// TemplateData is the data available to email templates
+type TemplateData struct {
+	Name string
+	Link string
+	// How long the link stays valid
+	ExpiresIn time.Duration
+}
+
+// Render renders a template in the language, falling back to DefaultLanguage, into a
+// message without a recipient
+func Render(name string, language string, data TemplateData) (Message, error) {
+	tmpl, ok := templates[language][name]
+	if !ok {
+		tmpl, ok = templates[DefaultLanguage][name]
+	}
+	if !ok {
+		return Message{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&text, "text", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "layout", data); err != nil {
+		return Message{}, err
+	}
+
+	// Subject and text are plain text, so the HTML escaping is undone
+	return Message{
+		Subject: strings.TrimSpace(html.UnescapeString(subject.String())),
+		Text:    strings.TrimSpace(html.UnescapeString(text.String())),
+		HTML:    body.String(),
+	}, nil
+}
+
+func mustParseTemplates() map[string]map[string]*template.Template {
+	result := map[string]map[string]*template.Template{}
+	languages, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	for _, language := range languages {
+		if !language.IsDir() {
+			continue
+		}
+		lang := language.Name()
+		files, err := fs.Glob(templateFS, path.Join("templates", lang, "*.html"))
+		if err != nil {
+			panic(err)
+		}
+
+		result[lang] = map[string]*template.Template{}
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), ".html")
+			result[lang][name] = template.Must(
+				template.New(name).
+					Funcs(template.FuncMap{"duration": durationFormatter(lang)}).
+					ParseFS(templateFS, "templates/layout.html", file),
+			)
+		}
+	}
+	return result
+}
+
+// durationFormatter formats link lifetimes as whole hours or minutes
+func durationFormatter(language string) func(time.Duration) string {
+	hours, minutes := "hours", "minutes"
+	if language == "vi" {
+		hours, minutes = "giờ", "phút"
+	}
+	return func(d time.Duration) string {
+		if d >= time.Hour && d%time.Hour == 0 {
+			return fmt.Sprintf("%d %s", int(d/time.Hour), hours)
+		}
+		return fmt.Sprintf("%d %s", int(d/time.Minute), minutes)
+	}
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// localStorage keeps files on the local filesystem; the API serves them under the public URL
+type localStorage struct {
+	root      string
+	publicURL string
+}
+
+func NewLocalStorage(root string, publicURL string) Storage {
+	return &localStorage{
+		root:      root,
+		publicURL: publicURL,
+	}
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(s.root, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStorage) URL(key string) string {
+	return joinURL(s.publicURL, key)
+}
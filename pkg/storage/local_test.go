@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStoragePutAndDelete(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStorage(root, "/internal/media")
+	ctx := context.Background()
+
+	key := "media/coffee_shop/1/photo.jpg"
+	if err := store.Put(ctx, key, []byte("image"), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "media", "coffee_shop", "1", "photo.jpg"))
+	if err != nil {
+		t.Fatalf("reading stored file: %v", err)
+	}
+	if string(data) != "image" {
+		t.Errorf("stored data = %q, want %q", data, "image")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(key))); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file still exists after Delete(), stat error = %v", err)
+	}
+
+	// Deleting a missing object is not an error
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing key error = %v, want nil", err)
+	}
+}
+
+func TestLocalStorageRejectsTraversal(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "uploads")
+	store := NewLocalStorage(root, "/internal/media")
+	ctx := context.Background()
+
+	victim := filepath.Join(parent, "victim.txt")
+	if err := os.WriteFile(victim, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../escaped.jpg", "media/../../escaped.jpg", "/tmp/escaped.jpg", "..\\escaped.jpg"} {
+		if err := store.Put(ctx, key, []byte("image"), "image/jpeg"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escaped.jpg")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Put() wrote outside the storage root, stat error = %v", err)
+	}
+
+	if err := store.Delete(ctx, "../victim.txt"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Delete(../victim.txt) error = %v, want ErrInvalidKey", err)
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Errorf("Delete() removed a file outside the storage root: %v", err)
+	}
+}
+
+func TestLocalStorageURL(t *testing.T) {
+	tests := []struct {
+		publicURL string
+		want      string
+	}{
+		{publicURL: "/internal/media", want: "/internal/media/media/a.jpg"},
+		{publicURL: "/internal/media/", want: "/internal/media/media/a.jpg"},
+		{publicURL: "https://cdn.example.com", want: "https://cdn.example.com/media/a.jpg"},
+	}
+
+	for _, tt := range tests {
+		store := NewLocalStorage(t.TempDir(), tt.publicURL)
+		if got := store.URL("media/a.jpg"); got != tt.want {
+			t.Errorf("URL() with public URL %q = %q, want %q", tt.publicURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "ap-southeast-1"
+	testBucket    = "cmm-media"
+)
+
+// fakeS3 is an S3 stand-in that verifies Signature Version 4 and keeps objects in memory,
+// keyed by the host and escaped path the request addressed them with
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string]fakeObject
+	requests int
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := verifySignature(r, body); err != nil {
+		http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	location := r.Host + r.RequestURI
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[location] = fakeObject{data: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, location)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) object(location string) (fakeObject, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	object, ok := f.objects[location]
+	return object, ok
+}
+
+// verifySignature recomputes the SigV4 signature of the request the way S3 does
+func verifySignature(r *http.Request, body []byte) error {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return errors.New("missing AWS4-HMAC-SHA256 authorization")
+	}
+	fields := make(map[string]string)
+	for _, part := range strings.Split(auth, ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		fields[name] = value
+	}
+
+	credential := strings.Split(fields["Credential"], "/")
+	if len(credential) != 5 || credential[0] != testAccessKey || credential[2] != testRegion ||
+		credential[3] != "s3" || credential[4] != "aws4_request" {
+		return fmt.Errorf("unexpected credential %q", fields["Credential"])
+	}
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, credential[1]) {
+		return fmt.Errorf("credential date %q does not match x-amz-date %q", credential[1], amzDate)
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return errors.New("payload hash does not match the body")
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	path, query, _ := strings.Cut(r.RequestURI, "?")
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		path,
+		query,
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join(credential[1:], "/")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range credential[1:] {
+		key = hmacSum(key, part)
+	}
+	want := hex.EncodeToString(hmacSum(key, stringToSign))
+	if !hmac.Equal([]byte(want), []byte(fields["Signature"])) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+func hmacSum(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// newTestS3Storage points an S3 backend at the stand-in. Every connection is dialed to the
+// stand-in so virtual-host addressed buckets resolve without DNS.
+func newTestS3Storage(t *testing.T, server *httptest.Server, options S3Options) *s3Storage {
+	t.Helper()
+	if options.Endpoint == "" {
+		options.Endpoint = server.URL
+	}
+	options.Region = testRegion
+	options.Bucket = testBucket
+	options.AccessKey = testAccessKey
+	if options.SecretKey == "" {
+		options.SecretKey = testSecretKey
+	}
+
+	store, err := NewS3Storage(options)
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+	s3 := store.(*s3Storage)
+	addr := server.Listener.Addr().String()
+	s3.client = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	return s3
+}
+
+func TestS3StoragePutAndDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	host := server.Listener.Addr().String()
+	key := "media/coffee_shop/1/shop front.jpg"
+
+	tests := []struct {
+		name     string
+		options  S3Options
+		location string
+	}{
+		{
+			name:     "path style",
+			options:  S3Options{PathStyle: true},
+			location: host + "/cmm-media/media/coffee_shop/1/shop%20front.jpg",
+		},
+		{
+			name:     "path style with endpoint path",
+			options:  S3Options{Endpoint: server.URL + "/storage/", PathStyle: true},
+			location: host + "/storage/cmm-media/media/coffee_shop/1/shop%20front.jpg",
+		},
+		{
+			name:     "virtual host",
+			options:  S3Options{PathStyle: false},
+			location: "cmm-media." + host + "/media/coffee_shop/1/shop%20front.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestS3Storage(t, server, tt.options)
+			ctx := context.Background()
+
+			if err := store.Put(ctx, key, []byte("jpeg bytes"), "image/jpeg"); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			object, ok := fake.object(tt.location)
+			if !ok {
+				t.Fatalf("no object stored at %s", tt.location)
+			}
+			if string(object.data) != "jpeg bytes" || object.contentType != "image/jpeg" {
+				t.Errorf("stored object = %q (%s), want %q (image/jpeg)", object.data, object.contentType, "jpeg bytes")
+			}
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, ok := fake.object(tt.location); ok {
+				t.Errorf("object at %s still exists after Delete()", tt.location)
+			}
+		})
+	}
+}
+
+func TestS3StorageRejectedSignature(t *testing.T) {
+	_, server := newFakeS3(t)
+	store := newTestS3Storage(t, server, S3Options{PathStyle: true, SecretKey: "wrong-secret"})
+
+	err := store.Put(context.Background(), "media/a.jpg", []byte("jpeg bytes"), "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Put() with a wrong secret error = %v, want a 403 error", err)
+	}
+}
+
+func TestS3StorageRejectsInvalidKey(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := newTestS3Storage(t, server, S3Options{PathStyle: true})
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "../other-bucket/a.jpg", []byte("jpeg bytes"), "image/jpeg"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put() error = %v, want ErrInvalidKey", err)
+	}
+	if err := store.Delete(ctx, "media/../../a.jpg"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Delete() error = %v, want ErrInvalidKey", err)
+	}
+	if fake.requests != 0 {
+		t.Errorf("invalid keys sent %d requests, want none", fake.requests)
+	}
+}
+
+func TestS3StorageURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		options S3Options
+		want    string
+	}{
+		{
+			name:    "path style",
+			options: S3Options{Endpoint: "http://localhost:9000", PathStyle: true},
+			want:    "http://localhost:9000/cmm-media/media/a%20b.jpg",
+		},
+		{
+			name:    "virtual host",
+			options: S3Options{Endpoint: "https://s3.ap-southeast-1.amazonaws.com"},
+			want:    "https://cmm-media.s3.ap-southeast-1.amazonaws.com/media/a%20b.jpg",
+		},
+		{
+			name:    "public URL",
+			options: S3Options{Endpoint: "http://localhost:9000", PathStyle: true, PublicURL: "https://cdn.example.com/"},
+			want:    "https://cdn.example.com/media/a b.jpg",
+		},
+		{
+			name:    "relative public URL is ignored",
+			options: S3Options{Endpoint: "http://localhost:9000", PathStyle: true, PublicURL: "/internal/media"},
+			want:    "http://localhost:9000/cmm-media/media/a%20b.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Bucket = testBucket
+			tt.options.AccessKey = testAccessKey
+			tt.options.SecretKey = testSecretKey
+			store, err := NewS3Storage(tt.options)
+			if err != nil {
+				t.Fatalf("NewS3Storage() error = %v", err)
+			}
+			if got := store.URL("media/a b.jpg"); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewS3StorageValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options S3Options
+	}{
+		{name: "endpoint without scheme", options: S3Options{Endpoint: "localhost:9000", Bucket: testBucket, AccessKey: testAccessKey, SecretKey: testSecretKey}},
+		{name: "missing bucket", options: S3Options{Endpoint: "http://localhost:9000", AccessKey: testAccessKey, SecretKey: testSecretKey}},
+		{name: "missing credentials", options: S3Options{Endpoint: "http://localhost:9000", Bucket: testBucket}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewS3Storage(tt.options); err == nil {
+			t.Errorf("NewS3Storage() with %s error = nil, want an error", tt.name)
+		}
+	}
+}
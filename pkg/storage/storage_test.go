@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leehai1107/cmm_server/pkg/config"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{key: "media/coffee_shop/1/2.jpg", valid: true},
+		{key: "avatar.png", valid: true},
+		{key: "media/..hidden/a.jpg", valid: true},
+		{key: "", valid: false},
+		{key: "/etc/passwd", valid: false},
+		{key: "..", valid: false},
+		{key: "../outside.jpg", valid: false},
+		{key: "media/../../outside.jpg", valid: false},
+		{key: "media/..", valid: false},
+		{key: "./media/a.jpg", valid: false},
+		{key: "media/./a.jpg", valid: false},
+		{key: "media//a.jpg", valid: false},
+		{key: "media/", valid: false},
+		{key: "media\\..\\outside.jpg", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validateKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("validateKey(%q) = %v, want ErrInvalidKey", tt.key, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	local, err := New(config.StorageCfg{Driver: DriverLocal, LocalDir: t.TempDir(), PublicURL: "/internal/media"})
+	if err != nil {
+		t.Fatalf("New(local) error = %v", err)
+	}
+	if _, ok := local.(*localStorage); !ok {
+		t.Errorf("New(local) = %T, want *localStorage", local)
+	}
+
+	s3, err := New(config.StorageCfg{
+		Driver:      DriverS3,
+		S3Endpoint:  "http://localhost:9000",
+		S3Bucket:    "cmm-media",
+		S3AccessKey: "access",
+		S3SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("New(s3) error = %v", err)
+	}
+	if _, ok := s3.(*s3Storage); !ok {
+		t.Errorf("New(s3) = %T, want *s3Storage", s3)
+	}
+
+	if _, err := New(config.StorageCfg{Driver: "ftp"}); err == nil {
+		t.Error("New(ftp) error = nil, want unknown driver error")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"math/rand"
 	"time"
 	"unsafe"
@@ -109,6 +110,26 @@ func RandSymbolChar(length int) string {
 	return random(SymbolChars, length)
 }
 
+// RandFromAlphabet generate a random string of specified length using only chars of alphabet.
+// It reads from crypto/rand, so the result is safe to use for codes that must not be guessable.
+func RandFromAlphabet(alphabet string, length int) (string, error) {
+	if alphabet == "" || length < 1 {
+		return "", nil
+	}
+
+	max := big.NewInt(int64(len(alphabet)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := crand.Int(crand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[n.Int64()]
+	}
+
+	return string(b), nil
+}
+
 // nearestPowerOfTwo 返回一个大于等于cap的最近的2的整数次幂，参考java8的hashmap的tableSizeFor函数
 func nearestPowerOfTwo(cap int) int {
 	n := cap - 1
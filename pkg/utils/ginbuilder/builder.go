@@ -3,6 +3,7 @@ package ginbuilder
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/pkg/utils/ginutils"
 )
 
@@ -36,5 +37,9 @@ func (b *builder) Build() *gin.Engine {
 func defaultGinEngine() *gin.Engine {
 	gin.SetMode(config.ServerConfig().GinMode)
 	e := gin.New()
+	// Without trusted proxies gin would take the client IP from any X-Forwarded-For header
+	if err := e.SetTrustedProxies(config.ServerConfig().TrustedProxies); err != nil {
+		logger.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 	return e
 }
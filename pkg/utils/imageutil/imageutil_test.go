@@ -0,0 +1,79 @@
+package imageutil
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodePixelLimit(t *testing.T) {
+	data := encodePNG(t, 300, 200)
+
+	img, err := Decode(data, 300*200)
+	if err != nil {
+		t.Fatalf("Decode() at the limit error = %v", err)
+	}
+	if img.Bounds().Dx() != 300 || img.Bounds().Dy() != 200 {
+		t.Errorf("Decode() size = %v, want 300x200", img.Bounds().Size())
+	}
+
+	if _, err := Decode(data, 300*200-1); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("Decode() over the limit error = %v, want ErrTooManyPixels", err)
+	}
+}
+
+func TestDecodeRejectsHugeDeclaredSize(t *testing.T) {
+	// A 13 byte GIF header declaring a 65535x65535 screen
+	data := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+
+	if _, err := Decode(data, 40_000_000); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("Decode() error = %v, want ErrTooManyPixels", err)
+	}
+}
+
+func TestDecodeInvalidImage(t *testing.T) {
+	if _, err := Decode([]byte("not an image"), 40_000_000); err == nil {
+		t.Error("Decode() error = nil, want an error")
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	img, err := Decode(encodePNG(t, 640, 480), 40_000_000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		maxWidth   int
+		wantWidth  int
+		wantHeight int
+	}{
+		{maxWidth: 320, wantWidth: 320, wantHeight: 240},
+		{maxWidth: 1024, wantWidth: 640, wantHeight: 480},
+	}
+
+	for _, tt := range tests {
+		data, err := Thumbnail(img, tt.maxWidth)
+		if err != nil {
+			t.Fatalf("Thumbnail(%d) error = %v", tt.maxWidth, err)
+		}
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decoding thumbnail: %v", err)
+		}
+		if format != "jpeg" || cfg.Width != tt.wantWidth || cfg.Height != tt.wantHeight {
+			t.Errorf("Thumbnail(%d) = %s %dx%d, want jpeg %dx%d", tt.maxWidth, format, cfg.Width, cfg.Height, tt.wantWidth, tt.wantHeight)
+		}
+	}
+}
@@ -33,10 +33,12 @@ var upgrader = websocket.Upgrader{
 
 // Client struct for websocket connection and message sending
 type Client struct {
-	ID   string
-	Conn *websocket.Conn
-	send chan Message
-	hub  *Hub
+	ID string
+	// UserID is set on the authenticated notification connection of a user, which only receives messages
+	UserID string
+	Conn   *websocket.Conn
+	send   chan Message
+	hub    *Hub
 }
 
 // NewClient creates a new client
@@ -62,6 +64,10 @@ func (c *Client) Read() {
 			logger.Errorf("Error: %v", err)
 			break
 		}
+		// Notifications only come from the server
+		if c.UserID != "" || msg.Type == MessageTypeNotification.Value() {
+			continue
+		}
 		c.hub.broadcast <- msg
 	}
 }
@@ -105,7 +111,8 @@ func (c *Client) Close() {
 }
 
 // Function to handle websocket connection and register client to hub and start goroutines
-func serveWS(ctx *gin.Context, roomId string, hub *Hub) {
+// userID is set for notification connections, which are keyed by the authenticated user instead of a room.
+func serveWS(ctx *gin.Context, roomId string, userID string, hub *Hub) {
 	// Validate hub
 	if hub == nil {
 		logger.Errorf("Hub is nil for RoomId: %s", roomId)
@@ -138,6 +145,7 @@ func serveWS(ctx *gin.Context, roomId string, hub *Hub) {
 		ws.Close()
 		return
 	}
+	client.UserID = userID
 
 	// Register the client to the hub
 	hub.register <- client
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/leehai1107/cmm_server/pkg/logger"
 )
 
 var hubSingleton *Hub
@@ -12,5 +13,27 @@ func InitHub() {
 }
 
 func ServeWs(ctx *gin.Context, roomId string) {
-	serveWS(ctx, roomId, hubSingleton)
+	serveWS(ctx, roomId, "", hubSingleton)
+}
+
+// ServeNotifications opens the notification connection of an authenticated user
+func ServeNotifications(ctx *gin.Context, userID string) {
+	serveWS(ctx, userID, userID, hubSingleton)
+}
+
+// SendNotification pushes a notification to the notification connections of the recipient.
+// It never blocks; when the hub is backed up the push is dropped and clients catch up by listing notifications.
+func SendNotification(recipient string, content string) {
+	if hubSingleton == nil {
+		return
+	}
+	select {
+	case hubSingleton.broadcast <- Message{
+		Type:      MessageTypeNotification.Value(),
+		Recipient: recipient,
+		Content:   content,
+	}:
+	default:
+		logger.Errorf("Dropped notification push for %s, hub is busy", recipient)
+	}
 }
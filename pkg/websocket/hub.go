@@ -4,10 +4,15 @@ import (
 	"github.com/leehai1107/cmm_server/pkg/logger"
 )
 
+// broadcastBuffer is how many messages may wait for the hub before notification pushes are dropped
+const broadcastBuffer = 256
+
 // Hub is a struct that holds all the clients and the messages that are sent to them
 type Hub struct {
 	// Registered clients.
 	clients map[string]map[*Client]bool
+	// Notification connections by authenticated user ID, kept apart from the chat rooms
+	users map[string]map[*Client]bool
 	//Unregistered clients.
 	unregister chan *Client
 	// Register requests from the clients.
@@ -28,9 +33,10 @@ type Message struct {
 func NewHub() *Hub {
 	return &Hub{
 		clients:    make(map[string]map[*Client]bool),
+		users:      make(map[string]map[*Client]bool),
 		unregister: make(chan *Client),
 		register:   make(chan *Client),
-		broadcast:  make(chan Message),
+		broadcast:  make(chan Message, broadcastBuffer),
 	}
 }
 
@@ -55,20 +61,29 @@ func (h *Hub) Run() {
 
 // function check if room exists and if not create it and add client to it
 func (h *Hub) RegisterNewClient(client *Client) {
-	connections := h.clients[client.ID]
+	rooms := h.clients
+	if client.UserID != "" {
+		rooms = h.users
+	}
+	connections := rooms[client.ID]
 	if connections == nil {
 		connections = make(map[*Client]bool)
-		h.clients[client.ID] = connections
+		rooms[client.ID] = connections
 	}
-	h.clients[client.ID][client] = true
+	rooms[client.ID][client] = true
 
-	logger.Infof("Size of clients: %d", len(h.clients[client.ID]))
+	logger.Infof("Size of clients: %d", len(rooms[client.ID]))
 }
 
 // function to remvoe client from room
 func (h *Hub) RemoveClient(client *Client) {
-	if _, ok := h.clients[client.ID]; ok {
-		delete(h.clients[client.ID], client)
+	rooms := h.clients
+	if client.UserID != "" {
+		rooms = h.users
+	}
+	// A client dropped for being slow is already closed
+	if _, ok := rooms[client.ID][client]; ok {
+		delete(rooms[client.ID], client)
 		close(client.send)
 		logger.Infof("Removed client from room: %s", client.ID)
 	}
@@ -93,13 +108,13 @@ func (h *Hub) HandleMessage(message Message) {
 	//Check if the message is a type of "notification"
 	if message.Type == MessageTypeNotification.Value() {
 		logger.Infof("Notification: %s", message.Content)
-		clients := h.clients[message.Recipient]
+		clients := h.users[message.Recipient]
 		for client := range clients {
 			select {
 			case client.send <- message:
 			default:
 				close(client.send)
-				delete(h.clients[message.Recipient], client)
+				delete(h.users[message.Recipient], client)
 			}
 		}
 	}
@@ -0,0 +1,70 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationsOnlyReachUserConnections(t *testing.T) {
+	hub := NewHub()
+	recipient := "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+	// A chat client that joined a room named after the recipient's ID
+	chat := NewClient(recipient, nil, hub)
+	hub.RegisterNewClient(chat)
+
+	user := NewClient(recipient, nil, hub)
+	user.UserID = recipient
+	hub.RegisterNewClient(user)
+
+	hub.HandleMessage(Message{Type: MessageTypeNotification.Value(), Recipient: recipient, Content: "hello"})
+
+	select {
+	case msg := <-user.send:
+		if msg.Content != "hello" {
+			t.Errorf("user connection got %q, want %q", msg.Content, "hello")
+		}
+	default:
+		t.Error("user connection did not receive the notification")
+	}
+	select {
+	case msg := <-chat.send:
+		t.Errorf("chat room connection received notification %q", msg.Content)
+	default:
+	}
+}
+
+func TestRemoveClientAfterSlowClientDropped(t *testing.T) {
+	hub := NewHub()
+	user := NewClient("user", nil, hub)
+	user.UserID = "user"
+	user.send = make(chan Message)
+	hub.RegisterNewClient(user)
+
+	// The unbuffered send channel makes the client look slow, so the hub drops and closes it
+	hub.HandleMessage(Message{Type: MessageTypeNotification.Value(), Recipient: "user"})
+
+	// Unregistering it afterwards must not close the channel twice
+	hub.RemoveClient(user)
+}
+
+func TestSendNotificationDoesNotBlock(t *testing.T) {
+	previous := hubSingleton
+	hubSingleton = NewHub()
+	defer func() { hubSingleton = previous }()
+
+	// Nothing runs the hub, so the buffer fills up and later pushes are dropped
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < broadcastBuffer+10; i++ {
+			SendNotification("user", "hello")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SendNotification blocked on a busy hub")
+	}
+}
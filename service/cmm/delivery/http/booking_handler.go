@@ -15,6 +15,7 @@ type IBookingHandler interface {
 	GetMyBookings(ctx *gin.Context)
 	CancelBooking(ctx *gin.Context)
 	GetRoomBookings(ctx *gin.Context)
+	CompleteBooking(ctx *gin.Context)
 }
 
 // CreateBooking godoc
@@ -180,3 +181,40 @@ func (h *Handler) GetRoomBookings(ctx *gin.Context) {
 
 	apiwrapper.SendSuccess(ctx, bookings)
 }
+
+// CompleteBooking godoc
+// @Summary Complete a booking
+// @Description Mark a booking as completed and record the owner's earning (owner only)
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/booking/{id}/complete [post]
+func (h *Handler) CompleteBooking(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	bookingID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid booking ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid booking ID")
+		return
+	}
+
+	err = h.bookingUsecase.CompleteBooking(ctx, ownerID, bookingID)
+	if err != nil {
+		log.Errorw("Failed to complete booking", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Booking completed successfully"})
+}
@@ -14,6 +14,7 @@ type ICoffeeShopHandler interface {
 	GetCoffeeShop(ctx *gin.Context)
 	GetMyCoffeeShops(ctx *gin.Context)
 	GetAllCoffeeShops(ctx *gin.Context)
+	GetNearbyCoffeeShops(ctx *gin.Context)
 	UpdateCoffeeShop(ctx *gin.Context)
 	DeleteCoffeeShop(ctx *gin.Context)
 	SetCommissionRate(ctx *gin.Context)
@@ -138,9 +139,44 @@ func (h *Handler) GetAllCoffeeShops(ctx *gin.Context) {
 	apiwrapper.SendSuccess(ctx, shops)
 }
 
+// GetNearbyCoffeeShops godoc
+// @Summary Find nearby coffee shops
+// @Description Find coffee shops within a radius of a point, closest first
+// @Tags coffee-shop
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Param radius query number false "Radius in kilometers (default 5, max 50)"
+// @Param open_now query bool false "Only shops open right now"
+// @Param rooms_available query bool false "Only shops with a room that is not booked right now"
+// @Param limit query int false "Maximum number of shops (default 20, max 100)"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/nearby [get]
+func (h *Handler) GetNearbyCoffeeShops(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.NearbyCoffeeShops
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	shops, err := h.coffeeShopUsecase.GetNearbyCoffeeShops(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to find nearby coffee shops", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to find nearby coffee shops")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, shops)
+}
+
 // UpdateCoffeeShop godoc
 // @Summary Update a coffee shop
-// @Description Update coffee shop details
+// @Description Update coffee shop details as its owner or a staff member with the manage_shop permission
 // @Tags coffee-shop
 // @Accept json
 // @Produce json
@@ -151,12 +187,12 @@ func (h *Handler) GetAllCoffeeShops(ctx *gin.Context) {
 func (h *Handler) UpdateCoffeeShop(ctx *gin.Context) {
 	log := logger.EnhanceWith(ctx)
 
-	ownerIDStr, exists := ctx.Get("user_id")
+	userIDStr, exists := ctx.Get("user_id")
 	if !exists {
 		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
 		return
 	}
-	ownerID := ownerIDStr.(uuid.UUID)
+	userID := userIDStr.(uuid.UUID)
 
 	var req request.UpdateCoffeeShop
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -165,7 +201,7 @@ func (h *Handler) UpdateCoffeeShop(ctx *gin.Context) {
 		return
 	}
 
-	err := h.coffeeShopUsecase.UpdateCoffeeShop(ctx, ownerID, req)
+	err := h.coffeeShopUsecase.UpdateCoffeeShop(ctx, userID, req)
 	if err != nil {
 		log.Errorw("Failed to update coffee shop", "error", err)
 		apiwrapper.SendBadRequest(ctx, err.Error())
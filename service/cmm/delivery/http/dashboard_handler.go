@@ -0,0 +1,108 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IDashboardHandler defines owner dashboard handler methods
+type IDashboardHandler interface {
+	GetShopDashboard(ctx *gin.Context)
+	GetShopOccupancy(ctx *gin.Context)
+}
+
+// GetShopDashboard godoc
+// @Summary Get shop earnings dashboard
+// @Description Get revenue, bookings, average booking length, voucher usage and commission of a shop by day, week or month (owner only)
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date, exclusive (YYYY-MM-DD)"
+// @Param interval query string false "day, week or month"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/dashboard [get]
+func (h *Handler) GetShopDashboard(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.DashboardQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	dashboard, err := h.dashboardUsecase.GetShopDashboard(ctx, ownerID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to get shop dashboard", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, dashboard)
+}
+
+// GetShopOccupancy godoc
+// @Summary Get room occupancy
+// @Description Get the occupancy rate of every room of a shop over a date range (owner only)
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date, exclusive (YYYY-MM-DD)"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/dashboard/occupancy [get]
+func (h *Handler) GetShopOccupancy(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.DashboardQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	occupancy, err := h.dashboardUsecase.GetShopOccupancy(ctx, ownerID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to get shop occupancy", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, occupancy)
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IFloorZoneHandler defines floor zone handler methods
+type IFloorZoneHandler interface {
+	CreateFloorZone(ctx *gin.Context)
+	GetFloorZones(ctx *gin.Context)
+	UpdateFloorZone(ctx *gin.Context)
+	DeleteFloorZone(ctx *gin.Context)
+}
+
+// CreateFloorZone godoc
+// @Summary Create a floor zone
+// @Description Create a zone grouping desks, seats and rooms of the owner's coffee shop
+// @Tags floor-zone
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.CreateFloorZone true "Floor zone details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/floor-zones [post]
+func (h *Handler) CreateFloorZone(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.CreateFloorZone
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	zone, err := h.floorZoneUsecase.CreateFloorZone(ctx, ownerID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to create floor zone", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, zone)
+}
+
+// GetFloorZones godoc
+// @Summary Get floor zones
+// @Description Get the floor zones of a coffee shop
+// @Tags floor-zone
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/floor-zones [get]
+func (h *Handler) GetFloorZones(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	zones, err := h.floorZoneUsecase.GetFloorZones(ctx, shopID)
+	if err != nil {
+		log.Errorw("Failed to get floor zones", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get floor zones")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, zones)
+}
+
+// UpdateFloorZone godoc
+// @Summary Update a floor zone
+// @Description Rename a floor zone or change its description
+// @Tags floor-zone
+// @Accept json
+// @Produce json
+// @Param id path string true "Floor zone ID"
+// @Param request body request.UpdateFloorZone true "Floor zone changes"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/floor-zone/{id} [put]
+func (h *Handler) UpdateFloorZone(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	zoneID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid floor zone ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid floor zone ID")
+		return
+	}
+
+	var req request.UpdateFloorZone
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	zone, err := h.floorZoneUsecase.UpdateFloorZone(ctx, ownerID, zoneID, req)
+	if err != nil {
+		log.Errorw("Failed to update floor zone", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, zone)
+}
+
+// DeleteFloorZone godoc
+// @Summary Delete a floor zone
+// @Description Delete a floor zone. Its desks, seats and rooms are kept without a zone.
+// @Tags floor-zone
+// @Accept json
+// @Produce json
+// @Param id path string true "Floor zone ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/floor-zone/{id} [delete]
+func (h *Handler) DeleteFloorZone(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	zoneID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid floor zone ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid floor zone ID")
+		return
+	}
+
+	if err := h.floorZoneUsecase.DeleteFloorZone(ctx, ownerID, zoneID); err != nil {
+		log.Errorw("Failed to delete floor zone", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Floor zone deleted successfully"})
+}
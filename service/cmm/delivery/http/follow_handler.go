@@ -0,0 +1,193 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IFollowHandler defines shop follow and feed handler methods
+type IFollowHandler interface {
+	FollowShop(ctx *gin.Context)
+	UnfollowShop(ctx *gin.Context)
+	GetFollowedShops(ctx *gin.Context)
+	GetShopFollowerCount(ctx *gin.Context)
+	GetFeed(ctx *gin.Context)
+}
+
+// FollowShop godoc
+// @Summary Follow a coffee shop
+// @Description Save a coffee shop as a favorite; its posts then appear in the user's feed
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/follow [post]
+func (h *Handler) FollowShop(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	if err := h.followUsecase.FollowShop(ctx, userID, shopID); err != nil {
+		log.Errorw("Failed to follow coffee shop", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Coffee shop followed"})
+}
+
+// UnfollowShop godoc
+// @Summary Unfollow a coffee shop
+// @Description Remove a coffee shop from the user's favorites
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/follow [delete]
+func (h *Handler) UnfollowShop(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	if err := h.followUsecase.UnfollowShop(ctx, userID, shopID); err != nil {
+		log.Errorw("Failed to unfollow coffee shop", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Coffee shop unfollowed"})
+}
+
+// GetFollowedShops godoc
+// @Summary Get followed coffee shops
+// @Description Get the coffee shops the current user follows, most recently followed first
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/following [get]
+func (h *Handler) GetFollowedShops(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shops, err := h.followUsecase.GetFollowedShops(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get followed coffee shops", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get followed coffee shops")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, shops)
+}
+
+// GetShopFollowerCount godoc
+// @Summary Get follower count
+// @Description Get how many users follow the owner's coffee shop
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/followers [get]
+func (h *Handler) GetShopFollowerCount(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	count, err := h.followUsecase.GetFollowerCount(ctx, ownerID, shopID)
+	if err != nil {
+		log.Errorw("Failed to get follower count", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, count)
+}
+
+// GetFeed godoc
+// @Summary Get my feed
+// @Description Get the posts of the coffee shops the current user follows, newest first
+// @Tags follow
+// @Accept json
+// @Produce json
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Number of posts to skip"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/post/feed [get]
+func (h *Handler) GetFeed(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.FeedQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	feed, err := h.followUsecase.GetFeed(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to get feed", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get feed")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, feed)
+}
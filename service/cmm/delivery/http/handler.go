@@ -15,18 +15,54 @@ type IHandler interface {
 	IWalletHandler
 	IVoucherHandler
 	IPostHandler
+	ISettlementHandler
+	IDashboardHandler
+	IReportHandler
+	IVoucherCampaignHandler
+	IReferralHandler
+	INotificationHandler
+	IWithdrawalHandler
+	ILoyaltyHandler
+	IPassHandler
+	IFloorZoneHandler
+	ISearchHandler
+	IReviewHandler
+	IFollowHandler
+	IMediaHandler
+	IShopVerificationHandler
+	IStaffHandler
+	IProfileHandler
+	ISecurityHandler
 }
 
 // Handler implements all handler interfaces
 type Handler struct {
-	userUsecase        usecase.IUserUsecase
-	adminUsecase       usecase.IAdminUsecase
-	bookingUsecase     usecase.IBookingUsecase
-	coffeeShopUsecase  usecase.ICoffeeShopUsecase
-	meetingRoomUsecase usecase.IMeetingRoomUsecase
-	walletUsecase      usecase.IWalletUsecase
-	voucherUsecase     usecase.IVoucherUsecase
-	postUsecase        usecase.IPostUsecase
+	userUsecase             usecase.IUserUsecase
+	adminUsecase            usecase.IAdminUsecase
+	bookingUsecase          usecase.IBookingUsecase
+	coffeeShopUsecase       usecase.ICoffeeShopUsecase
+	meetingRoomUsecase      usecase.IMeetingRoomUsecase
+	walletUsecase           usecase.IWalletUsecase
+	voucherUsecase          usecase.IVoucherUsecase
+	postUsecase             usecase.IPostUsecase
+	settlementUsecase       usecase.ISettlementUsecase
+	dashboardUsecase        usecase.IDashboardUsecase
+	reportUsecase           usecase.IReportUsecase
+	voucherCampaignUsecase  usecase.IVoucherCampaignUsecase
+	referralUsecase         usecase.IReferralUsecase
+	notificationUsecase     usecase.INotificationUsecase
+	withdrawalUsecase       usecase.IWithdrawalUsecase
+	loyaltyUsecase          usecase.ILoyaltyUsecase
+	passUsecase             usecase.IPassUsecase
+	floorZoneUsecase        usecase.IFloorZoneUsecase
+	searchUsecase           usecase.ISearchUsecase
+	reviewUsecase           usecase.IReviewUsecase
+	followUsecase           usecase.IFollowUsecase
+	mediaUsecase            usecase.IMediaUsecase
+	shopVerificationUsecase usecase.IShopVerificationUsecase
+	staffUsecase            usecase.IStaffUsecase
+	profileUsecase          usecase.IProfileUsecase
+	securityUsecase         usecase.ISecurityUsecase
 }
 
 func NewHandler(
@@ -38,15 +74,51 @@ func NewHandler(
 	walletUsecase usecase.IWalletUsecase,
 	voucherUsecase usecase.IVoucherUsecase,
 	postUsecase usecase.IPostUsecase,
+	settlementUsecase usecase.ISettlementUsecase,
+	dashboardUsecase usecase.IDashboardUsecase,
+	reportUsecase usecase.IReportUsecase,
+	voucherCampaignUsecase usecase.IVoucherCampaignUsecase,
+	referralUsecase usecase.IReferralUsecase,
+	notificationUsecase usecase.INotificationUsecase,
+	withdrawalUsecase usecase.IWithdrawalUsecase,
+	loyaltyUsecase usecase.ILoyaltyUsecase,
+	passUsecase usecase.IPassUsecase,
+	floorZoneUsecase usecase.IFloorZoneUsecase,
+	searchUsecase usecase.ISearchUsecase,
+	reviewUsecase usecase.IReviewUsecase,
+	followUsecase usecase.IFollowUsecase,
+	mediaUsecase usecase.IMediaUsecase,
+	shopVerificationUsecase usecase.IShopVerificationUsecase,
+	staffUsecase usecase.IStaffUsecase,
+	profileUsecase usecase.IProfileUsecase,
+	securityUsecase usecase.ISecurityUsecase,
 ) IHandler {
 	return &Handler{
-		userUsecase:        userUsecase,
-		adminUsecase:       adminUsecase,
-		bookingUsecase:     bookingUsecase,
-		coffeeShopUsecase:  coffeeShopUsecase,
-		meetingRoomUsecase: meetingRoomUsecase,
-		walletUsecase:      walletUsecase,
-		voucherUsecase:     voucherUsecase,
-		postUsecase:        postUsecase,
+		userUsecase:             userUsecase,
+		adminUsecase:            adminUsecase,
+		bookingUsecase:          bookingUsecase,
+		coffeeShopUsecase:       coffeeShopUsecase,
+		meetingRoomUsecase:      meetingRoomUsecase,
+		walletUsecase:           walletUsecase,
+		voucherUsecase:          voucherUsecase,
+		postUsecase:             postUsecase,
+		settlementUsecase:       settlementUsecase,
+		dashboardUsecase:        dashboardUsecase,
+		reportUsecase:           reportUsecase,
+		voucherCampaignUsecase:  voucherCampaignUsecase,
+		referralUsecase:         referralUsecase,
+		notificationUsecase:     notificationUsecase,
+		withdrawalUsecase:       withdrawalUsecase,
+		loyaltyUsecase:          loyaltyUsecase,
+		passUsecase:             passUsecase,
+		floorZoneUsecase:        floorZoneUsecase,
+		searchUsecase:           searchUsecase,
+		reviewUsecase:           reviewUsecase,
+		followUsecase:           followUsecase,
+		mediaUsecase:            mediaUsecase,
+		shopVerificationUsecase: shopVerificationUsecase,
+		staffUsecase:            staffUsecase,
+		profileUsecase:          profileUsecase,
+		securityUsecase:         securityUsecase,
 	}
 }
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+)
+
+// ILoyaltyHandler defines loyalty points handler methods
+type ILoyaltyHandler interface {
+	GetLoyaltySummary(ctx *gin.Context)
+	GetPointsHistory(ctx *gin.Context)
+}
+
+// GetLoyaltySummary godoc
+// @Summary Get loyalty summary
+// @Description Get the current user's points balance, membership tier and tier perks
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/points [get]
+func (h *Handler) GetLoyaltySummary(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	summary, err := h.loyaltyUsecase.GetLoyaltySummary(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get loyalty summary", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get loyalty summary")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, summary)
+}
+
+// GetPointsHistory godoc
+// @Summary Get points history
+// @Description Get the current user's points ledger: points earned, redeemed, refunded and expired
+// @Tags loyalty
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/points/history [get]
+func (h *Handler) GetPointsHistory(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	entries, err := h.loyaltyUsecase.GetPointsHistory(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get points history", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get points history")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, entries)
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// multipartOverhead leaves room for the form fields and boundaries around the uploaded file
+const multipartOverhead = 1 << 20
+
+// limitUploadBody caps the request body at the upload limit and returns the limit
+func limitUploadBody(ctx *gin.Context) int64 {
+	maxSize := config.StorageConfig().MaxUploadBytes
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+multipartOverhead)
+	return maxSize
+}
+
+// readUploadFile reads the "file" form field. It reads one byte past the limit
+// so that oversized files are rejected by the usecase.
+func readUploadFile(ctx *gin.Context, maxSize int64) (string, []byte, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return "", nil, errors.New("file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", nil, errors.New("invalid file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return "", nil, errors.New("invalid file")
+	}
+	return fileHeader.Filename, data, nil
+}
+
+// IMediaHandler defines media upload and gallery handler methods
+type IMediaHandler interface {
+	UploadMedia(ctx *gin.Context)
+	GetGallery(ctx *gin.Context)
+	DeleteMedia(ctx *gin.Context)
+}
+
+// UploadMedia godoc
+// @Summary Upload an image
+// @Description Add a JPEG, PNG, GIF or WebP image to the gallery of an owned coffee shop, meeting room or shop post. A thumbnail is generated for JPEG, PNG and GIF images
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param owner_type formData string true "Gallery owner type" Enums(coffee_shop, meeting_room, shop_post)
+// @Param owner_id formData string true "Gallery owner ID"
+// @Param file formData file true "Image file"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/media/upload [post]
+func (h *Handler) UploadMedia(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	maxSize := limitUploadBody(ctx)
+
+	var req request.UploadMedia
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	_, data, err := readUploadFile(ctx, maxSize)
+	if err != nil {
+		log.Errorw("Failed to read upload file", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	result, err := h.mediaUsecase.UploadMedia(ctx, userID, req, data)
+	if err != nil {
+		log.Errorw("Failed to upload media", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// GetGallery godoc
+// @Summary Get a media gallery
+// @Description Get the images of a coffee shop, meeting room or shop post in upload order
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param owner_type path string true "Gallery owner type" Enums(coffee_shop, meeting_room, shop_post)
+// @Param owner_id path string true "Gallery owner ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/media/{owner_type}/{owner_id} [get]
+func (h *Handler) GetGallery(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerID, err := uuid.Parse(ctx.Param("owner_id"))
+	if err != nil {
+		log.Errorw("Invalid owner ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid owner ID")
+		return
+	}
+
+	result, err := h.mediaUsecase.GetGallery(ctx, ctx.Param("owner_type"), ownerID)
+	if err != nil {
+		log.Errorw("Failed to get gallery", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to retrieve gallery")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// DeleteMedia godoc
+// @Summary Delete an image
+// @Description Remove an image and its thumbnail from the gallery of an owned coffee shop, meeting room or shop post
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param id path string true "Media ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/media/{id} [delete]
+func (h *Handler) DeleteMedia(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	mediaID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid media ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid media ID")
+		return
+	}
+
+	if err := h.mediaUsecase.DeleteMedia(ctx, userID, mediaID); err != nil {
+		log.Errorw("Failed to delete media", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Media deleted successfully"})
+}
@@ -16,6 +16,10 @@ type IMeetingRoomHandler interface {
 	GetAvailableMeetingRooms(ctx *gin.Context)
 	UpdateMeetingRoom(ctx *gin.Context)
 	DeleteMeetingRoom(ctx *gin.Context)
+	BulkCreateResources(ctx *gin.Context)
+	GetResourceAvailability(ctx *gin.Context)
+	SearchMeetingRooms(ctx *gin.Context)
+	GetAmenities(ctx *gin.Context)
 }
 
 // CreateMeetingRoom godoc
@@ -218,3 +222,142 @@ func (h *Handler) DeleteMeetingRoom(ctx *gin.Context) {
 
 	apiwrapper.SendSuccess(ctx, gin.H{"message": "Meeting room deleted successfully"})
 }
+
+// BulkCreateResources godoc
+// @Summary Create desks or seats in bulk
+// @Description Create a numbered run of desks, seats or rooms for a coffee shop, e.g. Desk 1 to Desk 20 in a floor zone
+// @Tags meeting-room
+// @Accept json
+// @Produce json
+// @Param request body request.BulkCreateResources true "Resources to create"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/meeting-room/bulk-create [post]
+func (h *Handler) BulkCreateResources(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	var req request.BulkCreateResources
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	rooms, err := h.meetingRoomUsecase.BulkCreateResources(ctx, ownerID, req)
+	if err != nil {
+		log.Errorw("Failed to create resources", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, rooms)
+}
+
+// GetResourceAvailability godoc
+// @Summary Get per-seat availability
+// @Description Get each desk, seat or room of a coffee shop with whether it is free for the given period and its price
+// @Tags meeting-room
+// @Accept json
+// @Produce json
+// @Param shop_id path string true "Coffee Shop ID"
+// @Param start_time query string true "Start time (RFC3339)"
+// @Param end_time query string true "End time (RFC3339)"
+// @Param resource_type query string false "meeting_room, desk or seat"
+// @Param floor_zone_id query string false "Floor zone ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/meeting-room/shop/{shop_id}/availability [get]
+func (h *Handler) GetResourceAvailability(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	shopID, err := uuid.Parse(ctx.Param("shop_id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.ResourceAvailability
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	availability, err := h.meetingRoomUsecase.GetResourceAvailability(ctx, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to get availability", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, availability)
+}
+
+// SearchMeetingRooms godoc
+// @Summary Search meeting rooms
+// @Description Search available rooms across coffee shops by location, amenities, capacity range and price range
+// @Tags meeting-room
+// @Accept json
+// @Produce json
+// @Param coffee_shop_id query string false "Coffee Shop ID"
+// @Param location query string false "Part of the shop location"
+// @Param resource_type query string false "meeting_room, desk or seat"
+// @Param amenities query []string false "Amenity codes the room must all offer" collectionFormat(csv)
+// @Param min_capacity query int false "Minimum capacity"
+// @Param max_capacity query int false "Maximum capacity"
+// @Param min_price query number false "Minimum price per price_unit"
+// @Param max_price query number false "Maximum price per price_unit"
+// @Param price_unit query string false "hour (default) or day; day-priced resources cost a whole day per hour"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Number of rooms to skip"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/meeting-room/search [get]
+func (h *Handler) SearchMeetingRooms(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.SearchMeetingRooms
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	rooms, err := h.meetingRoomUsecase.SearchMeetingRooms(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to search meeting rooms", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, rooms)
+}
+
+// GetAmenities godoc
+// @Summary Get amenities
+// @Description Get the amenities catalog used to describe and search rooms
+// @Tags meeting-room
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/meeting-room/amenities [get]
+func (h *Handler) GetAmenities(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	amenities, err := h.meetingRoomUsecase.GetAmenities(ctx)
+	if err != nil {
+		log.Errorw("Failed to get amenities", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get amenities")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, amenities)
+}
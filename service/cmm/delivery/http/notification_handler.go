@@ -0,0 +1,129 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/websocket"
+)
+
+// INotificationHandler defines notification handler methods
+type INotificationHandler interface {
+	GetMyNotifications(ctx *gin.Context)
+	MarkNotificationRead(ctx *gin.Context)
+	MarkAllNotificationsRead(ctx *gin.Context)
+	ServeNotificationWS(ctx *gin.Context)
+}
+
+// GetMyNotifications godoc
+// @Summary Get my notifications
+// @Description Get the current user's notifications, newest first. New notifications are also pushed to the user's websocket connections on /api/v1/notification/ws.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/notification [get]
+func (h *Handler) GetMyNotifications(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	unreadOnly := ctx.Query("unread") == "true"
+
+	notifications, err := h.notificationUsecase.GetMyNotifications(ctx, userID, unreadOnly)
+	if err != nil {
+		log.Errorw("Failed to get notifications", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get notifications")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Description Mark one of the current user's notifications as read
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/notification/{id}/read [post]
+func (h *Handler) MarkNotificationRead(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	notificationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid notification ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid notification ID")
+		return
+	}
+
+	if err := h.notificationUsecase.MarkRead(ctx, userID, notificationID); err != nil {
+		log.Errorw("Failed to mark notification as read", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark all of the current user's notifications as read
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/notification/read-all [post]
+func (h *Handler) MarkAllNotificationsRead(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	if err := h.notificationUsecase.MarkAllRead(ctx, userID); err != nil {
+		log.Errorw("Failed to mark notifications as read", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to mark notifications as read")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "All notifications marked as read"})
+}
+
+// ServeNotificationWS godoc
+// @Summary Notification WebSocket connection
+// @Description Establish a WebSocket connection that receives the current user's new notifications
+// @Tags notification
+// @Success 101 "Switching Protocols to WebSocket"
+// @Failure 401 {object} apiwrapper.APIResponse
+// @Router /api/v1/notification/ws [get]
+func (h *Handler) ServeNotificationWS(ctx *gin.Context) {
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	websocket.ServeNotifications(ctx, userID.String())
+}
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IPassHandler defines prepaid pass handler methods
+type IPassHandler interface {
+	CreatePassProduct(ctx *gin.Context)
+	GetShopPassProducts(ctx *gin.Context)
+	DeactivatePassProduct(ctx *gin.Context)
+	PurchasePass(ctx *gin.Context)
+	GetMyPasses(ctx *gin.Context)
+	GetMyPass(ctx *gin.Context)
+}
+
+// CreatePassProduct godoc
+// @Summary Create a pass
+// @Description Put a prepaid pass on sale for the owner's coffee shop, either a bundle of booking hours or unlimited bookings for a period
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.CreatePassProduct true "Pass details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/passes [post]
+func (h *Handler) CreatePassProduct(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.CreatePassProduct
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	product, err := h.passUsecase.CreatePassProduct(ctx, ownerID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to create pass", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, product)
+}
+
+// GetShopPassProducts godoc
+// @Summary Get shop passes
+// @Description Get the passes a coffee shop has on sale
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/passes [get]
+func (h *Handler) GetShopPassProducts(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	products, err := h.passUsecase.GetShopPassProducts(ctx, shopID)
+	if err != nil {
+		log.Errorw("Failed to get passes", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get passes")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, products)
+}
+
+// DeactivatePassProduct godoc
+// @Summary Take a pass off sale
+// @Description Stop selling a pass. Passes already bought stay valid until they expire.
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Param id path string true "Pass product ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/pass/product/{id}/deactivate [post]
+func (h *Handler) DeactivatePassProduct(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid pass ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid pass ID")
+		return
+	}
+
+	if err := h.passUsecase.DeactivatePassProduct(ctx, ownerID, productID); err != nil {
+		log.Errorw("Failed to deactivate pass", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Pass taken off sale"})
+}
+
+// PurchasePass godoc
+// @Summary Buy a pass
+// @Description Buy a coffee shop pass with the wallet balance. Pass the bought pass ID as pass_id when creating bookings.
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Param request body request.PurchasePass true "Pass to buy"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/pass/purchase [post]
+func (h *Handler) PurchasePass(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.PurchasePass
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	userPass, err := h.passUsecase.PurchasePass(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to purchase pass", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, userPass)
+}
+
+// GetMyPasses godoc
+// @Summary Get my passes
+// @Description Get the passes bought by the current user
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/pass/my-passes [get]
+func (h *Handler) GetMyPasses(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	userPasses, err := h.passUsecase.GetMyPasses(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get passes", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get passes")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, userPasses)
+}
+
+// GetMyPass godoc
+// @Summary Get my pass
+// @Description Get a pass of the current user with the bookings it paid for
+// @Tags pass
+// @Accept json
+// @Produce json
+// @Param id path string true "Pass ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/pass/my-passes/{id} [get]
+func (h *Handler) GetMyPass(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	userPassID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid pass ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid pass ID")
+		return
+	}
+
+	userPass, err := h.passUsecase.GetMyPass(ctx, userID, userPassID)
+	if err != nil {
+		log.Errorw("Failed to get pass", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, userPass)
+}
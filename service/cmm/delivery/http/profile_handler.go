@@ -0,0 +1,224 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IProfileHandler defines account profile handler methods
+type IProfileHandler interface {
+	GetMyProfile(ctx *gin.Context)
+	UpdateMyProfile(ctx *gin.Context)
+	UploadMyAvatar(ctx *gin.Context)
+	ChangePassword(ctx *gin.Context)
+	RequestEmailChange(ctx *gin.Context)
+	ConfirmEmailChange(ctx *gin.Context)
+}
+
+// GetMyProfile godoc
+// @Summary Get my profile
+// @Description Get the profile of the signed-in user, including a pending email change
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/me [get]
+func (h *Handler) GetMyProfile(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	result, err := h.profileUsecase.GetProfile(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get profile", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// UpdateMyProfile godoc
+// @Summary Update my profile
+// @Description Update the name, phone, avatar URL or preferred language of the signed-in user. Omitted fields are left unchanged
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.UpdateProfile true "Profile fields"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/me [put]
+func (h *Handler) UpdateMyProfile(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.UpdateProfile
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	result, err := h.profileUsecase.UpdateProfile(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to update profile", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// UploadMyAvatar godoc
+// @Summary Upload my avatar
+// @Description Upload a JPEG, PNG or GIF image as the avatar of the signed-in user. The image is resized and replaces the previous avatar
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image file"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/me/avatar [post]
+func (h *Handler) UploadMyAvatar(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	maxSize := limitUploadBody(ctx)
+	_, data, err := readUploadFile(ctx, maxSize)
+	if err != nil {
+		log.Errorw("Failed to read upload file", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	result, err := h.profileUsecase.UploadAvatar(ctx, userID, data)
+	if err != nil {
+		log.Errorw("Failed to upload avatar", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// ChangePassword godoc
+// @Summary Change my password
+// @Description Replace the password of the signed-in user after verifying the current password
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.ChangePassword true "Current and new password"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/me/password [post]
+func (h *Handler) ChangePassword(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.ChangePassword
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.profileUsecase.ChangePassword(ctx, userID, req); err != nil {
+		log.Errorw("Failed to change password", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Password changed successfully"})
+}
+
+// RequestEmailChange godoc
+// @Summary Request an email change
+// @Description Send a confirmation token to a new email address after verifying the current password. The email is changed once the token is confirmed
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.RequestEmailChange true "New email and current password"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/me/email [post]
+func (h *Handler) RequestEmailChange(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.RequestEmailChange
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.profileUsecase.RequestEmailChange(ctx, userID, req); err != nil {
+		log.Errorw("Failed to request email change", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Confirmation sent to the new email address"})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm an email change
+// @Description Switch the account to the new email address with the token sent to it. Tokens expire after 24 hours and can be used once
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.ConfirmEmailChange true "Confirmation token"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/email/confirm [post]
+func (h *Handler) ConfirmEmailChange(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ConfirmEmailChange
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.profileUsecase.ConfirmEmailChange(ctx, req); err != nil {
+		log.Errorw("Failed to confirm email change", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Email changed successfully"})
+}
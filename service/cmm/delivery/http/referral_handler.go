@@ -0,0 +1,42 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+)
+
+// IReferralHandler defines referral program handler methods
+type IReferralHandler interface {
+	GetMyReferrals(ctx *gin.Context)
+}
+
+// GetMyReferrals godoc
+// @Summary Get my referrals
+// @Description Get the current user's referral code, the users they referred and the rewards earned
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/user/referrals [get]
+func (h *Handler) GetMyReferrals(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	referrals, err := h.referralUsecase.GetMyReferrals(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get referrals", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, referrals)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IReportHandler defines admin report handler methods
+type IReportHandler interface {
+	GetPlatformOverviewReport(ctx *gin.Context)
+	GetTopShopsReport(ctx *gin.Context)
+	GetTopupVolumeReport(ctx *gin.Context)
+}
+
+// GetPlatformOverviewReport godoc
+// @Summary Platform overview report
+// @Description Get GMV, net revenue, active users, new registrations, refund rate, voucher cost and top-up volume (admin only)
+// @Tags report
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date, exclusive (YYYY-MM-DD)"
+// @Param format query string false "json or csv"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reports/overview [get]
+func (h *Handler) GetPlatformOverviewReport(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ReportQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	overview, err := h.reportUsecase.GetPlatformOverview(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to get platform overview", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	if req.Format == "csv" {
+		apiwrapper.SendCSV(ctx, reportFilename("overview", req),
+			[]string{"from", "to", "gmv", "net_revenue", "bookings", "refund_rate", "refund_amount", "voucher_cost", "active_users", "new_registrations", "topup_volume"},
+			[][]string{{
+				req.From.Format(reportDateFormat),
+				req.To.Format(reportDateFormat),
+				formatAmount(overview.GMV),
+				formatAmount(overview.NetRevenue),
+				strconv.FormatInt(overview.Bookings, 10),
+				formatAmount(overview.RefundRate),
+				formatAmount(overview.RefundAmount),
+				formatAmount(overview.VoucherCost),
+				strconv.FormatInt(overview.ActiveUsers, 10),
+				strconv.FormatInt(overview.NewRegistrations, 10),
+				formatAmount(overview.TopupVolume),
+			}})
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, overview)
+}
+
+// GetTopShopsReport godoc
+// @Summary Top shops report
+// @Description Get the shops with the highest GMV in a date range (admin only)
+// @Tags report
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date, exclusive (YYYY-MM-DD)"
+// @Param limit query int false "Number of shops (default 10)"
+// @Param format query string false "json or csv"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reports/top-shops [get]
+func (h *Handler) GetTopShopsReport(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ReportQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	shops, err := h.reportUsecase.GetTopShops(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to get top shops", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(shops))
+		for _, shop := range shops {
+			rows = append(rows, []string{
+				shop.CoffeeShopID.String(),
+				shop.ShopName,
+				strconv.FormatInt(shop.Bookings, 10),
+				formatAmount(shop.GMV),
+				formatAmount(shop.VoucherCost),
+			})
+		}
+		apiwrapper.SendCSV(ctx, reportFilename("top-shops", req),
+			[]string{"coffee_shop_id", "shop_name", "bookings", "gmv", "voucher_cost"}, rows)
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, shops)
+}
+
+// GetTopupVolumeReport godoc
+// @Summary Top-up volume report
+// @Description Get completed top-up count and amount by payment method (admin only)
+// @Tags report
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date, exclusive (YYYY-MM-DD)"
+// @Param format query string false "json or csv"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reports/topups [get]
+func (h *Handler) GetTopupVolumeReport(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ReportQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	methods, err := h.reportUsecase.GetTopupVolume(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to get top-up volume", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	if req.Format == "csv" {
+		rows := make([][]string, 0, len(methods))
+		for _, method := range methods {
+			rows = append(rows, []string{
+				method.Method,
+				strconv.FormatInt(method.Count, 10),
+				formatAmount(method.Amount),
+			})
+		}
+		apiwrapper.SendCSV(ctx, reportFilename("topups", req),
+			[]string{"method", "count", "amount"}, rows)
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, methods)
+}
+
+const reportDateFormat = "2006-01-02"
+
+func reportFilename(name string, req request.ReportQuery) string {
+	return fmt.Sprintf("%s_%s_%s.csv", name, req.From.Format(reportDateFormat), req.To.Format(reportDateFormat))
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
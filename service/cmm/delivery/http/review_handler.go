@@ -0,0 +1,293 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IReviewHandler defines review handler methods
+type IReviewHandler interface {
+	CreateReview(ctx *gin.Context)
+	GetMyReviews(ctx *gin.Context)
+	GetShopReviews(ctx *gin.Context)
+	GetRoomReviews(ctx *gin.Context)
+	ReplyToReview(ctx *gin.Context)
+	GetReviews(ctx *gin.Context)
+	HideReview(ctx *gin.Context)
+	RestoreReview(ctx *gin.Context)
+}
+
+// CreateReview godoc
+// @Summary Review a booking
+// @Description Rate a completed booking from 1 to 5 stars. Each booking can be reviewed once.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param request body request.CreateReview true "Review details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/review/create [post]
+func (h *Handler) CreateReview(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	customerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	customerID := customerIDStr.(uuid.UUID)
+
+	var req request.CreateReview
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	review, err := h.reviewUsecase.CreateReview(ctx, customerID, req)
+	if err != nil {
+		log.Errorw("Failed to create review", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, review)
+}
+
+// GetMyReviews godoc
+// @Summary Get my reviews
+// @Description Get the reviews written by the current user, including hidden ones
+// @Tags review
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/review/my-reviews [get]
+func (h *Handler) GetMyReviews(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	customerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	customerID := customerIDStr.(uuid.UUID)
+
+	reviews, err := h.reviewUsecase.GetMyReviews(ctx, customerID)
+	if err != nil {
+		log.Errorw("Failed to get reviews", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get reviews")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, reviews)
+}
+
+// GetShopReviews godoc
+// @Summary Get coffee shop reviews
+// @Description Get the published reviews of a coffee shop, newest first
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param shop_id path string true "Coffee Shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/review/coffee-shop/{shop_id} [get]
+func (h *Handler) GetShopReviews(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	shopID, err := uuid.Parse(ctx.Param("shop_id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	reviews, err := h.reviewUsecase.GetShopReviews(ctx, shopID)
+	if err != nil {
+		log.Errorw("Failed to get reviews", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get reviews")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, reviews)
+}
+
+// GetRoomReviews godoc
+// @Summary Get meeting room reviews
+// @Description Get the published reviews of a meeting room, newest first
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param room_id path string true "Room ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/review/meeting-room/{room_id} [get]
+func (h *Handler) GetRoomReviews(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	roomID, err := uuid.Parse(ctx.Param("room_id"))
+	if err != nil {
+		log.Errorw("Invalid room ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid room ID")
+		return
+	}
+
+	reviews, err := h.reviewUsecase.GetRoomReviews(ctx, roomID)
+	if err != nil {
+		log.Errorw("Failed to get reviews", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get reviews")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, reviews)
+}
+
+// ReplyToReview godoc
+// @Summary Reply to a review
+// @Description Post the shop owner's public reply to a review of their coffee shop
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param request body request.ReplyToReview true "Reply"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/review/{id}/reply [post]
+func (h *Handler) ReplyToReview(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	reviewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid review ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid review ID")
+		return
+	}
+
+	var req request.ReplyToReview
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.reviewUsecase.ReplyToReview(ctx, ownerID, reviewID, req); err != nil {
+		log.Errorw("Failed to reply to review", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Reply posted"})
+}
+
+// GetReviews godoc
+// @Summary Get reviews for moderation
+// @Description Get all reviews, optionally filtered by status (admin only)
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param status query string false "published or hidden"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reviews [get]
+func (h *Handler) GetReviews(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	reviews, err := h.reviewUsecase.GetReviews(ctx, ctx.Query("status"))
+	if err != nil {
+		log.Errorw("Failed to get reviews", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, reviews)
+}
+
+// HideReview godoc
+// @Summary Hide a review
+// @Description Hide a review from public listings and ratings with a reason (admin only)
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Param request body request.HideReview true "Moderation reason"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reviews/{id}/hide [post]
+func (h *Handler) HideReview(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	reviewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid review ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid review ID")
+		return
+	}
+
+	var req request.HideReview
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.reviewUsecase.HideReview(ctx, adminID, reviewID, req); err != nil {
+		log.Errorw("Failed to hide review", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Review hidden"})
+}
+
+// RestoreReview godoc
+// @Summary Restore a review
+// @Description Publish a hidden review again (admin only)
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path string true "Review ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/reviews/{id}/restore [post]
+func (h *Handler) RestoreReview(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	reviewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid review ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid review ID")
+		return
+	}
+
+	if err := h.reviewUsecase.RestoreReview(ctx, adminID, reviewID); err != nil {
+		log.Errorw("Failed to restore review", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Review restored"})
+}
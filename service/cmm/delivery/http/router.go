@@ -37,6 +37,40 @@ func (p *routerImpl) Register(r gin.IRouter) {
 	adminApi := api.Group("admin")
 	{
 		adminApi.POST("/create-account", p.handler.CreateAccount)
+
+		// Settlement and payouts
+		adminApi.POST("/settlement/run", p.handler.RunSettlement)
+		adminApi.GET("/settlement/all", p.handler.GetAllSettlements)
+		adminApi.GET("/settlement/:id", p.handler.GetSettlement)
+		adminApi.GET("/payout/:id", p.handler.GetPayoutStatement)
+		adminApi.POST("/payout/:id/mark-paid", p.handler.MarkPayoutPaid)
+
+		// Withdrawals
+		adminApi.GET("/withdrawals", p.handler.GetWithdrawals)
+		adminApi.GET("/withdrawals/:id", p.handler.GetWithdrawal)
+		adminApi.POST("/withdrawals/:id/approve", p.handler.ApproveWithdrawal)
+		adminApi.POST("/withdrawals/:id/mark-paid", p.handler.MarkWithdrawalPaid)
+		adminApi.POST("/withdrawals/:id/reject", p.handler.RejectWithdrawal)
+
+		// Shop verification
+		adminApi.GET("/coffee-shops", p.handler.GetShopVerifications)
+		adminApi.GET("/coffee-shops/:id", p.handler.GetShopVerification)
+		adminApi.POST("/coffee-shops/:id/approve", p.handler.ApproveShop)
+		adminApi.POST("/coffee-shops/:id/reject", p.handler.RejectShop)
+
+		// Review moderation
+		adminApi.GET("/reviews", p.handler.GetReviews)
+		adminApi.POST("/reviews/:id/hide", p.handler.HideReview)
+		adminApi.POST("/reviews/:id/restore", p.handler.RestoreReview)
+
+		// Reports
+		adminApi.GET("/reports/overview", p.handler.GetPlatformOverviewReport)
+		adminApi.GET("/reports/top-shops", p.handler.GetTopShopsReport)
+		adminApi.GET("/reports/topups", p.handler.GetTopupVolumeReport)
+
+		// Login security
+		adminApi.GET("/security-events", p.handler.GetSecurityEvents)
+		adminApi.POST("/security-events/:id/review", p.handler.ReviewSecurityEvent)
 	}
 
 	// User routes
@@ -44,34 +78,90 @@ func (p *routerImpl) Register(r gin.IRouter) {
 	{
 		userApi.POST("/login", p.handler.Login)
 		userApi.POST("/register", p.handler.Register)
+		userApi.POST("/verify-email", p.handler.VerifyEmail)
+		userApi.POST("/verify-email/resend", p.handler.ResendVerification)
+		userApi.POST("/password/forgot", p.handler.ForgotPassword)
+		userApi.POST("/password/reset", p.handler.ResetPassword)
+		userApi.GET("/referrals", p.handler.GetMyReferrals)
+		userApi.GET("/me", p.handler.GetMyProfile)
+		userApi.PUT("/me", p.handler.UpdateMyProfile)
+		userApi.POST("/me/avatar", p.handler.UploadMyAvatar)
+		userApi.POST("/me/password", p.handler.ChangePassword)
+		userApi.POST("/me/email", p.handler.RequestEmailChange)
+		userApi.POST("/email/confirm", p.handler.ConfirmEmailChange)
 	}
 
 	// Coffee Shop routes
 	coffeeShopApi := api.Group("coffee-shop")
 	{
 		coffeeShopApi.GET("/all", p.handler.GetAllCoffeeShops)
+		coffeeShopApi.GET("/nearby", p.handler.GetNearbyCoffeeShops)
 		coffeeShopApi.GET("/:id", p.handler.GetCoffeeShop)
 		coffeeShopApi.GET("/commission/:shop_id", p.handler.GetCommissionRate)
 
 		// Protected routes (require authentication)
 		coffeeShopApi.POST("/create", p.handler.CreateCoffeeShop)
 		coffeeShopApi.GET("/my-shops", p.handler.GetMyCoffeeShops)
+		coffeeShopApi.GET("/following", p.handler.GetFollowedShops)
 		coffeeShopApi.PUT("/update", p.handler.UpdateCoffeeShop)
 		coffeeShopApi.DELETE("/:id", p.handler.DeleteCoffeeShop)
 		coffeeShopApi.POST("/commission/set", p.handler.SetCommissionRate)
+		coffeeShopApi.GET("/payouts", p.handler.GetMyPayoutStatements)
+		coffeeShopApi.GET("/payouts/:id", p.handler.GetMyPayoutStatement)
+		coffeeShopApi.GET("/:id/dashboard", p.handler.GetShopDashboard)
+		coffeeShopApi.GET("/:id/dashboard/occupancy", p.handler.GetShopOccupancy)
+		coffeeShopApi.POST("/:id/vouchers", p.handler.CreateShopVoucher)
+		coffeeShopApi.GET("/:id/vouchers", p.handler.GetShopVouchers)
+		coffeeShopApi.PUT("/vouchers/:id", p.handler.UpdateShopVoucher)
+		coffeeShopApi.POST("/vouchers/:id/deactivate", p.handler.DeactivateShopVoucher)
+		coffeeShopApi.POST("/:id/passes", p.handler.CreatePassProduct)
+		coffeeShopApi.GET("/:id/passes", p.handler.GetShopPassProducts)
+		coffeeShopApi.POST("/:id/floor-zones", p.handler.CreateFloorZone)
+		coffeeShopApi.GET("/:id/floor-zones", p.handler.GetFloorZones)
+		coffeeShopApi.POST("/:id/follow", p.handler.FollowShop)
+		coffeeShopApi.DELETE("/:id/follow", p.handler.UnfollowShop)
+		coffeeShopApi.GET("/:id/followers", p.handler.GetShopFollowerCount)
+		coffeeShopApi.GET("/:id/verification", p.handler.GetMyShopVerification)
+		coffeeShopApi.POST("/:id/verification", p.handler.SubmitShopVerification)
+		coffeeShopApi.POST("/:id/verification/documents", p.handler.UploadShopDocument)
+		coffeeShopApi.DELETE("/verification/documents/:id", p.handler.DeleteShopDocument)
+		coffeeShopApi.POST("/:id/staff", p.handler.InviteStaff)
+		coffeeShopApi.GET("/:id/staff", p.handler.GetShopStaff)
+		coffeeShopApi.PUT("/staff/:id", p.handler.UpdateStaffPermissions)
+		coffeeShopApi.DELETE("/staff/:id", p.handler.RemoveStaff)
+	}
+
+	// Staff routes
+	staffApi := api.Group("staff")
+	{
+		staffApi.GET("/invitations", p.handler.GetMyStaffInvitations)
+		staffApi.POST("/invitations/:id/accept", p.handler.AcceptStaffInvitation)
+		staffApi.POST("/invitations/:id/decline", p.handler.DeclineStaffInvitation)
+		staffApi.GET("/shops", p.handler.GetMyStaffShops)
 	}
 
 	// Meeting Room routes
 	meetingRoomApi := api.Group("meeting-room")
 	{
+		meetingRoomApi.GET("/search", p.handler.SearchMeetingRooms)
+		meetingRoomApi.GET("/amenities", p.handler.GetAmenities)
 		meetingRoomApi.GET("/:id", p.handler.GetMeetingRoom)
 		meetingRoomApi.GET("/shop/:shop_id", p.handler.GetMeetingRoomsByCoffeeShop)
 		meetingRoomApi.GET("/shop/:shop_id/available", p.handler.GetAvailableMeetingRooms)
+		meetingRoomApi.GET("/shop/:shop_id/availability", p.handler.GetResourceAvailability)
 
 		// Protected routes
 		meetingRoomApi.POST("/create", p.handler.CreateMeetingRoom)
 		meetingRoomApi.PUT("/update", p.handler.UpdateMeetingRoom)
 		meetingRoomApi.DELETE("/:id", p.handler.DeleteMeetingRoom)
+		meetingRoomApi.POST("/bulk-create", p.handler.BulkCreateResources)
+	}
+
+	// Floor zone routes
+	floorZoneApi := api.Group("floor-zone")
+	{
+		floorZoneApi.PUT("/:id", p.handler.UpdateFloorZone)
+		floorZoneApi.DELETE("/:id", p.handler.DeleteFloorZone)
 	}
 
 	// Booking routes
@@ -81,6 +171,7 @@ func (p *routerImpl) Register(r gin.IRouter) {
 		bookingApi.GET("/:id", p.handler.GetBooking)
 		bookingApi.GET("/my-bookings", p.handler.GetMyBookings)
 		bookingApi.POST("/:id/cancel", p.handler.CancelBooking)
+		bookingApi.POST("/:id/complete", p.handler.CompleteBooking)
 		bookingApi.GET("/room/:room_id", p.handler.GetRoomBookings)
 	}
 
@@ -92,6 +183,65 @@ func (p *routerImpl) Register(r gin.IRouter) {
 		walletApi.POST("/topup/confirm", p.handler.ConfirmTopup) // Admin only
 		walletApi.GET("/topup/history", p.handler.GetTopupHistory)
 		walletApi.GET("/transactions", p.handler.GetTransactionHistory)
+		walletApi.POST("/transfer", p.handler.TransferWallet)
+		walletApi.GET("/transfers", p.handler.GetTransferHistory)
+		walletApi.GET("/points", p.handler.GetLoyaltySummary)
+		walletApi.GET("/points/history", p.handler.GetPointsHistory)
+
+		// Owner withdrawals
+		walletApi.POST("/payout-accounts", p.handler.CreatePayoutAccount)
+		walletApi.GET("/payout-accounts", p.handler.GetMyPayoutAccounts)
+		walletApi.DELETE("/payout-accounts/:id", p.handler.DeletePayoutAccount)
+		walletApi.POST("/withdraw", p.handler.RequestWithdrawal)
+		walletApi.GET("/withdrawals", p.handler.GetMyWithdrawals)
+	}
+
+	// Pass routes
+	passApi := api.Group("pass")
+	{
+		passApi.POST("/purchase", p.handler.PurchasePass)
+		passApi.GET("/my-passes", p.handler.GetMyPasses)
+		passApi.GET("/my-passes/:id", p.handler.GetMyPass)
+
+		// Owner routes
+		passApi.POST("/product/:id/deactivate", p.handler.DeactivatePassProduct)
+	}
+
+	// Review routes
+	reviewApi := api.Group("review")
+	{
+		reviewApi.GET("/coffee-shop/:shop_id", p.handler.GetShopReviews)
+		reviewApi.GET("/meeting-room/:room_id", p.handler.GetRoomReviews)
+
+		// Protected routes
+		reviewApi.POST("/create", p.handler.CreateReview)
+		reviewApi.GET("/my-reviews", p.handler.GetMyReviews)
+		reviewApi.POST("/:id/reply", p.handler.ReplyToReview)
+	}
+
+	// Search routes
+	searchApi := api.Group("search")
+	{
+		searchApi.GET("", p.handler.Search)
+	}
+
+	// Media routes
+	mediaApi := api.Group("media")
+	{
+		mediaApi.GET("/:owner_type/:owner_id", p.handler.GetGallery)
+
+		// Protected routes
+		mediaApi.POST("/upload", p.handler.UploadMedia)
+		mediaApi.DELETE("/:id", p.handler.DeleteMedia)
+	}
+
+	// Notification routes
+	notificationApi := api.Group("notification")
+	{
+		notificationApi.GET("", p.handler.GetMyNotifications)
+		notificationApi.POST("/:id/read", p.handler.MarkNotificationRead)
+		notificationApi.POST("/read-all", p.handler.MarkAllNotificationsRead)
+		notificationApi.GET("/ws", p.handler.ServeNotificationWS)
 	}
 
 	// Voucher routes
@@ -106,11 +256,20 @@ func (p *routerImpl) Register(r gin.IRouter) {
 		voucherApi.GET("/all", p.handler.GetAllVouchers)
 		voucherApi.PUT("/update", p.handler.UpdateVoucher)
 		voucherApi.DELETE("/:id", p.handler.DeleteVoucher)
+		voucherApi.GET("/:id/redemptions", p.handler.GetVoucherRedemptions)
+
+		// Campaigns (admin)
+		voucherApi.POST("/campaign/create", p.handler.CreateVoucherCampaign)
+		voucherApi.GET("/campaign/all", p.handler.GetAllVoucherCampaigns)
+		voucherApi.GET("/campaign/:id", p.handler.GetVoucherCampaign)
+		voucherApi.GET("/campaign/:id/export", p.handler.ExportVoucherCampaignCodes)
 	}
 
 	// Post routes
 	postApi := api.Group("post")
 	{
+		postApi.GET("/feed", p.handler.GetFeed)
+
 		// Shop posts
 		shopPostApi := postApi.Group("/shop")
 		{
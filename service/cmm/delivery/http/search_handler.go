@@ -0,0 +1,45 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// ISearchHandler defines search handler methods
+type ISearchHandler interface {
+	Search(ctx *gin.Context)
+}
+
+// Search godoc
+// @Summary Search shops, rooms and posts
+// @Description Full-text search over coffee shops, meeting rooms and shop posts, ranked by relevance with matches wrapped in <mark> tags. Matching ignores Vietnamese diacritics.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text"
+// @Param type query string false "shops, rooms or posts"
+// @Param limit query int false "Maximum results per kind (default 10, max 50)"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/search [get]
+func (h *Handler) Search(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.Search
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	result, err := h.searchUsecase.Search(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to search", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
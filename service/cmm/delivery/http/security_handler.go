@@ -0,0 +1,85 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// ISecurityHandler defines security event review handler methods
+type ISecurityHandler interface {
+	GetSecurityEvents(ctx *gin.Context)
+	ReviewSecurityEvent(ctx *gin.Context)
+}
+
+// GetSecurityEvents godoc
+// @Summary Get security events
+// @Description Get login lockouts and logins from new IPs, newest first (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param type query string false "Event type" Enums(account_locked, ip_locked, new_ip_login)
+// @Param user_id query string false "User ID"
+// @Param unreviewed query bool false "Only events not reviewed yet"
+// @Param limit query int false "Page size, 50 by default"
+// @Param offset query int false "Number of events to skip"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/security-events [get]
+func (h *Handler) GetSecurityEvents(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.SecurityEventQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	result, err := h.securityUsecase.GetSecurityEvents(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to get security events", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to retrieve security events")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// ReviewSecurityEvent godoc
+// @Summary Review a security event
+// @Description Mark a security event as reviewed (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Security event ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/security-events/{id}/review [post]
+func (h *Handler) ReviewSecurityEvent(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	eventID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid security event ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid security event ID")
+		return
+	}
+
+	if err := h.securityUsecase.ReviewSecurityEvent(ctx, adminID, eventID); err != nil {
+		log.Errorw("Failed to review security event", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Security event reviewed"})
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/usecase"
+)
+
+// ISettlementHandler defines settlement and payout handler methods
+type ISettlementHandler interface {
+	RunSettlement(ctx *gin.Context)
+	GetAllSettlements(ctx *gin.Context)
+	GetSettlement(ctx *gin.Context)
+	GetPayoutStatement(ctx *gin.Context)
+	MarkPayoutPaid(ctx *gin.Context)
+	GetMyPayoutStatements(ctx *gin.Context)
+	GetMyPayoutStatement(ctx *gin.Context)
+}
+
+// RunSettlement godoc
+// @Summary Run a settlement
+// @Description Settle all unsettled earnings in a period into payout statements per owner (admin only)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param request body request.RunSettlement true "Settlement period"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/settlement/run [post]
+func (h *Handler) RunSettlement(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	var req request.RunSettlement
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	settlement, err := h.settlementUsecase.RunSettlement(ctx, adminID, req)
+	if err != nil {
+		log.Errorw("Failed to run settlement", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, settlement)
+}
+
+// GetAllSettlements godoc
+// @Summary Get all settlements
+// @Description Get all settlement runs (admin only)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/settlement/all [get]
+func (h *Handler) GetAllSettlements(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	settlements, err := h.settlementUsecase.GetAllSettlements(ctx, adminID)
+	if err != nil {
+		log.Errorw("Failed to get settlements", "error", err)
+		if errors.Is(err, usecase.ErrAdminOnly) {
+			apiwrapper.SendUnauthorized(ctx, err.Error())
+			return
+		}
+		apiwrapper.SendInternalError(ctx, "Failed to get settlements")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, settlements)
+}
+
+// GetSettlement godoc
+// @Summary Get settlement details
+// @Description Get a settlement run with its payout statements (admin only)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param id path string true "Settlement ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/settlement/{id} [get]
+func (h *Handler) GetSettlement(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	settlementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid settlement ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid settlement ID")
+		return
+	}
+
+	settlement, err := h.settlementUsecase.GetSettlement(ctx, adminID, settlementID)
+	if err != nil {
+		log.Errorw("Failed to get settlement", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, settlement)
+}
+
+// GetPayoutStatement godoc
+// @Summary Get payout statement
+// @Description Get a payout statement with its line items (admin only)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout statement ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/payout/{id} [get]
+func (h *Handler) GetPayoutStatement(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	statementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid payout statement ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid payout statement ID")
+		return
+	}
+
+	statement, err := h.settlementUsecase.GetPayoutStatement(ctx, adminID, statementID)
+	if err != nil {
+		log.Errorw("Failed to get payout statement", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, statement)
+}
+
+// MarkPayoutPaid godoc
+// @Summary Mark payout as paid
+// @Description Mark a payout statement as paid and credit the owner's wallet (admin only)
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout statement ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/payout/{id}/mark-paid [post]
+func (h *Handler) MarkPayoutPaid(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	statementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid payout statement ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid payout statement ID")
+		return
+	}
+
+	err = h.settlementUsecase.MarkStatementPaid(ctx, adminID, statementID)
+	if err != nil {
+		log.Errorw("Failed to mark payout as paid", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Payout marked as paid"})
+}
+
+// GetMyPayoutStatements godoc
+// @Summary Get my payout statements
+// @Description Get all payout statements for the current owner
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/payouts [get]
+func (h *Handler) GetMyPayoutStatements(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	statements, err := h.settlementUsecase.GetOwnerPayoutStatements(ctx, ownerID)
+	if err != nil {
+		log.Errorw("Failed to get payout statements", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get payout statements")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, statements)
+}
+
+// GetMyPayoutStatement godoc
+// @Summary Get my payout statement
+// @Description Get one of the current owner's payout statements with its line items
+// @Tags settlement
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout statement ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/payouts/{id} [get]
+func (h *Handler) GetMyPayoutStatement(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	statementID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid payout statement ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid payout statement ID")
+		return
+	}
+
+	statement, err := h.settlementUsecase.GetOwnerPayoutStatement(ctx, ownerID, statementID)
+	if err != nil {
+		log.Errorw("Failed to get payout statement", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, statement)
+}
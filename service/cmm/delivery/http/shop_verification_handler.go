@@ -0,0 +1,334 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IShopVerificationHandler defines shop onboarding and verification handler methods
+type IShopVerificationHandler interface {
+	UploadShopDocument(ctx *gin.Context)
+	DeleteShopDocument(ctx *gin.Context)
+	SubmitShopVerification(ctx *gin.Context)
+	GetMyShopVerification(ctx *gin.Context)
+	GetShopVerifications(ctx *gin.Context)
+	GetShopVerification(ctx *gin.Context)
+	ApproveShop(ctx *gin.Context)
+	RejectShop(ctx *gin.Context)
+}
+
+// UploadShopDocument godoc
+// @Summary Upload a verification document
+// @Description Attach a JPEG, PNG or PDF business document to a pending or rejected coffee shop
+// @Tags shop-verification
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param document_type formData string true "Document type" Enums(business_license, identity_card, other)
+// @Param file formData file true "Document file"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/verification/documents [post]
+func (h *Handler) UploadShopDocument(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	maxSize := limitUploadBody(ctx)
+
+	var req request.UploadShopDocument
+	if err := ctx.ShouldBind(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	fileName, data, err := readUploadFile(ctx, maxSize)
+	if err != nil {
+		log.Errorw("Failed to read upload file", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	result, err := h.shopVerificationUsecase.UploadShopDocument(ctx, userID, shopID, req, fileName, data)
+	if err != nil {
+		log.Errorw("Failed to upload shop document", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// DeleteShopDocument godoc
+// @Summary Delete a verification document
+// @Description Remove a document from a pending or rejected coffee shop
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Document ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/verification/documents/{id} [delete]
+func (h *Handler) DeleteShopDocument(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	documentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid document ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid document ID")
+		return
+	}
+
+	if err := h.shopVerificationUsecase.DeleteShopDocument(ctx, userID, documentID); err != nil {
+		log.Errorw("Failed to delete shop document", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Document deleted successfully"})
+}
+
+// SubmitShopVerification godoc
+// @Summary Submit a coffee shop for verification
+// @Description Send the business details of a pending or rejected coffee shop to the admins for review. At least one document must be uploaded first
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.SubmitShopVerification true "Business details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/verification [post]
+func (h *Handler) SubmitShopVerification(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.SubmitShopVerification
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	result, err := h.shopVerificationUsecase.SubmitShopVerification(ctx, userID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to submit shop verification", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// GetMyShopVerification godoc
+// @Summary Get a coffee shop's verification
+// @Description Get the verification status, submitted details, documents and any rejection reason of an owned coffee shop
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/verification [get]
+func (h *Handler) GetMyShopVerification(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	result, err := h.shopVerificationUsecase.GetMyShopVerification(ctx, userID, shopID)
+	if err != nil {
+		log.Errorw("Failed to get shop verification", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// GetShopVerifications godoc
+// @Summary List shops by verification status
+// @Description List coffee shops in a verification status, oldest first; submitted shops by default (admin only)
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param status query string false "Verification status" Enums(pending, submitted, approved, rejected)
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/coffee-shops [get]
+func (h *Handler) GetShopVerifications(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ShopVerificationQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		log.Errorw("Invalid query parameters", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	result, err := h.shopVerificationUsecase.GetShopVerifications(ctx, req)
+	if err != nil {
+		log.Errorw("Failed to get shop verifications", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to retrieve coffee shops")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// GetShopVerification godoc
+// @Summary Get a shop's verification
+// @Description Get the submitted business details and documents of a coffee shop (admin only)
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/coffee-shops/{id} [get]
+func (h *Handler) GetShopVerification(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	result, err := h.shopVerificationUsecase.GetShopVerification(ctx, shopID)
+	if err != nil {
+		log.Errorw("Failed to get shop verification", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// ApproveShop godoc
+// @Summary Approve a coffee shop
+// @Description Approve a submitted coffee shop so it is listed publicly and takes bookings (admin only)
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/coffee-shops/{id}/approve [post]
+func (h *Handler) ApproveShop(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	if err := h.shopVerificationUsecase.ApproveShop(ctx, adminID, shopID); err != nil {
+		log.Errorw("Failed to approve coffee shop", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Coffee shop approved"})
+}
+
+// RejectShop godoc
+// @Summary Reject a coffee shop
+// @Description Reject a submitted coffee shop with a reason; the owner can correct it and submit again (admin only)
+// @Tags shop-verification
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.RejectShop true "Rejection reason"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/coffee-shops/{id}/reject [post]
+func (h *Handler) RejectShop(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.RejectShop
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.shopVerificationUsecase.RejectShop(ctx, adminID, shopID, req); err != nil {
+		log.Errorw("Failed to reject coffee shop", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Coffee shop rejected"})
+}
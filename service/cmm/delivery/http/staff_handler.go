@@ -0,0 +1,313 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+)
+
+// IStaffHandler defines shop staff handler methods
+type IStaffHandler interface {
+	InviteStaff(ctx *gin.Context)
+	GetShopStaff(ctx *gin.Context)
+	UpdateStaffPermissions(ctx *gin.Context)
+	RemoveStaff(ctx *gin.Context)
+	GetMyStaffInvitations(ctx *gin.Context)
+	AcceptStaffInvitation(ctx *gin.Context)
+	DeclineStaffInvitation(ctx *gin.Context)
+	GetMyStaffShops(ctx *gin.Context)
+}
+
+// InviteStaff godoc
+// @Summary Invite a staff member
+// @Description Invite a registered user by email to work at a coffee shop with the given permissions (owner only)
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.InviteStaff true "Invitation"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/staff [post]
+func (h *Handler) InviteStaff(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.InviteStaff
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	result, err := h.staffUsecase.InviteStaff(ctx, userID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to invite staff", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// GetShopStaff godoc
+// @Summary Get the staff of a coffee shop
+// @Description Get the staff members and pending invitations of a coffee shop (owner only)
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/staff [get]
+func (h *Handler) GetShopStaff(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	result, err := h.staffUsecase.GetShopStaff(ctx, userID, shopID)
+	if err != nil {
+		log.Errorw("Failed to get shop staff", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// UpdateStaffPermissions godoc
+// @Summary Update staff permissions
+// @Description Replace the permissions of a staff member or pending invitation (owner only)
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Staff ID"
+// @Param request body request.UpdateStaffPermissions true "Permissions"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/staff/{id} [put]
+func (h *Handler) UpdateStaffPermissions(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	staffID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid staff ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid staff ID")
+		return
+	}
+
+	var req request.UpdateStaffPermissions
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.staffUsecase.UpdateStaffPermissions(ctx, userID, staffID, req); err != nil {
+		log.Errorw("Failed to update staff permissions", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Staff permissions updated successfully"})
+}
+
+// RemoveStaff godoc
+// @Summary Remove a staff member
+// @Description Remove a staff member or cancel an invitation (owner), or leave a coffee shop (staff member)
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Staff ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/staff/{id} [delete]
+func (h *Handler) RemoveStaff(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	staffID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid staff ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid staff ID")
+		return
+	}
+
+	if err := h.staffUsecase.RemoveStaff(ctx, userID, staffID); err != nil {
+		log.Errorw("Failed to remove staff", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Staff member removed successfully"})
+}
+
+// GetMyStaffInvitations godoc
+// @Summary Get my staff invitations
+// @Description Get the pending invitations to join the staff of a coffee shop
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 500 {object} apiwrapper.APIResponse
+// @Router /api/v1/staff/invitations [get]
+func (h *Handler) GetMyStaffInvitations(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	result, err := h.staffUsecase.GetMyInvitations(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get staff invitations", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to retrieve invitations")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
+
+// AcceptStaffInvitation godoc
+// @Summary Accept a staff invitation
+// @Description Join the staff of a coffee shop with the permissions granted in the invitation
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/staff/invitations/{id}/accept [post]
+func (h *Handler) AcceptStaffInvitation(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	staffID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid invitation ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.staffUsecase.AcceptInvitation(ctx, userID, staffID); err != nil {
+		log.Errorw("Failed to accept staff invitation", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Invitation accepted"})
+}
+
+// DeclineStaffInvitation godoc
+// @Summary Decline a staff invitation
+// @Description Decline an invitation to join the staff of a coffee shop
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/staff/invitations/{id}/decline [post]
+func (h *Handler) DeclineStaffInvitation(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	staffID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid invitation ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.staffUsecase.DeclineInvitation(ctx, userID, staffID); err != nil {
+		log.Errorw("Failed to decline staff invitation", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Invitation declined"})
+}
+
+// GetMyStaffShops godoc
+// @Summary Get the shops I work at
+// @Description Get the coffee shops the user is staff of, with the permissions granted at each
+// @Tags staff
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 500 {object} apiwrapper.APIResponse
+// @Router /api/v1/staff/shops [get]
+func (h *Handler) GetMyStaffShops(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	result, err := h.staffUsecase.GetMyStaffShops(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get staff shops", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to retrieve coffee shops")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, result)
+}
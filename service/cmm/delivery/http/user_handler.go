@@ -1,21 +1,30 @@
 package http
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	pkgerrors "github.com/leehai1107/cmm_server/pkg/errors"
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/usecase"
 )
 
 // IUserHandler defines user-related handler methods
 type IUserHandler interface {
 	Login(ctx *gin.Context)
 	Register(ctx *gin.Context)
+	VerifyEmail(ctx *gin.Context)
+	ResendVerification(ctx *gin.Context)
+	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
 }
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate a user and return a token
+// @Description Authenticate a user and return a token. Repeated failures lock out the account or client IP for a growing period
 // @Tags user
 // @Accept json
 // @Produce json
@@ -23,6 +32,7 @@ type IUserHandler interface {
 // @Success 200 {object} apiwrapper.APIResponse "Success response with token"
 // @Failure 400 {object} apiwrapper.APIResponse "Bad request"
 // @Failure 401 {object} apiwrapper.APIResponse "Unauthorized"
+// @Failure 429 {object} apiwrapper.APIResponse "Too many failed attempts"
 // @Router /internal/api/v1/user/login [post]
 func (h *Handler) Login(ctx *gin.Context) {
 	var req request.Login
@@ -31,9 +41,13 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := h.userUsecase.Login(ctx, req)
+	token, err := h.userUsecase.Login(ctx, req, ctx.ClientIP(), ctx.Request.UserAgent())
 	if err != nil {
 		logger.EnhanceWith(ctx).Errorw("Login failed", "error", err, "email", req.Email)
+		if errors.Is(err, usecase.ErrLoginLocked) {
+			apiwrapper.SendError(ctx, http.StatusTooManyRequests, pkgerrors.AuthenticationFailed, err.Error())
+			return
+		}
 		apiwrapper.SendUnauthorized(ctx, "Login failed")
 		return
 	}
@@ -88,3 +102,119 @@ func (h *Handler) Register(ctx *gin.Context) {
 
 	apiwrapper.SendSuccess(ctx, nil)
 }
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Verify the email address of an account with the token from the verification email. Tokens can be used once
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmail true "Verification token"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/verify-email [post]
+func (h *Handler) VerifyEmail(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.VerifyEmail
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.userUsecase.VerifyEmail(ctx, req); err != nil {
+		log.Errorw("Failed to verify email", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new verification email to an unverified account. The response is the same whether or not the email is registered
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.ResendVerification true "Account email"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/verify-email/resend [post]
+func (h *Handler) ResendVerification(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ResendVerification
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.userUsecase.ResendVerification(ctx, req); err != nil {
+		log.Errorw("Failed to resend verification email", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to resend verification email")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "If the account needs verification, a new email has been sent"})
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description Email a password reset link. The response is the same whether or not the email is registered
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.ForgotPassword true "Account email"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/password/forgot [post]
+func (h *Handler) ForgotPassword(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ForgotPassword
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.userUsecase.ForgotPassword(ctx, req); err != nil {
+		log.Errorw("Failed to process forgot password", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to send password reset email")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from the password reset email. Tokens expire and can be used once
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPassword true "Reset token and new password"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /internal/api/v1/user/password/reset [post]
+func (h *Handler) ResetPassword(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	var req request.ResetPassword
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.userUsecase.ResetPassword(ctx, req); err != nil {
+		log.Errorw("Failed to reset password", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Password reset successfully"})
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/usecase"
+)
+
+// IVoucherCampaignHandler defines voucher campaign handler methods
+type IVoucherCampaignHandler interface {
+	CreateVoucherCampaign(ctx *gin.Context)
+	GetAllVoucherCampaigns(ctx *gin.Context)
+	GetVoucherCampaign(ctx *gin.Context)
+	ExportVoucherCampaignCodes(ctx *gin.Context)
+}
+
+// CreateVoucherCampaign godoc
+// @Summary Create a voucher campaign
+// @Description Create a campaign and generate its unique single-use voucher codes (admin only)
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param request body request.CreateVoucherCampaign true "Campaign details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/voucher/campaign/create [post]
+func (h *Handler) CreateVoucherCampaign(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	var req request.CreateVoucherCampaign
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	campaign, err := h.voucherCampaignUsecase.CreateCampaign(ctx, adminID, req)
+	if err != nil {
+		log.Errorw("Failed to create voucher campaign", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, campaign)
+}
+
+// GetAllVoucherCampaigns godoc
+// @Summary Get all voucher campaigns
+// @Description Get all voucher campaigns with redeemed and outstanding code counts (admin only)
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/voucher/campaign/all [get]
+func (h *Handler) GetAllVoucherCampaigns(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	campaigns, err := h.voucherCampaignUsecase.GetAllCampaigns(ctx, adminID)
+	if err != nil {
+		log.Errorw("Failed to get voucher campaigns", "error", err)
+		if errors.Is(err, usecase.ErrAdminOnly) {
+			apiwrapper.SendUnauthorized(ctx, err.Error())
+			return
+		}
+		apiwrapper.SendInternalError(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, campaigns)
+}
+
+// GetVoucherCampaign godoc
+// @Summary Get voucher campaign
+// @Description Get a voucher campaign with redeemed and outstanding code counts (admin only)
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Campaign ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/voucher/campaign/{id} [get]
+func (h *Handler) GetVoucherCampaign(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	campaignID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid campaign ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid campaign ID")
+		return
+	}
+
+	campaign, err := h.voucherCampaignUsecase.GetCampaign(ctx, adminID, campaignID)
+	if err != nil {
+		log.Errorw("Failed to get voucher campaign", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, campaign)
+}
+
+// ExportVoucherCampaignCodes godoc
+// @Summary Export voucher campaign codes
+// @Description Download every code of a voucher campaign as CSV (admin only)
+// @Tags voucher
+// @Produce text/csv
+// @Param id path string true "Campaign ID"
+// @Success 200 {file} file
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/voucher/campaign/{id}/export [get]
+func (h *Handler) ExportVoucherCampaignCodes(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	campaignID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid campaign ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid campaign ID")
+		return
+	}
+
+	campaign, codes, err := h.voucherCampaignUsecase.GetCampaignCodes(ctx, adminID, campaignID)
+	if err != nil {
+		log.Errorw("Failed to get voucher campaign codes", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	rows := make([][]string, 0, len(codes))
+	for _, code := range codes {
+		status := "outstanding"
+		if code.UsedCount > 0 {
+			status = "redeemed"
+		}
+		rows = append(rows, []string{
+			code.Code,
+			status,
+			strconv.Itoa(code.UsedCount),
+			strconv.Itoa(code.MaxUses),
+			code.ValidFrom.Format(time.RFC3339),
+			code.ValidTo.Format(time.RFC3339),
+		})
+	}
+
+	apiwrapper.SendCSV(ctx, fmt.Sprintf("voucher_campaign_%s.csv", campaign.ID),
+		[]string{"code", "status", "used_count", "max_uses", "valid_from", "valid_to"}, rows)
+}
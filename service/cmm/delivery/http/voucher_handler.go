@@ -17,6 +17,11 @@ type IVoucherHandler interface {
 	UpdateVoucher(ctx *gin.Context)
 	DeleteVoucher(ctx *gin.Context)
 	ApplyVoucher(ctx *gin.Context)
+	GetVoucherRedemptions(ctx *gin.Context)
+	CreateShopVoucher(ctx *gin.Context)
+	GetShopVouchers(ctx *gin.Context)
+	UpdateShopVoucher(ctx *gin.Context)
+	DeactivateShopVoucher(ctx *gin.Context)
 }
 
 // CreateVoucher godoc
@@ -103,7 +108,7 @@ func (h *Handler) GetAllVouchers(ctx *gin.Context) {
 
 // GetValidVouchers godoc
 // @Summary Get valid vouchers
-// @Description Get all currently valid vouchers
+// @Description Get all currently valid platform vouchers; campaign codes and shop vouchers are not listed
 // @Tags voucher
 // @Accept json
 // @Produce json
@@ -125,7 +130,7 @@ func (h *Handler) GetValidVouchers(ctx *gin.Context) {
 
 // UpdateVoucher godoc
 // @Summary Update a voucher
-// @Description Update voucher details (admin only)
+// @Description Update platform voucher details (admin only); shop vouchers are managed by their owner
 // @Tags voucher
 // @Accept json
 // @Produce json
@@ -155,7 +160,7 @@ func (h *Handler) UpdateVoucher(ctx *gin.Context) {
 
 // DeleteVoucher godoc
 // @Summary Delete a voucher
-// @Description Delete a voucher (admin only)
+// @Description Delete a platform voucher that has never been redeemed (admin only); shop vouchers are deactivated by their owner
 // @Tags voucher
 // @Accept json
 // @Produce json
@@ -203,7 +208,11 @@ func (h *Handler) ApplyVoucher(ctx *gin.Context) {
 		return
 	}
 
-	calculation, err := h.voucherUsecase.ApplyVoucher(ctx, req)
+	// Signed-in customers also get their per-user limits checked
+	userID, _ := ctx.Get("user_id")
+	customerID, _ := userID.(uuid.UUID)
+
+	calculation, err := h.voucherUsecase.ApplyVoucher(ctx, customerID, req)
 	if err != nil {
 		log.Errorw("Failed to apply voucher", "error", err)
 		apiwrapper.SendBadRequest(ctx, err.Error())
@@ -212,3 +221,196 @@ func (h *Handler) ApplyVoucher(ctx *gin.Context) {
 
 	apiwrapper.SendSuccess(ctx, calculation)
 }
+
+// GetVoucherRedemptions godoc
+// @Summary Get voucher redemptions
+// @Description Get the redemption ledger of a voucher (admin only)
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/voucher/{id}/redemptions [get]
+func (h *Handler) GetVoucherRedemptions(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	voucherID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid voucher ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid voucher ID")
+		return
+	}
+
+	redemptions, err := h.voucherUsecase.GetVoucherRedemptions(ctx, voucherID)
+	if err != nil {
+		log.Errorw("Failed to get voucher redemptions", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, redemptions)
+}
+
+// CreateShopVoucher godoc
+// @Summary Create a shop voucher
+// @Description Create a voucher limited to the owner's coffee shop and optionally to some of its meeting rooms. The discount is charged against the owner share.
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Param request body request.CreateShopVoucher true "Voucher details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/vouchers [post]
+func (h *Handler) CreateShopVoucher(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	var req request.CreateShopVoucher
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	voucher, err := h.voucherUsecase.CreateShopVoucher(ctx, ownerID, shopID, req)
+	if err != nil {
+		log.Errorw("Failed to create shop voucher", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, voucher)
+}
+
+// GetShopVouchers godoc
+// @Summary Get shop vouchers
+// @Description Get the vouchers issued by the owner's coffee shop
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee shop ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/{id}/vouchers [get]
+func (h *Handler) GetShopVouchers(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	shopID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid shop ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid shop ID")
+		return
+	}
+
+	vouchers, err := h.voucherUsecase.GetShopVouchers(ctx, ownerID, shopID)
+	if err != nil {
+		log.Errorw("Failed to get shop vouchers", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, vouchers)
+}
+
+// UpdateShopVoucher godoc
+// @Summary Update a shop voucher
+// @Description Update a voucher of the owner's coffee shop. Omitted fields keep their value.
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Param request body request.UpdateShopVoucher true "Voucher changes"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/vouchers/{id} [put]
+func (h *Handler) UpdateShopVoucher(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	voucherID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid voucher ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid voucher ID")
+		return
+	}
+
+	var req request.UpdateShopVoucher
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	voucher, err := h.voucherUsecase.UpdateShopVoucher(ctx, ownerID, voucherID, req)
+	if err != nil {
+		log.Errorw("Failed to update shop voucher", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, voucher)
+}
+
+// DeactivateShopVoucher godoc
+// @Summary Deactivate a shop voucher
+// @Description End a voucher of the owner's coffee shop now. The voucher and its redemptions are kept.
+// @Tags voucher
+// @Accept json
+// @Produce json
+// @Param id path string true "Voucher ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/coffee-shop/vouchers/{id}/deactivate [post]
+func (h *Handler) DeactivateShopVoucher(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	ownerIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	ownerID := ownerIDStr.(uuid.UUID)
+
+	voucherID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid voucher ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid voucher ID")
+		return
+	}
+
+	if err := h.voucherUsecase.DeactivateShopVoucher(ctx, ownerID, voucherID); err != nil {
+		log.Errorw("Failed to deactivate shop voucher", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Voucher deactivated successfully"})
+}
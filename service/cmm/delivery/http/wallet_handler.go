@@ -15,6 +15,8 @@ type IWalletHandler interface {
 	ConfirmTopup(ctx *gin.Context)
 	GetTopupHistory(ctx *gin.Context)
 	GetTransactionHistory(ctx *gin.Context)
+	TransferWallet(ctx *gin.Context)
+	GetTransferHistory(ctx *gin.Context)
 }
 
 // GetWallet godoc
@@ -170,3 +172,68 @@ func (h *Handler) GetTransactionHistory(ctx *gin.Context) {
 
 	apiwrapper.SendSuccess(ctx, transactions)
 }
+
+// TransferWallet godoc
+// @Summary Transfer money to another user
+// @Description Move wallet balance to another user's wallet, subject to daily limits. The recipient is notified.
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Param request body request.TransferWallet true "Transfer details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/transfer [post]
+func (h *Handler) TransferWallet(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.TransferWallet
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	transfer, err := h.walletUsecase.Transfer(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to transfer", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, transfer)
+}
+
+// GetTransferHistory godoc
+// @Summary Get transfer history
+// @Description Get the wallet transfers sent and received by the current user
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/transfers [get]
+func (h *Handler) GetTransferHistory(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	transfers, err := h.walletUsecase.GetTransferHistory(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get transfer history", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get transfer history")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, transfers)
+}
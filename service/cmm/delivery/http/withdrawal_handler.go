@@ -0,0 +1,378 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/apiwrapper"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/usecase"
+)
+
+// IWithdrawalHandler defines payout account and withdrawal handler methods
+type IWithdrawalHandler interface {
+	CreatePayoutAccount(ctx *gin.Context)
+	GetMyPayoutAccounts(ctx *gin.Context)
+	DeletePayoutAccount(ctx *gin.Context)
+	RequestWithdrawal(ctx *gin.Context)
+	GetMyWithdrawals(ctx *gin.Context)
+	GetWithdrawals(ctx *gin.Context)
+	GetWithdrawal(ctx *gin.Context)
+	ApproveWithdrawal(ctx *gin.Context)
+	MarkWithdrawalPaid(ctx *gin.Context)
+	RejectWithdrawal(ctx *gin.Context)
+}
+
+// CreatePayoutAccount godoc
+// @Summary Register a payout account
+// @Description Register a bank account to withdraw wallet balance to (owner only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param request body request.CreatePayoutAccount true "Bank account details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/payout-accounts [post]
+func (h *Handler) CreatePayoutAccount(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.CreatePayoutAccount
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	account, err := h.withdrawalUsecase.CreatePayoutAccount(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to create payout account", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, account)
+}
+
+// GetMyPayoutAccounts godoc
+// @Summary Get my payout accounts
+// @Description Get the bank accounts registered by the current user
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/payout-accounts [get]
+func (h *Handler) GetMyPayoutAccounts(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	accounts, err := h.withdrawalUsecase.GetMyPayoutAccounts(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get payout accounts", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get payout accounts")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, accounts)
+}
+
+// DeletePayoutAccount godoc
+// @Summary Delete a payout account
+// @Description Delete a bank account that no withdrawal has been made to
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Payout account ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/payout-accounts/{id} [delete]
+func (h *Handler) DeletePayoutAccount(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid payout account ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid payout account ID")
+		return
+	}
+
+	if err := h.withdrawalUsecase.DeletePayoutAccount(ctx, userID, accountID); err != nil {
+		log.Errorw("Failed to delete payout account", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Payout account deleted successfully"})
+}
+
+// RequestWithdrawal godoc
+// @Summary Request a withdrawal
+// @Description Request a withdrawal of wallet balance to a payout account (owner only). The amount is held until the withdrawal is paid or rejected.
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param request body request.RequestWithdrawal true "Withdrawal details"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/withdraw [post]
+func (h *Handler) RequestWithdrawal(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	var req request.RequestWithdrawal
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	withdrawal, err := h.withdrawalUsecase.RequestWithdrawal(ctx, userID, req)
+	if err != nil {
+		log.Errorw("Failed to request withdrawal", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, withdrawal)
+}
+
+// GetMyWithdrawals godoc
+// @Summary Get my withdrawals
+// @Description Get the withdrawals requested by the current user
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/wallet/withdrawals [get]
+func (h *Handler) GetMyWithdrawals(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	userID := userIDStr.(uuid.UUID)
+
+	withdrawals, err := h.withdrawalUsecase.GetMyWithdrawals(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get withdrawals", "error", err)
+		apiwrapper.SendInternalError(ctx, "Failed to get withdrawals")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, withdrawals)
+}
+
+// GetWithdrawals godoc
+// @Summary Get withdrawals
+// @Description Get all withdrawals, oldest first, optionally filtered by status (admin only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param status query string false "Withdrawal status" Enums(requested, approved, paid, rejected)
+// @Success 200 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/withdrawals [get]
+func (h *Handler) GetWithdrawals(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	withdrawals, err := h.withdrawalUsecase.GetWithdrawals(ctx, adminID, ctx.Query("status"))
+	if err != nil {
+		log.Errorw("Failed to get withdrawals", "error", err)
+		if errors.Is(err, usecase.ErrAdminOnly) {
+			apiwrapper.SendUnauthorized(ctx, err.Error())
+			return
+		}
+		apiwrapper.SendInternalError(ctx, "Failed to get withdrawals")
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, withdrawals)
+}
+
+// GetWithdrawal godoc
+// @Summary Get a withdrawal
+// @Description Get a withdrawal with the history of its status changes (admin only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Withdrawal ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/withdrawals/{id} [get]
+func (h *Handler) GetWithdrawal(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	withdrawalID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid withdrawal ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid withdrawal ID")
+		return
+	}
+
+	withdrawal, err := h.withdrawalUsecase.GetWithdrawal(ctx, adminID, withdrawalID)
+	if err != nil {
+		log.Errorw("Failed to get withdrawal", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, withdrawal)
+}
+
+// ApproveWithdrawal godoc
+// @Summary Approve a withdrawal
+// @Description Approve a requested withdrawal for transfer (admin only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Withdrawal ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/withdrawals/{id}/approve [post]
+func (h *Handler) ApproveWithdrawal(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	withdrawalID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid withdrawal ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid withdrawal ID")
+		return
+	}
+
+	if err := h.withdrawalUsecase.ApproveWithdrawal(ctx, adminID, withdrawalID); err != nil {
+		log.Errorw("Failed to approve withdrawal", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Withdrawal approved successfully"})
+}
+
+// MarkWithdrawalPaid godoc
+// @Summary Mark a withdrawal as paid
+// @Description Mark an approved withdrawal as transferred to the bank account (admin only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Withdrawal ID"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/withdrawals/{id}/mark-paid [post]
+func (h *Handler) MarkWithdrawalPaid(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	withdrawalID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid withdrawal ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid withdrawal ID")
+		return
+	}
+
+	if err := h.withdrawalUsecase.MarkWithdrawalPaid(ctx, adminID, withdrawalID); err != nil {
+		log.Errorw("Failed to mark withdrawal as paid", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Withdrawal marked as paid"})
+}
+
+// RejectWithdrawal godoc
+// @Summary Reject a withdrawal
+// @Description Reject a requested or approved withdrawal and return the held amount to the wallet (admin only)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Withdrawal ID"
+// @Param request body request.RejectWithdrawal true "Rejection reason"
+// @Success 200 {object} apiwrapper.APIResponse
+// @Failure 400 {object} apiwrapper.APIResponse
+// @Router /api/v1/admin/withdrawals/{id}/reject [post]
+func (h *Handler) RejectWithdrawal(ctx *gin.Context) {
+	log := logger.EnhanceWith(ctx)
+
+	adminIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		apiwrapper.SendUnauthorized(ctx, "User not authenticated")
+		return
+	}
+	adminID := adminIDStr.(uuid.UUID)
+
+	withdrawalID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		log.Errorw("Invalid withdrawal ID", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid withdrawal ID")
+		return
+	}
+
+	var req request.RejectWithdrawal
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Errorw("Invalid request format", "error", err)
+		apiwrapper.SendBadRequest(ctx, "Invalid request format")
+		return
+	}
+
+	if err := h.withdrawalUsecase.RejectWithdrawal(ctx, adminID, withdrawalID, req); err != nil {
+		log.Errorw("Failed to reject withdrawal", "error", err)
+		apiwrapper.SendBadRequest(ctx, err.Error())
+		return
+	}
+
+	apiwrapper.SendSuccess(ctx, gin.H{"message": "Withdrawal rejected"})
+}
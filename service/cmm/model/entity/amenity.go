@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// Amenity is an entry of the amenities catalog, identified by a stable code
+type Amenity struct {
+	Code string `gorm:"primaryKey;column:code"`
+	Name string `gorm:"column:name;not null"`
+}
+
+// RoomAmenity links a bookable resource to an amenity it offers
+type RoomAmenity struct {
+	MeetingRoomID uuid.UUID `gorm:"primaryKey;column:meeting_room_id"`
+	AmenityCode   string    `gorm:"primaryKey;column:amenity_code;index"`
+}
+
+// DefaultAmenities is the catalog seeded by the database migrations
+var DefaultAmenities = []Amenity{
+	{Code: "projector", Name: "Projector"},
+	{Code: "whiteboard", Name: "Whiteboard"},
+	{Code: "tv_screen", Name: "TV screen"},
+	{Code: "video_conference", Name: "Video conference kit"},
+	{Code: "quiet_zone", Name: "Quiet zone"},
+	{Code: "power_outlet", Name: "Power outlet"},
+	{Code: "air_conditioning", Name: "Air conditioning"},
+	{Code: "natural_light", Name: "Natural light"},
+}
@@ -12,6 +12,13 @@ type CoffeeShop struct {
     Name        string    `gorm:"column:name;not null"`
     Location    string    `gorm:"column:location;not null"`
     Description string    `gorm:"column:description"`
+    Latitude    *float64  `gorm:"column:latitude;index:idx_coffee_shops_lat_lng"`
+    Longitude   *float64  `gorm:"column:longitude;index:idx_coffee_shops_lat_lng"`
+    // Opening hours as HH:MM in GMT+7; a close time before the open time means the shop closes after midnight
+    OpenTime    string    `gorm:"column:open_time"`
+    CloseTime   string    `gorm:"column:close_time"`
+    // Verification status; only approved shops are listed publicly and take bookings
+    Status      string    `gorm:"column:status;not null;default:pending;index"`
     CreatedAt   time.Time `gorm:"column:created_at;default:now()"`
 }
 
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FloorZone groups the rooms, desks and seats of a coffee shop, e.g. "Ground floor" or "Quiet zone"
+type FloorZone struct {
+	ID           uuid.UUID `gorm:"primaryKey;column:id"`
+	CoffeeShopID uuid.UUID `gorm:"column:coffee_shop_id;not null;index"`
+	Name         string    `gorm:"column:name;not null"`
+	Description  string    `gorm:"column:description"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+}
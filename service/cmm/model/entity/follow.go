@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShopFollow marks a coffee shop as a favorite of a user, whose feed then shows the shop's posts
+type ShopFollow struct {
+	UserID       uuid.UUID `gorm:"primaryKey;column:user_id"`
+	CoffeeShopID uuid.UUID `gorm:"primaryKey;column:coffee_shop_id;index"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Login throttle scopes
+const (
+	ThrottleAccount = "account"
+	ThrottleIP      = "ip"
+)
+
+// Security event types
+const (
+	SecurityAccountLocked = "account_locked"
+	SecurityIPLocked      = "ip_locked"
+	// A successful login from an IP the user has not logged in from before
+	SecurityNewIPLogin = "new_ip_login"
+)
+
+// LoginThrottle counts recent failed logins for an account email or a client IP
+type LoginThrottle struct {
+	Scope        string     `gorm:"primaryKey;column:scope"`
+	Identifier   string     `gorm:"primaryKey;column:identifier"`
+	Failures     int        `gorm:"column:failures;not null;default:0"`
+	LastFailedAt time.Time  `gorm:"column:last_failed_at;not null"`
+	LockedUntil  *time.Time `gorm:"column:locked_until"`
+}
+
+// UserLoginIP is an IP address a user has logged in from
+type UserLoginIP struct {
+	UserID      uuid.UUID `gorm:"primaryKey;column:user_id"`
+	IP          string    `gorm:"primaryKey;column:ip"`
+	FirstSeenAt time.Time `gorm:"column:first_seen_at;not null"`
+	LastSeenAt  time.Time `gorm:"column:last_seen_at;not null"`
+}
+
+// SecurityEvent records a lockout or suspicious login for admins to review
+type SecurityEvent struct {
+	ID        uuid.UUID  `gorm:"primaryKey;column:id"`
+	Type      string     `gorm:"column:type;not null;index"`
+	UserID    *uuid.UUID `gorm:"column:user_id;index"`
+	Email     string     `gorm:"column:email"`
+	IP        string     `gorm:"column:ip;not null"`
+	UserAgent string     `gorm:"column:user_agent"`
+	// Failed attempts counted when a lockout started
+	Failures    int        `gorm:"column:failures"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	ReviewedBy  *uuid.UUID `gorm:"column:reviewed_by"`
+	ReviewedAt  *time.Time `gorm:"column:reviewed_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;default:now();index"`
+}
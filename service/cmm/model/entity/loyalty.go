@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Point entry types
+const (
+	PointEarn   = "earn"
+	PointRedeem = "redeem"
+	PointRefund = "refund"
+	PointExpire = "expire"
+)
+
+// Membership tiers, from lowest to highest
+const (
+	TierMember = "member"
+	TierSilver = "silver"
+	TierGold   = "gold"
+)
+
+// PointEntry is a line of a user's loyalty points ledger. Earn entries keep the points
+// not yet redeemed in Remaining and are consumed oldest expiry first.
+type PointEntry struct {
+	ID            uuid.UUID   `gorm:"primaryKey;column:id"`
+	UserID        uuid.UUID   `gorm:"column:user_id;not null;index"`
+	Type          string      `gorm:"column:type;not null"`
+	Points        int         `gorm:"column:points;not null"`
+	Remaining     int         `gorm:"column:remaining;not null;default:0"`
+	ServiceID     ServiceType `gorm:"column:service_id;not null;index:idx_point_entries_service_ref"`
+	ServiceRefID  uuid.UUID   `gorm:"column:service_ref_id;not null;index:idx_point_entries_service_ref"`
+	SourceEntryID *uuid.UUID  `gorm:"column:source_entry_id"`
+	ExpiresAt     *time.Time  `gorm:"column:expires_at;index"`
+	CreatedAt     time.Time   `gorm:"column:created_at;default:now()"`
+}
+
+// PointConsumption records how many points a redemption took from an earn entry,
+// so they can be given back if the redemption is refunded
+type PointConsumption struct {
+	ID            uuid.UUID `gorm:"primaryKey;column:id"`
+	RedeemEntryID uuid.UUID `gorm:"column:redeem_entry_id;not null;index"`
+	EarnEntryID   uuid.UUID `gorm:"column:earn_entry_id;not null"`
+	Points        int       `gorm:"column:points;not null"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media owner types
+const (
+	MediaOwnerCoffeeShop  = "coffee_shop"
+	MediaOwnerMeetingRoom = "meeting_room"
+	MediaOwnerShopPost    = "shop_post"
+)
+
+// Media is an image in the gallery of a coffee shop, meeting room or shop post
+type Media struct {
+	ID        uuid.UUID `gorm:"primaryKey;column:id"`
+	OwnerType string    `gorm:"column:owner_type;not null;index:idx_media_owner"`
+	OwnerID   uuid.UUID `gorm:"column:owner_id;not null;index:idx_media_owner"`
+	// Storage keys; a thumbnail is only rendered for images the server can decode
+	StorageKey   string    `gorm:"column:storage_key;not null"`
+	ThumbnailKey string    `gorm:"column:thumbnail_key"`
+	URL          string    `gorm:"column:url;not null"`
+	ThumbnailURL string    `gorm:"column:thumbnail_url"`
+	ContentType  string    `gorm:"column:content_type;not null"`
+	Size         int64     `gorm:"column:size;not null"`
+	Width        int       `gorm:"column:width"`
+	Height       int       `gorm:"column:height"`
+	UploadedBy   uuid.UUID `gorm:"column:uploaded_by;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+}
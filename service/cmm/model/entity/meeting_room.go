@@ -6,23 +6,38 @@ import (
     "github.com/google/uuid"
 )
 
+// Bookable resource types
+const (
+    ResourceMeetingRoom = "meeting_room"
+    ResourceDesk        = "desk"
+    ResourceSeat        = "seat"
+)
+
+// MeetingRoom is a bookable resource of a coffee shop: a meeting room, a desk or a single seat
 type MeetingRoom struct {
-    ID           uuid.UUID `gorm:"primaryKey;column:id"`
-    CoffeeShopID uuid.UUID `gorm:"column:coffee_shop_id;not null"`
-    Name         string    `gorm:"column:name;not null"`
-    Capacity     int       `gorm:"column:capacity;not null"`
-    PricePerHour float64   `gorm:"column:price_per_hour;not null"`
-    Available    bool      `gorm:"column:available;default:true"`
+    ID           uuid.UUID  `gorm:"primaryKey;column:id"`
+    CoffeeShopID uuid.UUID  `gorm:"column:coffee_shop_id;not null"`
+    FloorZoneID  *uuid.UUID `gorm:"column:floor_zone_id;index"`
+    ResourceType string     `gorm:"column:resource_type;not null;default:meeting_room"`
+    Name         string     `gorm:"column:name;not null"`
+    Capacity     int        `gorm:"column:capacity;not null"`
+    PricePerHour float64    `gorm:"column:price_per_hour;not null"`
+    PricePerDay  float64    `gorm:"column:price_per_day;not null;default:0"`
+    Available    bool       `gorm:"column:available;default:true"`
 }
 
 type Booking struct {
-    ID            uuid.UUID `gorm:"primaryKey;column:id"`
-    CustomerID    uuid.UUID `gorm:"column:customer_id;not null"`
-    MeetingRoomID uuid.UUID `gorm:"column:meeting_room_id;not null"`
-    StartTime     time.Time `gorm:"column:start_time;not null"`
-    EndTime       time.Time `gorm:"column:end_time;not null"`
-    TotalPrice    float64   `gorm:"column:total_price;not null"`
-    VoucherID     uuid.UUID `gorm:"column:voucher_id"`
-    Status        string    `gorm:"column:status;default:booked"`
-    CreatedAt     time.Time `gorm:"column:created_at;default:now()"`
+    ID             uuid.UUID `gorm:"primaryKey;column:id"`
+    CustomerID     uuid.UUID `gorm:"column:customer_id;not null"`
+    MeetingRoomID  uuid.UUID `gorm:"column:meeting_room_id;not null"`
+    StartTime      time.Time `gorm:"column:start_time;not null"`
+    EndTime        time.Time `gorm:"column:end_time;not null"`
+    TotalPrice     float64   `gorm:"column:total_price;not null"`
+    DiscountAmount float64   `gorm:"column:discount_amount;not null;default:0"`
+    VoucherID      uuid.UUID `gorm:"column:voucher_id"`
+    PointsRedeemed int       `gorm:"column:points_redeemed;not null;default:0"`
+    PointsDiscount float64   `gorm:"column:points_discount;not null;default:0"`
+    UserPassID     uuid.UUID `gorm:"column:user_pass_id"`
+    Status         string    `gorm:"column:status;default:booked"`
+    CreatedAt      time.Time `gorm:"column:created_at;default:now()"`
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types
+const (
+	NotificationWalletTransfer = "wallet_transfer"
+	NotificationWithdrawal     = "withdrawal"
+	NotificationReview         = "review"
+	NotificationShopStatus     = "shop_status"
+	NotificationStaffInvite    = "staff_invite"
+)
+
+// Notification is a message shown to a user in their notification inbox
+type Notification struct {
+	ID        uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserID    uuid.UUID  `gorm:"column:user_id;not null;index"`
+	Type      string     `gorm:"column:type;not null"`
+	Title     string     `gorm:"column:title;not null"`
+	Body      string     `gorm:"column:body"`
+	RefID     *uuid.UUID `gorm:"column:ref_id"`
+	ReadAt    *time.Time `gorm:"column:read_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;default:now()"`
+}
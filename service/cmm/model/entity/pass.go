@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pass types
+const (
+	// PassHours is a bundle of booking hours consumed by each booking
+	PassHours = "hours"
+	// PassUnlimited allows any number of non-overlapping bookings while it is valid
+	PassUnlimited = "unlimited"
+)
+
+// User pass statuses
+const (
+	UserPassActive  = "active"
+	UserPassExpired = "expired"
+)
+
+// Pass usage statuses
+const (
+	PassUsageUsed     = "used"
+	PassUsageRestored = "restored"
+)
+
+// PassProduct is a prepaid pass a coffee shop sells for its meeting rooms
+type PassProduct struct {
+	ID           uuid.UUID `gorm:"primaryKey;column:id"`
+	CoffeeShopID uuid.UUID `gorm:"column:coffee_shop_id;not null;index"`
+	Name         string    `gorm:"column:name;not null"`
+	Description  string    `gorm:"column:description"`
+	Type         string    `gorm:"column:type;not null"`
+	Hours        float64   `gorm:"column:hours;not null;default:0"`
+	ValidityDays int       `gorm:"column:validity_days;not null"`
+	Price        float64   `gorm:"column:price;not null"`
+	Active       bool      `gorm:"column:active;not null;default:true"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+}
+
+// UserPass is a pass bought by a user. The product terms are copied so later
+// changes to the product do not affect passes already sold.
+type UserPass struct {
+	ID             uuid.UUID `gorm:"primaryKey;column:id"`
+	UserID         uuid.UUID `gorm:"column:user_id;not null;index"`
+	PassProductID  uuid.UUID `gorm:"column:pass_product_id;not null;index"`
+	CoffeeShopID   uuid.UUID `gorm:"column:coffee_shop_id;not null"`
+	Name           string    `gorm:"column:name;not null"`
+	Type           string    `gorm:"column:type;not null"`
+	TotalHours     float64   `gorm:"column:total_hours;not null;default:0"`
+	RemainingHours float64   `gorm:"column:remaining_hours;not null;default:0"`
+	Price          float64   `gorm:"column:price;not null"`
+	Status         string    `gorm:"column:status;not null;default:active"`
+	StartsAt       time.Time `gorm:"column:starts_at;not null"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null;index"`
+	PurchasedAt    time.Time `gorm:"column:purchased_at;default:now()"`
+}
+
+// PassUsage records a booking paid with a pass
+type PassUsage struct {
+	ID         uuid.UUID `gorm:"primaryKey;column:id"`
+	UserPassID uuid.UUID `gorm:"column:user_pass_id;not null;index"`
+	BookingID  uuid.UUID `gorm:"column:booking_id;not null;uniqueIndex"`
+	StartTime  time.Time `gorm:"column:start_time;not null"`
+	EndTime    time.Time `gorm:"column:end_time;not null"`
+	Hours      float64   `gorm:"column:hours;not null"`
+	Status     string    `gorm:"column:status;not null;default:used"`
+	CreatedAt  time.Time `gorm:"column:created_at;default:now()"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referral statuses
+const (
+	ReferralPending  = "pending"
+	ReferralRewarded = "rewarded"
+	ReferralCapped   = "capped"
+)
+
+// Referral links a new user to the user whose referral code they registered with
+type Referral struct {
+	ID               uuid.UUID   `gorm:"primaryKey;column:id"`
+	ReferrerID       uuid.UUID   `gorm:"column:referrer_id;not null;index"`
+	RefereeID        uuid.UUID   `gorm:"column:referee_id;not null;uniqueIndex"`
+	Status           string      `gorm:"column:status;not null;default:pending"`
+	ReferrerReward   float64     `gorm:"column:referrer_reward;not null;default:0"`
+	RefereeReward    float64     `gorm:"column:referee_reward;not null;default:0"`
+	TriggerServiceID ServiceType `gorm:"column:trigger_service_id"`
+	TriggerRefID     *uuid.UUID  `gorm:"column:trigger_ref_id"`
+	CreatedAt        time.Time   `gorm:"column:created_at;default:now()"`
+	RewardedAt       *time.Time  `gorm:"column:rewarded_at"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review statuses
+const (
+	ReviewPublished = "published"
+	ReviewHidden    = "hidden"
+)
+
+// Review is a customer's rating of a completed booking. Each booking can be reviewed once.
+type Review struct {
+	ID            uuid.UUID  `gorm:"primaryKey;column:id"`
+	BookingID     uuid.UUID  `gorm:"column:booking_id;not null;uniqueIndex"`
+	CustomerID    uuid.UUID  `gorm:"column:customer_id;not null;index"`
+	CoffeeShopID  uuid.UUID  `gorm:"column:coffee_shop_id;not null;index"`
+	MeetingRoomID uuid.UUID  `gorm:"column:meeting_room_id;not null;index"`
+	Rating        int        `gorm:"column:rating;not null"`
+	Comment       string     `gorm:"column:comment"`
+	Status        string     `gorm:"column:status;not null;default:published;index"`
+	OwnerReply    string     `gorm:"column:owner_reply"`
+	RepliedAt     *time.Time `gorm:"column:replied_at"`
+	HiddenReason  string     `gorm:"column:hidden_reason"`
+	ModeratedBy   *uuid.UUID `gorm:"column:moderated_by"`
+	ModeratedAt   *time.Time `gorm:"column:moderated_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;default:now()"`
+}
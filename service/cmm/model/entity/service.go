@@ -1,7 +1,35 @@
 package entity
 
+// ServiceType identifies a billable platform service in the services catalog
+type ServiceType int
+
+const (
+    ServiceBooking     ServiceType = 1
+    ServiceTopup       ServiceType = 2
+    ServiceFoodOrder   ServiceType = 3
+    ServiceEventTicket ServiceType = 4
+    ServicePayout      ServiceType = 5
+    ServiceReferral    ServiceType = 6
+    ServiceTransfer    ServiceType = 7
+    ServiceWithdrawal  ServiceType = 8
+    ServicePass        ServiceType = 9
+)
+
 type Service struct {
-    ID          int    `gorm:"primaryKey;column:id"`
-    Name        string `gorm:"column:name;unique;not null"`
-    Description string `gorm:"column:description"`
-}
\ No newline at end of file
+    ID          ServiceType `gorm:"primaryKey;autoIncrement:false;column:id"`
+    Name        string      `gorm:"column:name;unique;not null"`
+    Description string      `gorm:"column:description"`
+}
+
+// DefaultServices is the catalog seeded by the database migrations
+var DefaultServices = []Service{
+    {ID: ServiceBooking, Name: "booking", Description: "Meeting room booking"},
+    {ID: ServiceTopup, Name: "topup", Description: "Wallet top-up"},
+    {ID: ServiceFoodOrder, Name: "food_order", Description: "Food and drink order"},
+    {ID: ServiceEventTicket, Name: "event_ticket", Description: "Event ticket purchase"},
+    {ID: ServicePayout, Name: "payout", Description: "Owner earnings payout"},
+    {ID: ServiceReferral, Name: "referral_reward", Description: "Referral program wallet credit"},
+    {ID: ServiceTransfer, Name: "wallet_transfer", Description: "Peer-to-peer wallet transfer"},
+    {ID: ServiceWithdrawal, Name: "withdrawal", Description: "Wallet withdrawal to a bank account"},
+    {ID: ServicePass, Name: "pass", Description: "Prepaid pass purchase"},
+}
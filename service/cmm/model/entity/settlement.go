@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Who pays for the voucher discount on an earning
+const (
+	DiscountFundedByPlatform = "platform"
+	DiscountFundedByShop     = "shop"
+)
+
+// Earning is the revenue split of a single completed booking or order
+type Earning struct {
+	ID                uuid.UUID   `gorm:"primaryKey;column:id"`
+	CoffeeShopID      uuid.UUID   `gorm:"column:coffee_shop_id;not null;index"`
+	OwnerID           uuid.UUID   `gorm:"column:owner_id;not null;index"`
+	ServiceID         ServiceType `gorm:"column:service_id;not null;uniqueIndex:idx_earnings_service_ref"`
+	ServiceRefID      uuid.UUID   `gorm:"column:service_ref_id;not null;uniqueIndex:idx_earnings_service_ref"`
+	GrossAmount       float64     `gorm:"column:gross_amount;not null"`
+	DiscountAmount    float64     `gorm:"column:discount_amount;not null;default:0"`
+	DiscountFundedBy  string      `gorm:"column:discount_funded_by"`
+	RatePercent       int         `gorm:"column:rate_percent;not null"`
+	CommissionAmount  float64     `gorm:"column:commission_amount;not null"`
+	OwnerAmount       float64     `gorm:"column:owner_amount;not null"`
+	PayoutStatementID *uuid.UUID  `gorm:"column:payout_statement_id;index"`
+	EarnedAt          time.Time   `gorm:"column:earned_at;default:now()"`
+}
+
+// Settlement is a single settlement run covering a period
+type Settlement struct {
+	ID          uuid.UUID `gorm:"primaryKey;column:id"`
+	PeriodStart time.Time `gorm:"column:period_start;not null"`
+	PeriodEnd   time.Time `gorm:"column:period_end;not null"`
+	CreatedBy   uuid.UUID `gorm:"column:created_by;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;default:now()"`
+}
+
+// PayoutStatement totals the earnings of one owner within a settlement
+type PayoutStatement struct {
+	ID               uuid.UUID  `gorm:"primaryKey;column:id"`
+	SettlementID     uuid.UUID  `gorm:"column:settlement_id;not null;index"`
+	OwnerID          uuid.UUID  `gorm:"column:owner_id;not null;index"`
+	GrossAmount      float64    `gorm:"column:gross_amount;not null"`
+	CommissionAmount float64    `gorm:"column:commission_amount;not null"`
+	PayoutAmount     float64    `gorm:"column:payout_amount;not null"`
+	ItemCount        int        `gorm:"column:item_count;not null"`
+	Status           string     `gorm:"column:status;default:pending"`
+	PaidAt           *time.Time `gorm:"column:paid_at"`
+	CreatedAt        time.Time  `gorm:"column:created_at;default:now()"`
+}
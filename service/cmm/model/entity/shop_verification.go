@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Coffee shop verification statuses
+const (
+	// New shops wait for their owner to submit business details
+	ShopPending   = "pending"
+	ShopSubmitted = "submitted"
+	ShopApproved  = "approved"
+	// Rejected shops can be corrected and submitted again
+	ShopRejected = "rejected"
+)
+
+// Shop document types
+const (
+	DocumentBusinessLicense = "business_license"
+	DocumentIdentityCard    = "identity_card"
+	DocumentOther           = "other"
+)
+
+// ShopVerification holds the business details an owner submits to get a coffee shop approved
+type ShopVerification struct {
+	CoffeeShopID    uuid.UUID  `gorm:"primaryKey;column:coffee_shop_id"`
+	BusinessName    string     `gorm:"column:business_name;not null"`
+	TaxCode         string     `gorm:"column:tax_code;not null"`
+	BusinessAddress string     `gorm:"column:business_address;not null"`
+	ContactPhone    string     `gorm:"column:contact_phone;not null"`
+	SubmittedAt     time.Time  `gorm:"column:submitted_at;not null"`
+	RejectionReason string     `gorm:"column:rejection_reason"`
+	ReviewedBy      *uuid.UUID `gorm:"column:reviewed_by"`
+	ReviewedAt      *time.Time `gorm:"column:reviewed_at"`
+}
+
+// ShopDocument is a file, e.g. a business license, supporting a shop's verification
+type ShopDocument struct {
+	ID           uuid.UUID `gorm:"primaryKey;column:id"`
+	CoffeeShopID uuid.UUID `gorm:"column:coffee_shop_id;not null;index"`
+	DocumentType string    `gorm:"column:document_type;not null"`
+	FileName     string    `gorm:"column:file_name"`
+	StorageKey   string    `gorm:"column:storage_key;not null"`
+	URL          string    `gorm:"column:url;not null"`
+	ContentType  string    `gorm:"column:content_type;not null"`
+	Size         int64     `gorm:"column:size;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+}
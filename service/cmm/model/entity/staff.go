@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Staff permissions; the shop owner implicitly has all of them
+const (
+	// Shop details such as name, location, description and opening hours
+	PermissionManageShop     = "manage_shop"
+	PermissionManageRooms    = "manage_rooms"
+	PermissionManageBookings = "manage_bookings"
+	// Menu, shop posts and their galleries
+	PermissionManageMenu  = "manage_menu"
+	PermissionViewReports = "view_reports"
+)
+
+// Staff statuses
+const (
+	StaffInvited = "invited"
+	StaffActive  = "active"
+)
+
+// ShopStaff lets a user act on a coffee shop with the permissions its owner granted.
+// Invitations only take effect once the invited user accepts them.
+type ShopStaff struct {
+	ID           uuid.UUID  `gorm:"primaryKey;column:id"`
+	CoffeeShopID uuid.UUID  `gorm:"column:coffee_shop_id;not null;uniqueIndex:idx_shop_staff_member"`
+	UserID       uuid.UUID  `gorm:"column:user_id;not null;uniqueIndex:idx_shop_staff_member;index"`
+	Permissions  []string   `gorm:"column:permissions;type:jsonb;serializer:json;not null"`
+	Status       string     `gorm:"column:status;not null;default:invited"`
+	InvitedBy    uuid.UUID  `gorm:"column:invited_by;not null"`
+	AcceptedAt   *time.Time `gorm:"column:accepted_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;default:now()"`
+}
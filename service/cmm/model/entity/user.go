@@ -15,10 +15,17 @@ const (
 )
 
 type User struct {
-	ID           uuid.UUID `gorm:"primaryKey;column:id"`
-	FullName     string    `gorm:"column:full_name;not null"`
-	Email        string    `gorm:"column:email;unique;not null"`
-	PasswordHash string    `gorm:"column:password_hash;not null"`
-	Role         UserRole  `gorm:"column:role;not null;default:customer"`
-	CreatedAt    time.Time `gorm:"column:created_at;default:now()"`
+	ID           uuid.UUID  `gorm:"primaryKey;column:id"`
+	FullName     string     `gorm:"column:full_name;not null"`
+	Email        string     `gorm:"column:email;unique;not null"`
+	PasswordHash string     `gorm:"column:password_hash;not null"`
+	Role         UserRole   `gorm:"column:role;not null;default:customer"`
+	ReferralCode *string    `gorm:"column:referral_code;uniqueIndex"`
+	ReferredBy   *uuid.UUID `gorm:"column:referred_by;index"`
+	Phone        string     `gorm:"column:phone"`
+	AvatarURL    string     `gorm:"column:avatar_url"`
+	// Language for notifications and emails: vi or en
+	PreferredLanguage string     `gorm:"column:preferred_language;not null;default:vi"`
+	EmailVerifiedAt   *time.Time `gorm:"column:email_verified_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at;default:now()"`
 }
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User token purposes
+const (
+	TokenEmailVerification = "email_verification"
+	TokenPasswordReset     = "password_reset"
+	TokenEmailChange       = "email_change"
+)
+
+// UserToken is a single-use, expiring token sent to a user's email address.
+// Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        uuid.UUID `gorm:"primaryKey;column:id"`
+	UserID    uuid.UUID `gorm:"column:user_id;not null;index"`
+	Purpose   string    `gorm:"column:purpose;not null"`
+	TokenHash string    `gorm:"column:token_hash;not null;uniqueIndex"`
+	// Address the token was sent to; for an email change, the new address
+	Email     string     `gorm:"column:email;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;default:now()"`
+}
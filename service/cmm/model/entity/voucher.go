@@ -6,13 +6,52 @@ import (
     "github.com/google/uuid"
 )
 
+// Voucher discount types
+const (
+    VoucherDiscountPercent = "percent"
+    VoucherDiscountFixed   = "fixed"
+)
+
 type Voucher struct {
-    ID              uuid.UUID `gorm:"primaryKey;column:id"`
-    Code            string    `gorm:"column:code;unique;not null"`
-    DiscountPercent int       `gorm:"column:discount_percent;not null"`
-    MaxUses         int       `gorm:"column:max_uses"`
-    UsedCount       int       `gorm:"column:used_count;default:0"`
-    ServiceID       int       `gorm:"column:service_id"`
-    ValidFrom       time.Time `gorm:"column:valid_from"`
-    ValidTo         time.Time `gorm:"column:valid_to"`
+    ID              uuid.UUID    `gorm:"primaryKey;column:id"`
+    Code            string       `gorm:"column:code;unique;not null"`
+    DiscountType    string       `gorm:"column:discount_type;not null;default:percent"`
+    DiscountPercent int          `gorm:"column:discount_percent;not null;default:0"`
+    DiscountAmount  float64      `gorm:"column:discount_amount;not null;default:0"`
+    MaxDiscount     float64      `gorm:"column:max_discount;not null;default:0"`
+    MinOrderAmount  float64      `gorm:"column:min_order_amount;not null;default:0"`
+    MaxUses         int          `gorm:"column:max_uses"`
+    UsedCount       int          `gorm:"column:used_count;default:0"`
+    PerUserLimit    int          `gorm:"column:per_user_limit;not null;default:0"`
+    FirstTimeOnly   bool         `gorm:"column:first_time_only;not null;default:false"`
+    CampaignID      *uuid.UUID   `gorm:"column:campaign_id;index"`
+    CoffeeShopID    *uuid.UUID   `gorm:"column:coffee_shop_id;index"`
+    ServiceID       *ServiceType `gorm:"column:service_id"`
+    ValidFrom       time.Time    `gorm:"column:valid_from"`
+    ValidTo         time.Time    `gorm:"column:valid_to"`
+}
+
+// VoucherMeetingRoom limits a shop voucher to specific meeting rooms
+type VoucherMeetingRoom struct {
+    VoucherID     uuid.UUID `gorm:"primaryKey;column:voucher_id"`
+    MeetingRoomID uuid.UUID `gorm:"primaryKey;column:meeting_room_id"`
+}
+
+// Voucher redemption statuses
+const (
+    RedemptionRedeemed = "redeemed"
+    RedemptionReleased = "released"
+)
+
+// VoucherRedemption records one use of a voucher against an order or booking
+type VoucherRedemption struct {
+    ID             uuid.UUID   `gorm:"primaryKey;column:id"`
+    VoucherID      uuid.UUID   `gorm:"column:voucher_id;not null;index"`
+    UserID         uuid.UUID   `gorm:"column:user_id;not null;index"`
+    ServiceID      ServiceType `gorm:"column:service_id;not null"`
+    ServiceRefID   uuid.UUID   `gorm:"column:service_ref_id;not null;index"`
+    DiscountAmount float64     `gorm:"column:discount_amount;not null"`
+    Status         string      `gorm:"column:status;not null;default:redeemed"`
+    RedeemedAt     time.Time   `gorm:"column:redeemed_at;not null"`
+    ReleasedAt     *time.Time  `gorm:"column:released_at"`
 }
\ No newline at end of file
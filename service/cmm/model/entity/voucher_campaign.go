@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VoucherCampaign groups the single-use voucher codes generated for one marketing campaign
+type VoucherCampaign struct {
+	ID         uuid.UUID `gorm:"primaryKey;column:id"`
+	Name       string    `gorm:"column:name;not null"`
+	Prefix     string    `gorm:"column:prefix"`
+	Alphabet   string    `gorm:"column:alphabet;not null"`
+	CodeLength int       `gorm:"column:code_length;not null"`
+	CodeCount  int       `gorm:"column:code_count;not null;default:0"`
+	CreatedBy  uuid.UUID `gorm:"column:created_by;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;default:now()"`
+}
@@ -7,8 +7,9 @@ import (
 )
 
 type Wallet struct {
-    UserID  uuid.UUID `gorm:"primaryKey;column:user_id"`
-    Balance float64   `gorm:"column:balance;not null;default:0"`
+    UserID      uuid.UUID `gorm:"primaryKey;column:user_id"`
+    Balance     float64   `gorm:"column:balance;not null;default:0"`
+    HeldBalance float64   `gorm:"column:held_balance;not null;default:0"`
 }
 
 type Topup struct {
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletTransfer moves balance from one user's wallet to another's
+type WalletTransfer struct {
+	ID          uuid.UUID `gorm:"primaryKey;column:id"`
+	SenderID    uuid.UUID `gorm:"column:sender_id;not null;index"`
+	RecipientID uuid.UUID `gorm:"column:recipient_id;not null;index"`
+	Amount      float64   `gorm:"column:amount;not null"`
+	Note        string    `gorm:"column:note"`
+	CreatedAt   time.Time `gorm:"column:created_at;default:now();index"`
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Withdrawal statuses
+const (
+	WithdrawalRequested = "requested"
+	WithdrawalApproved  = "approved"
+	WithdrawalPaid      = "paid"
+	WithdrawalRejected  = "rejected"
+)
+
+// PayoutAccount is a bank account an owner can withdraw wallet balance to
+type PayoutAccount struct {
+	ID            uuid.UUID `gorm:"primaryKey;column:id"`
+	UserID        uuid.UUID `gorm:"column:user_id;not null;index"`
+	BankName      string    `gorm:"column:bank_name;not null"`
+	AccountNumber string    `gorm:"column:account_number;not null"`
+	AccountHolder string    `gorm:"column:account_holder;not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;default:now()"`
+}
+
+// Withdrawal is a request to move wallet balance to a payout account. The amount
+// is held on the wallet from the request until the withdrawal is paid or rejected.
+type Withdrawal struct {
+	ID              uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserID          uuid.UUID  `gorm:"column:user_id;not null;index"`
+	PayoutAccountID uuid.UUID  `gorm:"column:payout_account_id;not null"`
+	Amount          float64    `gorm:"column:amount;not null"`
+	Status          string     `gorm:"column:status;not null;default:requested;index"`
+	RejectReason    string     `gorm:"column:reject_reason"`
+	ProcessedBy     *uuid.UUID `gorm:"column:processed_by"`
+	ProcessedAt     *time.Time `gorm:"column:processed_at"`
+	PaidAt          *time.Time `gorm:"column:paid_at"`
+	CreatedAt       time.Time  `gorm:"column:created_at;default:now()"`
+}
+
+// WithdrawalEvent is one status change of a withdrawal. Events are only appended, so they
+// keep the full history while the withdrawal row holds the current status.
+type WithdrawalEvent struct {
+	ID           uuid.UUID `gorm:"primaryKey;column:id"`
+	WithdrawalID uuid.UUID `gorm:"column:withdrawal_id;not null;index"`
+	Status       string    `gorm:"column:status;not null"`
+	// User who made the change: the owner for the request, an admin afterwards
+	ActorID   *uuid.UUID `gorm:"column:actor_id"`
+	Note      string     `gorm:"column:note"`
+	CreatedAt time.Time  `gorm:"column:created_at;default:now()"`
+}
@@ -12,6 +12,8 @@ type CreateBooking struct {
 	StartTime     time.Time `json:"start_time" binding:"required"`
 	EndTime       time.Time `json:"end_time" binding:"required"`
 	VoucherCode   string    `json:"voucher_code,omitempty"`
+	RedeemPoints  int       `json:"redeem_points,omitempty" binding:"min=0"`
+	PassID        uuid.UUID `json:"pass_id,omitempty"`
 }
 
 type CancelBooking struct {
@@ -20,9 +22,13 @@ type CancelBooking struct {
 
 // Coffee Shop requests
 type CreateCoffeeShop struct {
-	Name        string `json:"name" binding:"required"`
-	Location    string `json:"location" binding:"required"`
-	Description string `json:"description"`
+	Name        string   `json:"name" binding:"required"`
+	Location    string   `json:"location" binding:"required"`
+	Description string   `json:"description"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90" example:"10.7769"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180" example:"106.7009"`
+	OpenTime    string   `json:"open_time" binding:"required_with=CloseTime,omitempty,datetime=15:04" example:"07:00"`
+	CloseTime   string   `json:"close_time" binding:"required_with=OpenTime,omitempty,datetime=15:04" example:"22:00"`
 }
 
 type UpdateCoffeeShop struct {
@@ -30,6 +36,23 @@ type UpdateCoffeeShop struct {
 	Name        string    `json:"name"`
 	Location    string    `json:"location"`
 	Description string    `json:"description"`
+	Latitude    *float64  `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude   *float64  `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	OpenTime    string    `json:"open_time" binding:"required_with=CloseTime,omitempty,datetime=15:04"`
+	CloseTime   string    `json:"close_time" binding:"required_with=OpenTime,omitempty,datetime=15:04"`
+}
+
+// NearbyCoffeeShops finds shops within a radius of a point
+type NearbyCoffeeShops struct {
+	Latitude  *float64 `form:"lat" binding:"required,min=-90,max=90" example:"10.7769"`
+	Longitude *float64 `form:"lng" binding:"required,min=-180,max=180" example:"106.7009"`
+	// Search radius in kilometers, 5 by default
+	Radius float64 `form:"radius" binding:"min=0,max=50" example:"3"`
+	// Only shops open right now
+	OpenNow bool `form:"open_now"`
+	// Only shops with a room that is not booked right now
+	RoomsAvailable bool `form:"rooms_available"`
+	Limit          int  `form:"limit" binding:"min=0,max=100" example:"20"`
 }
 
 type SetCommissionRate struct {
@@ -39,18 +62,65 @@ type SetCommissionRate struct {
 
 // Meeting Room requests
 type CreateMeetingRoom struct {
-	CoffeeShopID uuid.UUID `json:"coffee_shop_id" binding:"required"`
-	Name         string    `json:"name" binding:"required"`
-	Capacity     int       `json:"capacity" binding:"required,min=1"`
-	PricePerHour float64   `json:"price_per_hour" binding:"required,min=0"`
+	CoffeeShopID uuid.UUID  `json:"coffee_shop_id" binding:"required"`
+	FloorZoneID  *uuid.UUID `json:"floor_zone_id"`
+	ResourceType string     `json:"resource_type" binding:"omitempty,oneof=meeting_room desk seat"`
+	Name         string     `json:"name" binding:"required"`
+	Capacity     int        `json:"capacity" binding:"required,min=1"`
+	PricePerHour float64    `json:"price_per_hour" binding:"min=0"`
+	PricePerDay  float64    `json:"price_per_day" binding:"min=0"`
+	Amenities    []string   `json:"amenities" example:"projector,whiteboard"`
 }
 
 type UpdateMeetingRoom struct {
-	ID           uuid.UUID `json:"id" binding:"required"`
-	Name         string    `json:"name"`
-	Capacity     int       `json:"capacity" binding:"min=1"`
-	PricePerHour float64   `json:"price_per_hour" binding:"min=0"`
-	Available    *bool     `json:"available"`
+	ID           uuid.UUID  `json:"id" binding:"required"`
+	FloorZoneID  *uuid.UUID `json:"floor_zone_id"`
+	Name         string     `json:"name"`
+	Capacity     int        `json:"capacity" binding:"min=1"`
+	PricePerHour float64    `json:"price_per_hour" binding:"min=0"`
+	PricePerDay  *float64   `json:"price_per_day" binding:"omitempty,min=0"`
+	Available    *bool      `json:"available"`
+	// Replaces the room's amenities when set; an empty list clears them
+	Amenities *[]string `json:"amenities"`
+}
+
+// BulkCreateResources creates numbered desks or seats, e.g. "Desk 1" to "Desk 20"
+type BulkCreateResources struct {
+	CoffeeShopID uuid.UUID  `json:"coffee_shop_id" binding:"required"`
+	FloorZoneID  *uuid.UUID `json:"floor_zone_id"`
+	ResourceType string     `json:"resource_type" binding:"required,oneof=meeting_room desk seat"`
+	NamePrefix   string     `json:"name_prefix" binding:"required,max=50" example:"Desk "`
+	StartNumber  int        `json:"start_number" binding:"min=0" example:"1"`
+	Count        int        `json:"count" binding:"required,min=1,max=200" example:"20"`
+	Capacity     int        `json:"capacity" binding:"min=0" example:"1"`
+	PricePerHour float64    `json:"price_per_hour" binding:"min=0" example:"20000"`
+	PricePerDay  float64    `json:"price_per_day" binding:"min=0" example:"120000"`
+	Amenities    []string   `json:"amenities" example:"power_outlet"`
+}
+
+// ResourceAvailability asks which resources of a shop are free in a period
+type ResourceAvailability struct {
+	StartTime    time.Time  `form:"start_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-01-01T09:00:00Z"`
+	EndTime      time.Time  `form:"end_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00" example:"2024-01-01T17:00:00Z"`
+	ResourceType string     `form:"resource_type" binding:"omitempty,oneof=meeting_room desk seat"`
+	FloorZoneID  string     `form:"floor_zone_id" binding:"omitempty,uuid" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+}
+
+// SearchMeetingRooms filters rooms across shops by amenities, capacity and price
+type SearchMeetingRooms struct {
+	CoffeeShopID string `form:"coffee_shop_id" binding:"omitempty,uuid" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Location     string `form:"location" example:"District 1"`
+	ResourceType string `form:"resource_type" binding:"omitempty,oneof=meeting_room desk seat"`
+	// Amenity codes the room must all offer, repeated or comma-separated
+	Amenities   []string `form:"amenities" example:"projector,whiteboard"`
+	MinCapacity int      `form:"min_capacity" binding:"min=0" example:"4"`
+	MaxCapacity int      `form:"max_capacity" binding:"min=0" example:"10"`
+	MinPrice    float64  `form:"min_price" binding:"min=0"`
+	MaxPrice    float64  `form:"max_price" binding:"min=0" example:"200000"`
+	// Whether min_price, max_price and the ordering use the price of an hour or of a day; hour when omitted
+	PriceUnit string `form:"price_unit" binding:"omitempty,oneof=hour day" example:"hour"`
+	Limit       int      `form:"limit" binding:"min=0,max=100" example:"20"`
+	Offset      int      `form:"offset" binding:"min=0"`
 }
 
 // Wallet requests
@@ -63,11 +133,23 @@ type ConfirmTopup struct {
 	TopupID uuid.UUID `json:"topup_id" binding:"required"`
 }
 
+type TransferWallet struct {
+	RecipientEmail string  `json:"recipient_email" binding:"required,email"`
+	Amount         float64 `json:"amount" binding:"required,gt=0"`
+	Note           string  `json:"note" binding:"max=255"`
+}
+
 // Voucher requests
 type CreateVoucher struct {
 	Code            string     `json:"code" binding:"required"`
-	DiscountPercent int        `json:"discount_percent" binding:"required,min=1,max=100"`
+	DiscountType    string     `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountPercent int        `json:"discount_percent" binding:"min=0,max=100"`
+	DiscountAmount  float64    `json:"discount_amount" binding:"min=0"`
+	MaxDiscount     float64    `json:"max_discount" binding:"min=0"`
+	MinOrderAmount  float64    `json:"min_order_amount" binding:"min=0"`
 	MaxUses         int        `json:"max_uses" binding:"min=0"`
+	PerUserLimit    int        `json:"per_user_limit" binding:"min=0"`
+	FirstTimeOnly   bool       `json:"first_time_only"`
 	ServiceID       int        `json:"service_id"`
 	ValidFrom       time.Time  `json:"valid_from" binding:"required"`
 	ValidTo         time.Time  `json:"valid_to" binding:"required"`
@@ -76,15 +158,57 @@ type CreateVoucher struct {
 type UpdateVoucher struct {
 	ID              uuid.UUID  `json:"id" binding:"required"`
 	Code            string     `json:"code"`
-	DiscountPercent int        `json:"discount_percent" binding:"min=1,max=100"`
+	DiscountType    string     `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountPercent int        `json:"discount_percent" binding:"omitempty,min=1,max=100"`
+	DiscountAmount  *float64   `json:"discount_amount" binding:"omitempty,min=0"`
+	MaxDiscount     *float64   `json:"max_discount" binding:"omitempty,min=0"`
+	MinOrderAmount  *float64   `json:"min_order_amount" binding:"omitempty,min=0"`
 	MaxUses         int        `json:"max_uses" binding:"min=0"`
+	PerUserLimit    *int       `json:"per_user_limit" binding:"omitempty,min=0"`
+	FirstTimeOnly   *bool      `json:"first_time_only"`
+	ValidFrom       *time.Time `json:"valid_from"`
+	ValidTo         *time.Time `json:"valid_to"`
+}
+
+// CreateShopVoucher is a voucher issued by a coffee shop owner for their own shop
+type CreateShopVoucher struct {
+	Code            string      `json:"code" binding:"required"`
+	DiscountType    string      `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountPercent int         `json:"discount_percent" binding:"min=0,max=100"`
+	DiscountAmount  float64     `json:"discount_amount" binding:"min=0"`
+	MaxDiscount     float64     `json:"max_discount" binding:"min=0"`
+	MinOrderAmount  float64     `json:"min_order_amount" binding:"min=0"`
+	MaxUses         int         `json:"max_uses" binding:"min=0"`
+	PerUserLimit    int         `json:"per_user_limit" binding:"min=0"`
+	FirstTimeOnly   bool        `json:"first_time_only"`
+	MeetingRoomIDs  []uuid.UUID `json:"meeting_room_ids"`
+	ValidFrom       time.Time   `json:"valid_from" binding:"required"`
+	ValidTo         time.Time   `json:"valid_to" binding:"required"`
+}
+
+// UpdateShopVoucher changes a shop voucher; omitted fields keep their value
+type UpdateShopVoucher struct {
+	Code            string     `json:"code"`
+	DiscountType    string     `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountPercent int        `json:"discount_percent" binding:"omitempty,min=1,max=100"`
+	DiscountAmount  *float64   `json:"discount_amount" binding:"omitempty,min=0"`
+	MaxDiscount     *float64   `json:"max_discount" binding:"omitempty,min=0"`
+	MinOrderAmount  *float64   `json:"min_order_amount" binding:"omitempty,min=0"`
+	MaxUses         *int       `json:"max_uses" binding:"omitempty,min=0"`
+	PerUserLimit    *int       `json:"per_user_limit" binding:"omitempty,min=0"`
+	FirstTimeOnly   *bool      `json:"first_time_only"`
 	ValidFrom       *time.Time `json:"valid_from"`
 	ValidTo         *time.Time `json:"valid_to"`
+	// Replaces the rooms the voucher is limited to when present; an empty list opens it to every room
+	MeetingRoomIDs []uuid.UUID `json:"meeting_room_ids"`
 }
 
 type ApplyVoucher struct {
-	VoucherCode string  `json:"voucher_code" binding:"required"`
-	Amount      float64 `json:"amount" binding:"required,min=0"`
+	VoucherCode   string    `json:"voucher_code" binding:"required"`
+	Amount        float64   `json:"amount" binding:"required,min=0"`
+	// Service the voucher is used for; bookings when omitted
+	ServiceID     int       `json:"service_id" binding:"min=0"`
+	MeetingRoomID uuid.UUID `json:"meeting_room_id"`
 }
 
 // Post requests
@@ -0,0 +1,14 @@
+package request
+
+import "time"
+
+// DashboardQuery represents the date range and bucket size of a dashboard report
+// @Description Dashboard query parameters
+type DashboardQuery struct {
+	// Start of the range (inclusive)
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" example:"2024-01-01"`
+	// End of the range (exclusive)
+	To time.Time `form:"to" binding:"required" time_format:"2006-01-02" example:"2024-02-01"`
+	// Bucket size: day, week or month
+	Interval string `form:"interval" example:"day"`
+}
@@ -0,0 +1,15 @@
+package request
+
+// CreateFloorZone creates a zone grouping the resources of a coffee shop
+// @Description Floor zone request
+type CreateFloorZone struct {
+	Name        string `json:"name" binding:"required,max=100" example:"Quiet zone"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// UpdateFloorZone updates the name or description of a floor zone
+// @Description Floor zone update request
+type UpdateFloorZone struct {
+	Name        string  `json:"name" binding:"max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,8 @@
+package request
+
+// FeedQuery pages through the posts of followed shops
+// @Description Feed query parameters
+type FeedQuery struct {
+	Limit  int `form:"limit" binding:"min=0,max=50" example:"20"`
+	Offset int `form:"offset" binding:"min=0" example:"0"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// Media requests
+type UploadMedia struct {
+	OwnerType string `form:"owner_type" binding:"required,oneof=coffee_shop meeting_room shop_post"`
+	OwnerID   string `form:"owner_id" binding:"required,uuid" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+}
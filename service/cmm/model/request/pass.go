@@ -0,0 +1,23 @@
+package request
+
+import "github.com/google/uuid"
+
+// CreatePassProduct creates a prepaid pass sold by a coffee shop
+// @Description Pass product request
+type CreatePassProduct struct {
+	Name        string `json:"name" binding:"required,max=100" example:"10 hours of meeting room time"`
+	Description string `json:"description" binding:"max=500"`
+	// "hours" passes hold a number of booking hours, "unlimited" passes allow any non-overlapping bookings
+	Type string `json:"type" binding:"required,oneof=hours unlimited" example:"hours"`
+	// Booking hours included, required for hours passes
+	Hours float64 `json:"hours" binding:"min=0" example:"10"`
+	// Days the pass stays valid after purchase
+	ValidityDays int     `json:"validity_days" binding:"required,min=1,max=366" example:"30"`
+	Price        float64 `json:"price" binding:"required,gt=0" example:"900000"`
+}
+
+// PurchasePass buys a pass with the wallet balance
+// @Description Pass purchase request
+type PurchasePass struct {
+	PassProductID uuid.UUID `json:"pass_product_id" binding:"required"`
+}
@@ -0,0 +1,16 @@
+package request
+
+import "time"
+
+// ReportQuery represents the date range and output format of an admin report
+// @Description Admin report query parameters
+type ReportQuery struct {
+	// Start of the range (inclusive)
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" example:"2024-01-01"`
+	// End of the range (exclusive), at most 366 days after from
+	To time.Time `form:"to" binding:"required" time_format:"2006-01-02" example:"2024-02-01"`
+	// Output format: json (default) or csv
+	Format string `form:"format" example:"csv"`
+	// Maximum number of rows for ranked reports
+	Limit int `form:"limit" binding:"min=0,max=100" example:"10"`
+}
@@ -0,0 +1,23 @@
+package request
+
+import "github.com/google/uuid"
+
+// CreateReview rates a completed booking
+// @Description Review request
+type CreateReview struct {
+	BookingID uuid.UUID `json:"booking_id" binding:"required"`
+	Rating    int       `json:"rating" binding:"required,min=1,max=5" example:"5"`
+	Comment   string    `json:"comment" binding:"max=2000" example:"Quiet room, fast wifi"`
+}
+
+// ReplyToReview is the shop owner's public answer to a review
+// @Description Review reply request
+type ReplyToReview struct {
+	Reply string `json:"reply" binding:"required,max=2000" example:"Thank you, see you again!"`
+}
+
+// HideReview hides a review from the public with a reason
+// @Description Review moderation request
+type HideReview struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"Offensive language"`
+}
@@ -0,0 +1,12 @@
+package request
+
+// Search is a full-text query over coffee shops, rooms and shop posts
+// @Description Full-text search query parameters
+type Search struct {
+	// Search text; accents are optional and "quoted phrases", OR and -word are supported
+	Q string `form:"q" binding:"required,max=200" example:"ca phe yen tinh"`
+	// Restrict the search to shops, rooms or posts; all by default
+	Type string `form:"type" binding:"omitempty,oneof=shops rooms posts" example:"shops"`
+	// Maximum number of results per kind
+	Limit int `form:"limit" binding:"min=0,max=50" example:"10"`
+}
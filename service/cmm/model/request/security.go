@@ -0,0 +1,13 @@
+package request
+
+// SecurityEventQuery filters and pages the security events listed for admins
+// @Description Security event query parameters
+type SecurityEventQuery struct {
+	Type string `form:"type" binding:"omitempty,oneof=account_locked ip_locked new_ip_login" example:"account_locked"`
+	// Only events of this user
+	UserID string `form:"user_id" binding:"omitempty,uuid" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	// Only events no admin has reviewed yet
+	Unreviewed bool `form:"unreviewed" example:"true"`
+	Limit      int  `form:"limit" binding:"min=0,max=100" example:"50"`
+	Offset     int  `form:"offset" binding:"min=0" example:"0"`
+}
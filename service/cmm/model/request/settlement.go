@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+// RunSettlement represents a settlement run over a period
+// @Description Settlement run request
+type RunSettlement struct {
+	// Start of the settled period (inclusive)
+	PeriodStart time.Time `json:"period_start" binding:"required" example:"2024-01-01T00:00:00Z"`
+	// End of the settled period (exclusive)
+	PeriodEnd time.Time `json:"period_end" binding:"required" example:"2024-02-01T00:00:00Z"`
+}
@@ -0,0 +1,25 @@
+package request
+
+// SubmitShopVerification sends a coffee shop's business details for admin review
+// @Description Shop verification request
+type SubmitShopVerification struct {
+	BusinessName    string `json:"business_name" binding:"required,max=255" example:"Cong Ty TNHH Ca Phe Sai Gon"`
+	TaxCode         string `json:"tax_code" binding:"required,max=32" example:"0312345678"`
+	BusinessAddress string `json:"business_address" binding:"required,max=255" example:"12 Nguyen Hue, District 1, Ho Chi Minh City"`
+	ContactPhone    string `json:"contact_phone" binding:"required,max=20" example:"0901234567"`
+}
+
+// UploadShopDocument is the form accompanying an uploaded verification document
+type UploadShopDocument struct {
+	DocumentType string `form:"document_type" binding:"required,oneof=business_license identity_card other"`
+}
+
+// RejectShop turns down a shop's verification with a reason shown to the owner
+// @Description Shop rejection request
+type RejectShop struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"Business license is unreadable"`
+}
+
+type ShopVerificationQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending submitted approved rejected"`
+}
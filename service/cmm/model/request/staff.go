@@ -0,0 +1,14 @@
+package request
+
+// InviteStaff invites a registered user to work at a coffee shop
+// @Description Staff invitation request
+type InviteStaff struct {
+	Email       string   `json:"email" binding:"required,email" example:"barista@example.com"`
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,oneof=manage_shop manage_rooms manage_bookings manage_menu view_reports" example:"manage_bookings,manage_menu"`
+}
+
+// UpdateStaffPermissions replaces the permissions of a staff member
+// @Description Staff permissions request
+type UpdateStaffPermissions struct {
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,oneof=manage_shop manage_rooms manage_bookings manage_menu view_reports" example:"manage_bookings"`
+}
@@ -20,4 +20,62 @@ type Register struct {
 	Email string `json:"email" binding:"required,email" example:"user@example.com"`
 	// User's password
 	Password string `json:"password" binding:"required,min=8" example:"password123"`
+	// Optional referral code of the user who invited them
+	ReferralCode string `json:"referral_code" example:"K7WQ2MZP"`
+}
+
+// UpdateProfile updates the profile of the signed-in user; omitted fields are left unchanged
+// @Description Profile update request
+type UpdateProfile struct {
+	FullName *string `json:"full_name" binding:"omitempty,min=1,max=100" example:"John Doe"`
+	// Phone number with an optional leading +; an empty string removes it
+	Phone *string `json:"phone" binding:"omitempty,max=20" example:"+84901234567"`
+	// An empty string removes the avatar
+	AvatarURL         *string `json:"avatar_url" binding:"omitempty" example:"https://example.com/avatar.jpg"`
+	PreferredLanguage *string `json:"preferred_language" binding:"omitempty,oneof=vi en" example:"en"`
+}
+
+// ChangePassword replaces the password after verifying the current one
+// @Description Change password request
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+	NewPassword     string `json:"new_password" binding:"required,min=8" example:"newpassword123"`
+}
+
+// RequestEmailChange starts an email change; the new address must be confirmed
+// @Description Email change request
+type RequestEmailChange struct {
+	NewEmail        string `json:"new_email" binding:"required,email" example:"new@example.com"`
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+}
+
+// ConfirmEmailChange confirms an email change with the token sent to the new address
+// @Description Email change confirmation request
+type ConfirmEmailChange struct {
+	Token string `json:"token" binding:"required" example:"q3Jx9..."`
+}
+
+// VerifyEmail verifies an email address with the token sent to it
+// @Description Email verification request
+type VerifyEmail struct {
+	Token string `json:"token" binding:"required" example:"q3Jx9..."`
+}
+
+// ResendVerification sends the verification email again
+// @Description Verification email resend request
+type ResendVerification struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// ForgotPassword sends a password reset email
+// @Description Forgot password request
+type ForgotPassword struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// ResetPassword sets a new password with the token from the reset email
+// @Description Password reset request
+type ResetPassword struct {
+	Token       string `json:"token" binding:"required" example:"q3Jx9..."`
+	NewPassword string `json:"new_password" binding:"required,min=8" example:"newpassword123"`
 }
@@ -0,0 +1,26 @@
+package request
+
+import "time"
+
+// CreateVoucherCampaign creates a campaign and generates its single-use codes
+// @Description Voucher campaign request
+type CreateVoucherCampaign struct {
+	Name string `json:"name" binding:"required" example:"Summer 2024"`
+	// Prepended to every generated code
+	Prefix string `json:"prefix" binding:"max=16" example:"SUMMER-"`
+	// Characters used for the random part of the code (defaults to an unambiguous upper case alphabet)
+	Alphabet string `json:"alphabet" example:"ABCDEFGHJKLMNPQRSTUVWXYZ23456789"`
+	// Length of the random part of the code (default 8)
+	CodeLength int `json:"code_length" binding:"omitempty,min=4,max=32" example:"8"`
+	// Number of codes to generate
+	Quantity        int       `json:"quantity" binding:"required,min=1,max=10000" example:"1000"`
+	DiscountType    string    `json:"discount_type" binding:"omitempty,oneof=percent fixed"`
+	DiscountPercent int       `json:"discount_percent" binding:"min=0,max=100"`
+	DiscountAmount  float64   `json:"discount_amount" binding:"min=0"`
+	MaxDiscount     float64   `json:"max_discount" binding:"min=0"`
+	MinOrderAmount  float64   `json:"min_order_amount" binding:"min=0"`
+	FirstTimeOnly   bool      `json:"first_time_only"`
+	ServiceID       int       `json:"service_id"`
+	ValidFrom       time.Time `json:"valid_from" binding:"required"`
+	ValidTo         time.Time `json:"valid_to" binding:"required"`
+}
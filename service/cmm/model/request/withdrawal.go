@@ -0,0 +1,24 @@
+package request
+
+import "github.com/google/uuid"
+
+// CreatePayoutAccount registers a bank account for withdrawals
+// @Description Payout account request
+type CreatePayoutAccount struct {
+	BankName      string `json:"bank_name" binding:"required,max=100" example:"Vietcombank"`
+	AccountNumber string `json:"account_number" binding:"required,max=34" example:"0123456789"`
+	AccountHolder string `json:"account_holder" binding:"required,max=100" example:"NGUYEN VAN A"`
+}
+
+// RequestWithdrawal asks to move wallet balance to a payout account
+// @Description Withdrawal request
+type RequestWithdrawal struct {
+	PayoutAccountID uuid.UUID `json:"payout_account_id" binding:"required"`
+	Amount          float64   `json:"amount" binding:"required,gt=0" example:"500000"`
+}
+
+// RejectWithdrawal rejects a withdrawal and releases the held amount
+// @Description Withdrawal rejection request
+type RejectWithdrawal struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"Account holder does not match"`
+}
@@ -8,27 +8,48 @@ import (
 
 // Booking responses
 type BookingResponse struct {
-	ID            uuid.UUID `json:"id"`
-	CustomerID    uuid.UUID `json:"customer_id"`
-	MeetingRoomID uuid.UUID `json:"meeting_room_id"`
-	RoomName      string    `json:"room_name,omitempty"`
-	ShopName      string    `json:"shop_name,omitempty"`
-	StartTime     time.Time `json:"start_time"`
-	EndTime       time.Time `json:"end_time"`
-	TotalPrice    float64   `json:"total_price"`
-	VoucherID     uuid.UUID `json:"voucher_id,omitempty"`
-	Status        string    `json:"status"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID             uuid.UUID `json:"id"`
+	CustomerID     uuid.UUID `json:"customer_id"`
+	MeetingRoomID  uuid.UUID `json:"meeting_room_id"`
+	RoomName       string    `json:"room_name,omitempty"`
+	ShopName       string    `json:"shop_name,omitempty"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	TotalPrice     float64   `json:"total_price"`
+	DiscountAmount float64   `json:"discount_amount,omitempty"`
+	VoucherID      uuid.UUID `json:"voucher_id,omitempty"`
+	PointsRedeemed int       `json:"points_redeemed,omitempty"`
+	PointsDiscount float64   `json:"points_discount,omitempty"`
+	PassID         uuid.UUID `json:"pass_id,omitempty"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 // Coffee Shop responses
 type CoffeeShopResponse struct {
-	ID          uuid.UUID `json:"id"`
-	OwnerID     uuid.UUID `json:"owner_id"`
-	Name        string    `json:"name"`
-	Location    string    `json:"location"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            uuid.UUID `json:"id"`
+	OwnerID       uuid.UUID `json:"owner_id"`
+	Name          string    `json:"name"`
+	Location      string    `json:"location"`
+	Description   string    `json:"description"`
+	Latitude      *float64  `json:"latitude,omitempty"`
+	Longitude     *float64  `json:"longitude,omitempty"`
+	OpenTime      string    `json:"open_time,omitempty"`
+	CloseTime     string    `json:"close_time,omitempty"`
+	Status        string    `json:"status"`
+	AverageRating float64   `json:"average_rating"`
+	ReviewCount   int64     `json:"review_count"`
+	// Only filled in for the shop's owner
+	FollowerCount *int64 `json:"follower_count,omitempty"`
+	// Gallery, only filled in when a single shop is fetched
+	Media     []MediaResponse `json:"media,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NearbyCoffeeShopResponse is a shop with its distance from the searched point
+type NearbyCoffeeShopResponse struct {
+	CoffeeShopResponse
+	DistanceKm float64 `json:"distance_km"`
 }
 
 type CommissionRateResponse struct {
@@ -39,19 +60,42 @@ type CommissionRateResponse struct {
 
 // Meeting Room responses
 type MeetingRoomResponse struct {
-	ID           uuid.UUID `json:"id"`
-	CoffeeShopID uuid.UUID `json:"coffee_shop_id"`
-	ShopName     string    `json:"shop_name,omitempty"`
-	Name         string    `json:"name"`
-	Capacity     int       `json:"capacity"`
-	PricePerHour float64   `json:"price_per_hour"`
-	Available    bool      `json:"available"`
+	ID            uuid.UUID         `json:"id"`
+	CoffeeShopID  uuid.UUID         `json:"coffee_shop_id"`
+	ShopName      string            `json:"shop_name,omitempty"`
+	FloorZoneID   *uuid.UUID        `json:"floor_zone_id,omitempty"`
+	ResourceType  string            `json:"resource_type"`
+	Name          string            `json:"name"`
+	Capacity      int               `json:"capacity"`
+	PricePerHour  float64           `json:"price_per_hour"`
+	PricePerDay   float64           `json:"price_per_day,omitempty"`
+	Available     bool              `json:"available"`
+	Amenities     []AmenityResponse `json:"amenities,omitempty"`
+	AverageRating float64           `json:"average_rating"`
+	ReviewCount   int64             `json:"review_count"`
+	// Gallery, only filled in when a single room is fetched
+	Media []MediaResponse `json:"media,omitempty"`
+}
+
+type AmenityResponse struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// ResourceAvailabilityResponse is a resource with whether it is free in the asked period and its price
+type ResourceAvailabilityResponse struct {
+	MeetingRoomResponse
+	Free  bool    `json:"free"`
+	Price float64 `json:"price"`
 }
 
 // Wallet responses
 type WalletResponse struct {
-	UserID  uuid.UUID `json:"user_id"`
-	Balance float64   `json:"balance"`
+	UserID      uuid.UUID `json:"user_id"`
+	Balance     float64   `json:"balance"`
+	HeldBalance float64   `json:"held_balance"`
+	Points      int       `json:"points"`
+	Tier        string    `json:"tier"`
 }
 
 type TopupResponse struct {
@@ -63,24 +107,56 @@ type TopupResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type WalletTransferResponse struct {
+	ID          uuid.UUID `json:"id"`
+	SenderID    uuid.UUID `json:"sender_id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+	Amount      float64   `json:"amount"`
+	Note        string    `json:"note,omitempty"`
+	Direction   string    `json:"direction"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // Voucher responses
 type VoucherResponse struct {
-	ID              uuid.UUID `json:"id"`
-	Code            string    `json:"code"`
-	DiscountPercent int       `json:"discount_percent"`
-	MaxUses         int       `json:"max_uses"`
-	UsedCount       int       `json:"used_count"`
-	ServiceID       int       `json:"service_id,omitempty"`
-	ValidFrom       time.Time `json:"valid_from"`
-	ValidTo         time.Time `json:"valid_to"`
+	ID              uuid.UUID   `json:"id"`
+	Code            string      `json:"code"`
+	DiscountType    string      `json:"discount_type"`
+	DiscountPercent int         `json:"discount_percent"`
+	DiscountAmount  float64     `json:"discount_amount,omitempty"`
+	MaxDiscount     float64     `json:"max_discount,omitempty"`
+	MinOrderAmount  float64     `json:"min_order_amount,omitempty"`
+	MaxUses         int         `json:"max_uses"`
+	UsedCount       int         `json:"used_count"`
+	PerUserLimit    int         `json:"per_user_limit"`
+	FirstTimeOnly   bool        `json:"first_time_only"`
+	ServiceID       int         `json:"service_id,omitempty"`
+	CoffeeShopID    *uuid.UUID  `json:"coffee_shop_id,omitempty"`
+	MeetingRoomIDs  []uuid.UUID `json:"meeting_room_ids,omitempty"`
+	ValidFrom       time.Time   `json:"valid_from"`
+	ValidTo         time.Time   `json:"valid_to"`
 }
 
 type VoucherCalculation struct {
 	OriginalAmount  float64 `json:"original_amount"`
 	DiscountAmount  float64 `json:"discount_amount"`
 	FinalAmount     float64 `json:"final_amount"`
+	DiscountType    string  `json:"discount_type"`
 	DiscountPercent int     `json:"discount_percent"`
 	VoucherCode     string  `json:"voucher_code"`
+	AppliedRule     string  `json:"applied_rule"`
+}
+
+type VoucherRedemptionResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	VoucherID      uuid.UUID  `json:"voucher_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	ServiceID      int        `json:"service_id"`
+	ServiceRefID   uuid.UUID  `json:"service_ref_id"`
+	DiscountAmount float64    `json:"discount_amount"`
+	Status         string     `json:"status"`
+	RedeemedAt     time.Time  `json:"redeemed_at"`
+	ReleasedAt     *time.Time `json:"released_at,omitempty"`
 }
 
 // Post responses
@@ -91,6 +167,8 @@ type ShopPostResponse struct {
 	Title        string    `json:"title"`
 	Content      string    `json:"content"`
 	PublishedAt  time.Time `json:"published_at"`
+	// Gallery, only filled in when a single post is fetched
+	Media []MediaResponse `json:"media,omitempty"`
 }
 
 type InternalPostResponse struct {
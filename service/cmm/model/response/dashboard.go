@@ -0,0 +1,44 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dashboard responses
+type DashboardPeriod struct {
+	Period              time.Time `json:"period"`
+	Revenue             float64   `json:"revenue"`
+	Bookings            int64     `json:"bookings"`
+	AverageBookingHours float64   `json:"average_booking_hours"`
+	VoucherUses         int64     `json:"voucher_uses"`
+	VoucherDiscount     float64   `json:"voucher_discount"`
+	CommissionPaid      float64   `json:"commission_paid"`
+	OwnerEarnings       float64   `json:"owner_earnings"`
+}
+
+type ShopDashboardResponse struct {
+	CoffeeShopID uuid.UUID         `json:"coffee_shop_id"`
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	Interval     string            `json:"interval"`
+	Totals       DashboardPeriod   `json:"totals"`
+	Periods      []DashboardPeriod `json:"periods"`
+}
+
+type RoomOccupancyResponse struct {
+	MeetingRoomID uuid.UUID `json:"meeting_room_id"`
+	RoomName      string    `json:"room_name"`
+	Bookings      int64     `json:"bookings"`
+	BookedHours   float64   `json:"booked_hours"`
+	OccupancyRate float64   `json:"occupancy_rate"`
+}
+
+type ShopOccupancyResponse struct {
+	CoffeeShopID   uuid.UUID               `json:"coffee_shop_id"`
+	From           time.Time               `json:"from"`
+	To             time.Time               `json:"to"`
+	AvailableHours float64                 `json:"available_hours"`
+	Rooms          []RoomOccupancyResponse `json:"rooms"`
+}
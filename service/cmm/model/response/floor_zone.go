@@ -0,0 +1,16 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Floor zone responses
+type FloorZoneResponse struct {
+	ID           uuid.UUID `json:"id"`
+	CoffeeShopID uuid.UUID `json:"coffee_shop_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
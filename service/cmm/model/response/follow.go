@@ -0,0 +1,16 @@
+package response
+
+import "github.com/google/uuid"
+
+// Follow responses
+type FeedResponse struct {
+	Posts   []ShopPostResponse `json:"posts"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+	HasMore bool               `json:"has_more"`
+}
+
+type FollowerCountResponse struct {
+	CoffeeShopID  uuid.UUID `json:"coffee_shop_id"`
+	FollowerCount int64     `json:"follower_count"`
+}
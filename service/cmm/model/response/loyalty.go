@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Loyalty responses
+type LoyaltyResponse struct {
+	Points                  int     `json:"points"`
+	PointValue              float64 `json:"point_value"`
+	PointsValue             float64 `json:"points_value"`
+	PointsExpiringSoon      int     `json:"points_expiring_soon"`
+	Tier                    string  `json:"tier"`
+	RollingSpend            float64 `json:"rolling_spend"`
+	NextTier                string  `json:"next_tier,omitempty"`
+	SpendToNextTier         float64 `json:"spend_to_next_tier,omitempty"`
+	EarnBonusPercent        int     `json:"earn_bonus_percent"`
+	CancellationWindowHours int     `json:"cancellation_window_hours"`
+}
+
+type PointEntryResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Type         string     `json:"type"`
+	Points       int        `json:"points"`
+	ServiceID    int        `json:"service_id"`
+	ServiceRefID uuid.UUID  `json:"service_ref_id"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
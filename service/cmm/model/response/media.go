@@ -0,0 +1,21 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media responses
+type MediaResponse struct {
+	ID           uuid.UUID `json:"id"`
+	OwnerType    string    `json:"owner_type"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pass responses
+type PassProductResponse struct {
+	ID           uuid.UUID `json:"id"`
+	CoffeeShopID uuid.UUID `json:"coffee_shop_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Type         string    `json:"type"`
+	Hours        float64   `json:"hours,omitempty"`
+	ValidityDays int       `json:"validity_days"`
+	Price        float64   `json:"price"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PassUsageResponse struct {
+	ID        uuid.UUID `json:"id"`
+	BookingID uuid.UUID `json:"booking_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Hours     float64   `json:"hours"`
+	Status    string    `json:"status"`
+}
+
+type UserPassResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	PassProductID  uuid.UUID           `json:"pass_product_id"`
+	CoffeeShopID   uuid.UUID           `json:"coffee_shop_id"`
+	Name           string              `json:"name"`
+	Type           string              `json:"type"`
+	TotalHours     float64             `json:"total_hours,omitempty"`
+	RemainingHours float64             `json:"remaining_hours,omitempty"`
+	Price          float64             `json:"price"`
+	Status         string              `json:"status"`
+	StartsAt       time.Time           `json:"starts_at"`
+	ExpiresAt      time.Time           `json:"expires_at"`
+	PurchasedAt    time.Time           `json:"purchased_at"`
+	Usages         []PassUsageResponse `json:"usages,omitempty"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referral responses
+type ReferralResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	RefereeID      uuid.UUID  `json:"referee_id"`
+	Status         string     `json:"status"`
+	ReferrerReward float64    `json:"referrer_reward"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RewardedAt     *time.Time `json:"rewarded_at,omitempty"`
+}
+
+type ReferralSummaryResponse struct {
+	ReferralCode string             `json:"referral_code"`
+	Referred     int                `json:"referred"`
+	Rewarded     int                `json:"rewarded"`
+	Pending      int                `json:"pending"`
+	TotalEarned  float64            `json:"total_earned"`
+	Referrals    []ReferralResponse `json:"referrals"`
+}
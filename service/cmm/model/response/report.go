@@ -0,0 +1,36 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Admin report responses
+type PlatformOverviewResponse struct {
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	GMV              float64   `json:"gmv"`
+	NetRevenue       float64   `json:"net_revenue"`
+	Bookings         int64     `json:"bookings"`
+	RefundRate       float64   `json:"refund_rate"`
+	RefundAmount     float64   `json:"refund_amount"`
+	VoucherCost      float64   `json:"voucher_cost"`
+	ActiveUsers      int64     `json:"active_users"`
+	NewRegistrations int64     `json:"new_registrations"`
+	TopupVolume      float64   `json:"topup_volume"`
+}
+
+type TopShopResponse struct {
+	CoffeeShopID uuid.UUID `json:"coffee_shop_id"`
+	ShopName     string    `json:"shop_name"`
+	Bookings     int64     `json:"bookings"`
+	GMV          float64   `json:"gmv"`
+	VoucherCost  float64   `json:"voucher_cost"`
+}
+
+type TopupMethodResponse struct {
+	Method string  `json:"method"`
+	Count  int64   `json:"count"`
+	Amount float64 `json:"amount"`
+}
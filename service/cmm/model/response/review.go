@@ -0,0 +1,23 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review responses
+type ReviewResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	BookingID     uuid.UUID  `json:"booking_id"`
+	CustomerID    uuid.UUID  `json:"customer_id"`
+	CoffeeShopID  uuid.UUID  `json:"coffee_shop_id"`
+	MeetingRoomID uuid.UUID  `json:"meeting_room_id"`
+	Rating        int        `json:"rating"`
+	Comment       string     `json:"comment,omitempty"`
+	Status        string     `json:"status"`
+	OwnerReply    string     `json:"owner_reply,omitempty"`
+	RepliedAt     *time.Time `json:"replied_at,omitempty"`
+	HiddenReason  string     `json:"hidden_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
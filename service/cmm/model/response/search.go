@@ -0,0 +1,27 @@
+package response
+
+// Search responses
+type SearchResponse struct {
+	Shops []ShopSearchResponse `json:"shops,omitempty"`
+	Rooms []RoomSearchResponse `json:"rooms,omitempty"`
+	Posts []PostSearchResponse `json:"posts,omitempty"`
+}
+
+// ShopSearchResponse is a matching shop with a highlighted excerpt of its description
+type ShopSearchResponse struct {
+	CoffeeShopResponse
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline,omitempty"`
+}
+
+type RoomSearchResponse struct {
+	MeetingRoomResponse
+	Rank float64 `json:"rank"`
+}
+
+// PostSearchResponse is a matching post with a highlighted excerpt of its content
+type PostSearchResponse struct {
+	ShopPostResponse
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline,omitempty"`
+}
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Security event responses
+type SecurityEventResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Type        string     `json:"type"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	FullName    string     `json:"full_name,omitempty"`
+	Email       string     `json:"email"`
+	IP          string     `json:"ip"`
+	UserAgent   string     `json:"user_agent"`
+	Failures    int        `json:"failures,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type SecurityEventListResponse struct {
+	Events  []SecurityEventResponse `json:"events"`
+	Limit   int                     `json:"limit"`
+	Offset  int                     `json:"offset"`
+	HasMore bool                    `json:"has_more"`
+}
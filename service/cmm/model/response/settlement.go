@@ -0,0 +1,45 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Settlement responses
+type EarningResponse struct {
+	ID               uuid.UUID `json:"id"`
+	CoffeeShopID     uuid.UUID `json:"coffee_shop_id"`
+	ServiceID        int       `json:"service_id"`
+	ServiceRefID     uuid.UUID `json:"service_ref_id"`
+	GrossAmount      float64   `json:"gross_amount"`
+	DiscountAmount   float64   `json:"discount_amount,omitempty"`
+	DiscountFundedBy string    `json:"discount_funded_by,omitempty"`
+	RatePercent      int       `json:"rate_percent"`
+	CommissionAmount float64   `json:"commission_amount"`
+	OwnerAmount      float64   `json:"owner_amount"`
+	EarnedAt         time.Time `json:"earned_at"`
+}
+
+type PayoutStatementResponse struct {
+	ID               uuid.UUID         `json:"id"`
+	SettlementID     uuid.UUID         `json:"settlement_id"`
+	OwnerID          uuid.UUID         `json:"owner_id"`
+	GrossAmount      float64           `json:"gross_amount"`
+	CommissionAmount float64           `json:"commission_amount"`
+	PayoutAmount     float64           `json:"payout_amount"`
+	ItemCount        int               `json:"item_count"`
+	Status           string            `json:"status"`
+	PaidAt           *time.Time        `json:"paid_at,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	Items            []EarningResponse `json:"items,omitempty"`
+}
+
+type SettlementResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	PeriodStart time.Time                 `json:"period_start"`
+	PeriodEnd   time.Time                 `json:"period_end"`
+	CreatedBy   uuid.UUID                 `json:"created_by"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	Statements  []PayoutStatementResponse `json:"statements,omitempty"`
+}
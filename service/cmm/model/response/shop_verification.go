@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shop verification responses
+type ShopVerificationResponse struct {
+	CoffeeShopID    uuid.UUID              `json:"coffee_shop_id"`
+	ShopName        string                 `json:"shop_name"`
+	OwnerID         uuid.UUID              `json:"owner_id"`
+	Status          string                 `json:"status"`
+	BusinessName    string                 `json:"business_name,omitempty"`
+	TaxCode         string                 `json:"tax_code,omitempty"`
+	BusinessAddress string                 `json:"business_address,omitempty"`
+	ContactPhone    string                 `json:"contact_phone,omitempty"`
+	SubmittedAt     *time.Time             `json:"submitted_at,omitempty"`
+	RejectionReason string                 `json:"rejection_reason,omitempty"`
+	ReviewedAt      *time.Time             `json:"reviewed_at,omitempty"`
+	Documents       []ShopDocumentResponse `json:"documents,omitempty"`
+}
+
+type ShopDocumentResponse struct {
+	ID           uuid.UUID `json:"id"`
+	DocumentType string    `json:"document_type"`
+	FileName     string    `json:"file_name,omitempty"`
+	URL          string    `json:"url"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	CreatedAt    time.Time `json:"created_at"`
+}
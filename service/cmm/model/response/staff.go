@@ -0,0 +1,21 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Staff responses
+type StaffResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	CoffeeShopID uuid.UUID  `json:"coffee_shop_id"`
+	ShopName     string     `json:"shop_name,omitempty"`
+	UserID       uuid.UUID  `json:"user_id"`
+	FullName     string     `json:"full_name,omitempty"`
+	Email        string     `json:"email,omitempty"`
+	Permissions  []string   `json:"permissions"`
+	Status       string     `json:"status"`
+	AcceptedAt   *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Profile responses
+type ProfileResponse struct {
+	ID            uuid.UUID `json:"id"`
+	FullName      string    `json:"full_name"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	// New address waiting for confirmation, if an email change is in progress
+	PendingEmail      string    `json:"pending_email,omitempty"`
+	Phone             string    `json:"phone"`
+	AvatarURL         string    `json:"avatar_url"`
+	PreferredLanguage string    `json:"preferred_language"`
+	Role              string    `json:"role"`
+	ReferralCode      *string   `json:"referral_code,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
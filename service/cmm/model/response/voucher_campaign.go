@@ -0,0 +1,22 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Voucher campaign responses
+type VoucherCampaignResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	Prefix           string    `json:"prefix,omitempty"`
+	CodeLength       int       `json:"code_length"`
+	TotalCodes       int64     `json:"total_codes"`
+	RedeemedCodes    int64     `json:"redeemed_codes"`
+	OutstandingCodes int64     `json:"outstanding_codes"`
+	Redemptions      int64     `json:"redemptions"`
+	DiscountTotal    float64   `json:"discount_total"`
+	CreatedBy        uuid.UUID `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Withdrawal responses
+type PayoutAccountResponse struct {
+	ID            uuid.UUID `json:"id"`
+	BankName      string    `json:"bank_name"`
+	AccountNumber string    `json:"account_number"`
+	AccountHolder string    `json:"account_holder"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WithdrawalResponse struct {
+	ID              uuid.UUID                 `json:"id"`
+	UserID          uuid.UUID                 `json:"user_id"`
+	PayoutAccountID uuid.UUID                 `json:"payout_account_id"`
+	PayoutAccount   *PayoutAccountResponse    `json:"payout_account,omitempty"`
+	Amount          float64                   `json:"amount"`
+	Status          string                    `json:"status"`
+	RejectReason    string                    `json:"reject_reason,omitempty"`
+	ProcessedBy     *uuid.UUID                `json:"processed_by,omitempty"`
+	ProcessedAt     *time.Time                `json:"processed_at,omitempty"`
+	PaidAt          *time.Time                `json:"paid_at,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	History         []WithdrawalEventResponse `json:"history,omitempty"`
+}
+
+type WithdrawalEventResponse struct {
+	Status    string     `json:"status"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
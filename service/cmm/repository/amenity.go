@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IAmenityRepo interface {
+	GetAllAmenities() ([]entity.Amenity, error)
+	CountAmenities(codes []string) (int64, error)
+	SetRoomAmenities(roomIDs []uuid.UUID, codes []string) error
+	GetAmenitiesByRooms(roomIDs []uuid.UUID) (map[uuid.UUID][]entity.Amenity, error)
+}
+
+type amenityRepo struct {
+	db *gorm.DB
+}
+
+func NewAmenityRepo(db *gorm.DB) IAmenityRepo {
+	return &amenityRepo{
+		db: db,
+	}
+}
+
+// roomAmenityRow is an amenity together with the room it is linked to
+type roomAmenityRow struct {
+	MeetingRoomID uuid.UUID
+	Code          string
+	Name          string
+}
+
+func (r *amenityRepo) GetAllAmenities() ([]entity.Amenity, error) {
+	logger.Info("GetAllAmenities repository method called")
+	var amenities []entity.Amenity
+	err := r.db.Order("name").Find(&amenities).Error
+	return amenities, err
+}
+
+// CountAmenities counts how many of the given codes exist in the catalog
+func (r *amenityRepo) CountAmenities(codes []string) (int64, error) {
+	logger.Info("CountAmenities repository method called")
+	var count int64
+	err := r.db.Model(&entity.Amenity{}).Where("code IN ?", codes).Count(&count).Error
+	return count, err
+}
+
+// SetRoomAmenities replaces the amenities of each given room with the given codes
+func (r *amenityRepo) SetRoomAmenities(roomIDs []uuid.UUID, codes []string) error {
+	logger.Info("SetRoomAmenities repository method called")
+	if len(roomIDs) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("meeting_room_id IN ?", roomIDs).Delete(&entity.RoomAmenity{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+
+		links := make([]entity.RoomAmenity, 0, len(roomIDs)*len(codes))
+		for _, roomID := range roomIDs {
+			for _, code := range codes {
+				links = append(links, entity.RoomAmenity{MeetingRoomID: roomID, AmenityCode: code})
+			}
+		}
+		return tx.CreateInBatches(links, 500).Error
+	})
+}
+
+// GetAmenitiesByRooms returns the amenities of each given room, keyed by room ID
+func (r *amenityRepo) GetAmenitiesByRooms(roomIDs []uuid.UUID) (map[uuid.UUID][]entity.Amenity, error) {
+	logger.Info("GetAmenitiesByRooms repository method called")
+	result := make(map[uuid.UUID][]entity.Amenity)
+	if len(roomIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []roomAmenityRow
+	err := r.db.Table("room_amenities").
+		Select("room_amenities.meeting_room_id, amenities.code, amenities.name").
+		Joins("JOIN amenities ON amenities.code = room_amenities.amenity_code").
+		Where("room_amenities.meeting_room_id IN ?", roomIDs).
+		Order("amenities.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.MeetingRoomID] = append(result[row.MeetingRoomID], entity.Amenity{Code: row.Code, Name: row.Name})
+	}
+	return result, nil
+}
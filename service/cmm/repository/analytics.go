@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+// ShopPeriodStats holds the aggregated figures of one shop for one period bucket
+type ShopPeriodStats struct {
+	Period          time.Time `gorm:"column:period"`
+	Revenue         float64   `gorm:"column:revenue"`
+	Bookings        int64     `gorm:"column:bookings"`
+	BookedHours     float64   `gorm:"column:booked_hours"`
+	VoucherUses     int64     `gorm:"column:voucher_uses"`
+	VoucherDiscount float64   `gorm:"column:voucher_discount"`
+	Commission      float64   `gorm:"column:commission"`
+	OwnerEarnings   float64   `gorm:"column:owner_earnings"`
+}
+
+// RoomOccupancyStats holds the booked time of one room within a range
+type RoomOccupancyStats struct {
+	MeetingRoomID uuid.UUID `gorm:"column:meeting_room_id"`
+	RoomName      string    `gorm:"column:room_name"`
+	Bookings      int64     `gorm:"column:bookings"`
+	BookedHours   float64   `gorm:"column:booked_hours"`
+}
+
+// PlatformOverviewStats holds platform-wide figures within a range
+type PlatformOverviewStats struct {
+	GMV               float64 `gorm:"column:gmv"`
+	NetRevenue        float64 `gorm:"column:net_revenue"`
+	Bookings          int64   `gorm:"column:bookings"`
+	CancelledBookings int64   `gorm:"column:cancelled_bookings"`
+	RefundAmount      float64 `gorm:"column:refund_amount"`
+	VoucherCost       float64 `gorm:"column:voucher_cost"`
+	ActiveUsers       int64   `gorm:"column:active_users"`
+	NewRegistrations  int64   `gorm:"column:new_registrations"`
+	TopupVolume       float64 `gorm:"column:topup_volume"`
+}
+
+// TopShopStats holds the booking figures of one shop within a range
+type TopShopStats struct {
+	CoffeeShopID uuid.UUID `gorm:"column:coffee_shop_id"`
+	ShopName     string    `gorm:"column:shop_name"`
+	Bookings     int64     `gorm:"column:bookings"`
+	GMV          float64   `gorm:"column:gmv"`
+	VoucherCost  float64   `gorm:"column:voucher_cost"`
+}
+
+// TopupMethodStats holds the completed top-ups of one payment method within a range
+type TopupMethodStats struct {
+	Method string  `gorm:"column:method"`
+	Count  int64   `gorm:"column:count"`
+	Amount float64 `gorm:"column:amount"`
+}
+
+type IAnalyticsRepo interface {
+	// Owner dashboard
+	GetShopPeriodStats(shopID uuid.UUID, from, to time.Time, interval string) ([]ShopPeriodStats, error)
+	GetShopRoomOccupancy(shopID uuid.UUID, from, to time.Time) ([]RoomOccupancyStats, error)
+
+	// Platform reports
+	GetPlatformOverview(from, to time.Time) (*PlatformOverviewStats, error)
+	GetTopShops(from, to time.Time, limit int) ([]TopShopStats, error)
+	GetTopupVolumeByMethod(from, to time.Time) ([]TopupMethodStats, error)
+}
+
+type analyticsRepo struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepo(db *gorm.DB) IAnalyticsRepo {
+	return &analyticsRepo{
+		db: db,
+	}
+}
+
+// GetShopPeriodStats buckets a shop's bookings, booking payments and earnings by
+// day, week or month. Every bucket in the range is returned, even when empty.
+func (r *analyticsRepo) GetShopPeriodStats(shopID uuid.UUID, from, to time.Time, interval string) ([]ShopPeriodStats, error) {
+	logger.Info("GetShopPeriodStats repository method called")
+	var stats []ShopPeriodStats
+	err := r.db.Raw(`
+		WITH periods AS (
+			SELECT generate_series(
+				date_trunc(@interval, CAST(@from AS timestamptz)),
+				date_trunc(@interval, CAST(@to AS timestamptz) - interval '1 microsecond'),
+				('1 ' || @interval)::interval
+			) AS period
+		),
+		booking_stats AS (
+			SELECT date_trunc(@interval, b.start_time) AS period,
+				COUNT(*) AS bookings,
+				SUM(EXTRACT(EPOCH FROM (b.end_time - b.start_time)) / 3600) AS booked_hours,
+				COUNT(*) FILTER (WHERE b.voucher_id <> @nil_id) AS voucher_uses,
+				SUM(b.discount_amount) AS voucher_discount
+			FROM bookings b
+			JOIN meeting_rooms r ON r.id = b.meeting_room_id
+			WHERE r.coffee_shop_id = @shop_id
+				AND b.status <> 'cancelled'
+				AND b.start_time >= @from AND b.start_time < @to
+			GROUP BY 1
+		),
+		revenue_stats AS (
+			SELECT date_trunc(@interval, t.paid_at) AS period,
+				SUM(t.amount) AS revenue
+			FROM transactions t
+			JOIN bookings b ON b.id = t.service_ref_id
+			JOIN meeting_rooms r ON r.id = b.meeting_room_id
+			WHERE t.service_id = @booking_service
+				AND r.coffee_shop_id = @shop_id
+				AND t.paid_at >= @from AND t.paid_at < @to
+			GROUP BY 1
+		),
+		earning_stats AS (
+			SELECT date_trunc(@interval, e.earned_at) AS period,
+				SUM(e.commission_amount) AS commission,
+				SUM(e.owner_amount) AS owner_earnings
+			FROM earnings e
+			WHERE e.coffee_shop_id = @shop_id
+				AND e.earned_at >= @from AND e.earned_at < @to
+			GROUP BY 1
+		)
+		SELECT p.period,
+			COALESCE(rs.revenue, 0) AS revenue,
+			COALESCE(bs.bookings, 0) AS bookings,
+			COALESCE(bs.booked_hours, 0) AS booked_hours,
+			COALESCE(bs.voucher_uses, 0) AS voucher_uses,
+			COALESCE(bs.voucher_discount, 0) AS voucher_discount,
+			COALESCE(es.commission, 0) AS commission,
+			COALESCE(es.owner_earnings, 0) AS owner_earnings
+		FROM periods p
+		LEFT JOIN booking_stats bs ON bs.period = p.period
+		LEFT JOIN revenue_stats rs ON rs.period = p.period
+		LEFT JOIN earning_stats es ON es.period = p.period
+		ORDER BY p.period ASC
+	`, map[string]interface{}{
+		"interval":        interval,
+		"from":            from,
+		"to":              to,
+		"shop_id":         shopID,
+		"nil_id":          uuid.Nil,
+		"booking_service": entity.ServiceBooking,
+	}).Scan(&stats).Error
+	return stats, err
+}
+
+// GetShopRoomOccupancy sums the booked hours of every room of a shop, clipped to the range
+func (r *analyticsRepo) GetShopRoomOccupancy(shopID uuid.UUID, from, to time.Time) ([]RoomOccupancyStats, error) {
+	logger.Info("GetShopRoomOccupancy repository method called")
+	var stats []RoomOccupancyStats
+	err := r.db.Raw(`
+		SELECT r.id AS meeting_room_id,
+			r.name AS room_name,
+			COUNT(b.id) AS bookings,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (LEAST(b.end_time, @to) - GREATEST(b.start_time, @from))) / 3600), 0) AS booked_hours
+		FROM meeting_rooms r
+		LEFT JOIN bookings b ON b.meeting_room_id = r.id
+			AND b.status <> 'cancelled'
+			AND b.start_time < @to AND b.end_time > @from
+		WHERE r.coffee_shop_id = @shop_id
+		GROUP BY r.id, r.name
+		ORDER BY r.name ASC
+	`, map[string]interface{}{
+		"from":    from,
+		"to":      to,
+		"shop_id": shopID,
+	}).Scan(&stats).Error
+	return stats, err
+}
+
+// GetPlatformOverview aggregates bookings, transactions, users and top-ups in one round trip
+func (r *analyticsRepo) GetPlatformOverview(from, to time.Time) (*PlatformOverviewStats, error) {
+	logger.Info("GetPlatformOverview repository method called")
+	var stats PlatformOverviewStats
+	err := r.db.Raw(`
+		WITH booking_stats AS (
+			SELECT COALESCE(SUM(b.total_price + b.discount_amount) FILTER (WHERE b.status <> 'cancelled'), 0) AS gmv,
+				COUNT(*) AS bookings,
+				COUNT(*) FILTER (WHERE b.status = 'cancelled') AS cancelled_bookings,
+				COALESCE(SUM(b.discount_amount) FILTER (WHERE b.status <> 'cancelled'), 0) AS voucher_cost
+			FROM bookings b
+			WHERE b.created_at >= @from AND b.created_at < @to
+		),
+		transaction_stats AS (
+			SELECT COALESCE(SUM(t.amount) FILTER (WHERE t.service_id = @booking_service), 0) AS net_revenue,
+				COALESCE(-SUM(t.amount) FILTER (WHERE t.service_id = @booking_service AND t.status = 'refunded'), 0) AS refund_amount,
+				COUNT(DISTINCT t.user_id) AS active_users
+			FROM transactions t
+			WHERE t.paid_at >= @from AND t.paid_at < @to
+		),
+		user_stats AS (
+			SELECT COUNT(*) AS new_registrations
+			FROM users u
+			WHERE u.created_at >= @from AND u.created_at < @to
+		),
+		topup_stats AS (
+			SELECT COALESCE(SUM(tp.amount), 0) AS topup_volume
+			FROM topups tp
+			WHERE tp.status = 'completed' AND tp.created_at >= @from AND tp.created_at < @to
+		)
+		SELECT * FROM booking_stats, transaction_stats, user_stats, topup_stats
+	`, map[string]interface{}{
+		"from":            from,
+		"to":              to,
+		"booking_service": entity.ServiceBooking,
+	}).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *analyticsRepo) GetTopShops(from, to time.Time, limit int) ([]TopShopStats, error) {
+	logger.Info("GetTopShops repository method called")
+	var stats []TopShopStats
+	err := r.db.Raw(`
+		SELECT s.id AS coffee_shop_id,
+			s.name AS shop_name,
+			COUNT(b.id) AS bookings,
+			COALESCE(SUM(b.total_price + b.discount_amount), 0) AS gmv,
+			COALESCE(SUM(b.discount_amount), 0) AS voucher_cost
+		FROM coffee_shops s
+		JOIN meeting_rooms r ON r.coffee_shop_id = s.id
+		JOIN bookings b ON b.meeting_room_id = r.id
+		WHERE b.status <> 'cancelled'
+			AND b.created_at >= @from AND b.created_at < @to
+		GROUP BY s.id, s.name
+		ORDER BY gmv DESC
+		LIMIT @limit
+	`, map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"limit": limit,
+	}).Scan(&stats).Error
+	return stats, err
+}
+
+func (r *analyticsRepo) GetTopupVolumeByMethod(from, to time.Time) ([]TopupMethodStats, error) {
+	logger.Info("GetTopupVolumeByMethod repository method called")
+	var stats []TopupMethodStats
+	err := r.db.Model(&entity.Topup{}).
+		Select("method, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("status = ? AND created_at >= ? AND created_at < ?", "completed", from, to).
+		Group("method").
+		Order("amount DESC").
+		Scan(&stats).Error
+	return stats, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +16,10 @@ type IBookingRepo interface {
 	GetBookingsByCustomer(customerID uuid.UUID) ([]entity.Booking, error)
 	GetBookingsByMeetingRoom(roomID uuid.UUID) ([]entity.Booking, error)
 	UpdateBookingStatus(id uuid.UUID, status string) error
+	CompleteBooking(id uuid.UUID, earning *entity.Earning) (bool, error)
 	CheckRoomAvailability(roomID uuid.UUID, startTime, endTime time.Time) (bool, error)
-	CancelBooking(id uuid.UUID) error
+	GetBookedRoomIDs(roomIDs []uuid.UUID, startTime, endTime time.Time) ([]uuid.UUID, error)
+	CancelBooking(id uuid.UUID, refund *entity.Transaction) (bool, error)
 }
 
 type bookingRepo struct {
@@ -63,6 +66,34 @@ func (r *bookingRepo) UpdateBookingStatus(id uuid.UUID, status string) error {
 	return r.db.Model(&entity.Booking{}).Where("id = ?", id).Update("status", status).Error
 }
 
+// CompleteBooking marks a booked booking completed and records the shop's earning, if any,
+// in one database transaction
+func (r *bookingRepo) CompleteBooking(id uuid.UUID, earning *entity.Earning) (bool, error) {
+	logger.Info("CompleteBooking repository method called")
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Booking{}).
+			Where("id = ? AND status = ?", id, "booked").
+			Update("status", "completed")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if earning != nil {
+			if err := tx.Create(earning).Error; err != nil {
+				return err
+			}
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
 func (r *bookingRepo) CheckRoomAvailability(roomID uuid.UUID, startTime, endTime time.Time) (bool, error) {
 	logger.Info("CheckRoomAvailability repository method called")
 	var count int64
@@ -84,7 +115,56 @@ func (r *bookingRepo) CheckRoomAvailability(roomID uuid.UUID, startTime, endTime
 	return count == 0, nil
 }
 
-func (r *bookingRepo) CancelBooking(id uuid.UUID) error {
+// GetBookedRoomIDs returns which of the given rooms have a booking overlapping the period
+func (r *bookingRepo) GetBookedRoomIDs(roomIDs []uuid.UUID, startTime, endTime time.Time) ([]uuid.UUID, error) {
+	logger.Info("GetBookedRoomIDs repository method called")
+	var bookedIDs []uuid.UUID
+	if len(roomIDs) == 0 {
+		return bookedIDs, nil
+	}
+	err := r.db.Model(&entity.Booking{}).
+		Distinct("meeting_room_id").
+		Where("meeting_room_id IN ?", roomIDs).
+		Where("status != ?", "cancelled").
+		Where("start_time < ? AND end_time > ?", endTime, startTime).
+		Pluck("meeting_room_id", &bookedIDs).Error
+	return bookedIDs, err
+}
+
+// CancelBooking cancels a booked booking and, when a refund is given, credits the customer's
+// wallet and records the refund transaction in one database transaction. The refund transaction
+// carries a negative amount. It returns false when the booking is no longer booked.
+func (r *bookingRepo) CancelBooking(id uuid.UUID, refund *entity.Transaction) (bool, error) {
 	logger.Info("CancelBooking repository method called")
-	return r.db.Model(&entity.Booking{}).Where("id = ?", id).Update("status", "cancelled").Error
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Booking{}).
+			Where("id = ? AND status = ?", id, "booked").
+			Update("status", "cancelled")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if refund != nil {
+			result = tx.Model(&entity.Wallet{}).
+				Where("user_id = ?", refund.UserID).
+				Update("balance", gorm.Expr("balance + ?", -refund.Amount))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("customer wallet not found")
+			}
+			if err := tx.Create(refund).Error; err != nil {
+				return err
+			}
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
 }
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"math"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
@@ -11,7 +14,8 @@ type ICoffeeShopRepo interface {
 	CreateCoffeeShop(shop *entity.CoffeeShop) error
 	GetCoffeeShopByID(id uuid.UUID) (*entity.CoffeeShop, error)
 	GetCoffeeShopsByOwner(ownerID uuid.UUID) ([]entity.CoffeeShop, error)
-	GetAllCoffeeShops() ([]entity.CoffeeShop, error)
+	GetApprovedCoffeeShops() ([]entity.CoffeeShop, error)
+	FindNearbyCoffeeShops(filter NearbyShopFilter) ([]NearbyShopResult, error)
 	UpdateCoffeeShop(shop *entity.CoffeeShop) error
 	DeleteCoffeeShop(id uuid.UUID) error
 
@@ -20,6 +24,26 @@ type ICoffeeShopRepo interface {
 	GetCommissionRate(shopID uuid.UUID) (*entity.CommissionRate, error)
 }
 
+// NearbyShopFilter narrows a nearby search. OpenAt is a HH:MM time of day and
+// FreeAt a moment at which the shop must have an unbooked room; zero values leave them out.
+type NearbyShopFilter struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	OpenAt    string
+	FreeAt    *time.Time
+	Limit     int
+}
+
+// NearbyShopResult is a shop found by a nearby search with its distance from the searched point
+type NearbyShopResult struct {
+	entity.CoffeeShop `gorm:"embedded"`
+	DistanceKm        float64
+}
+
+// earthRadiusKm is the mean Earth radius used by the Haversine formula
+const earthRadiusKm = 6371.0
+
 type coffeeShopRepo struct {
 	db *gorm.DB
 }
@@ -52,13 +76,68 @@ func (r *coffeeShopRepo) GetCoffeeShopsByOwner(ownerID uuid.UUID) ([]entity.Coff
 	return shops, err
 }
 
-func (r *coffeeShopRepo) GetAllCoffeeShops() ([]entity.CoffeeShop, error) {
-	logger.Info("GetAllCoffeeShops repository method called")
+// GetApprovedCoffeeShops returns the shops listed publicly
+func (r *coffeeShopRepo) GetApprovedCoffeeShops() ([]entity.CoffeeShop, error) {
+	logger.Info("GetApprovedCoffeeShops repository method called")
 	var shops []entity.CoffeeShop
-	err := r.db.Find(&shops).Error
+	err := r.db.Where("status = ?", entity.ShopApproved).Find(&shops).Error
 	return shops, err
 }
 
+// FindNearbyCoffeeShops returns shops within the radius, closest first. Distances use the
+// Haversine formula in plain SQL; a bounding box on latitude and longitude narrows the rows first.
+func (r *coffeeShopRepo) FindNearbyCoffeeShops(filter NearbyShopFilter) ([]NearbyShopResult, error) {
+	logger.Info("FindNearbyCoffeeShops repository method called")
+	inner := r.db.Model(&entity.CoffeeShop{}).
+		Select(`coffee_shops.*, ? * 2 * ASIN(LEAST(1, SQRT(
+			POWER(SIN(RADIANS(latitude - ?) / 2), 2) +
+			COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)
+		))) AS distance_km`, earthRadiusKm, filter.Latitude, filter.Latitude, filter.Longitude).
+		Where("latitude IS NOT NULL AND longitude IS NOT NULL").
+		Where("status = ?", entity.ShopApproved)
+
+	// One degree of latitude is about 111 km; a degree of longitude shrinks with the cosine of the latitude
+	latDelta := filter.RadiusKm / 111.045
+	inner = inner.Where("latitude BETWEEN ? AND ?", filter.Latitude-latDelta, filter.Latitude+latDelta)
+	if filter.Latitude+latDelta < 90 && filter.Latitude-latDelta > -90 {
+		lngDelta := latDelta / math.Cos(filter.Latitude*math.Pi/180)
+		// Skip the longitude box when it wraps around the antimeridian
+		if filter.Longitude-lngDelta >= -180 && filter.Longitude+lngDelta <= 180 {
+			inner = inner.Where("longitude BETWEEN ? AND ?", filter.Longitude-lngDelta, filter.Longitude+lngDelta)
+		}
+	}
+
+	if filter.OpenAt != "" {
+		inner = inner.Where(`open_time != '' AND (
+			(open_time < close_time AND open_time <= ? AND close_time > ?) OR
+			(open_time > close_time AND (open_time <= ? OR close_time > ?)) OR
+			open_time = close_time
+		)`, filter.OpenAt, filter.OpenAt, filter.OpenAt, filter.OpenAt)
+	}
+	if filter.FreeAt != nil {
+		inner = inner.Where(`EXISTS (
+			SELECT 1 FROM meeting_rooms
+			WHERE meeting_rooms.coffee_shop_id = coffee_shops.id AND meeting_rooms.available = true
+			AND NOT EXISTS (
+				SELECT 1 FROM bookings
+				WHERE bookings.meeting_room_id = meeting_rooms.id AND bookings.status != ?
+				AND bookings.start_time <= ? AND bookings.end_time > ?
+			)
+		)`, "cancelled", *filter.FreeAt, *filter.FreeAt)
+	}
+
+	query := r.db.Table("(?) AS nearby", inner).
+		Where("distance_km <= ?", filter.RadiusKm).
+		Order("distance_km")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var results []NearbyShopResult
+	err := query.Scan(&results).Error
+	return results, err
+}
+
 func (r *coffeeShopRepo) UpdateCoffeeShop(shop *entity.CoffeeShop) error {
 	logger.Info("UpdateCoffeeShop repository method called")
 	return r.db.Save(shop).Error
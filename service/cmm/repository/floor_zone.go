@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IFloorZoneRepo interface {
+	CreateFloorZone(zone *entity.FloorZone) error
+	GetFloorZoneByID(id uuid.UUID) (*entity.FloorZone, error)
+	GetFloorZonesByCoffeeShop(shopID uuid.UUID) ([]entity.FloorZone, error)
+	UpdateFloorZone(zone *entity.FloorZone) error
+	DeleteFloorZone(id uuid.UUID) error
+}
+
+type floorZoneRepo struct {
+	db *gorm.DB
+}
+
+func NewFloorZoneRepo(db *gorm.DB) IFloorZoneRepo {
+	return &floorZoneRepo{
+		db: db,
+	}
+}
+
+func (r *floorZoneRepo) CreateFloorZone(zone *entity.FloorZone) error {
+	logger.Info("CreateFloorZone repository method called")
+	return r.db.Create(zone).Error
+}
+
+func (r *floorZoneRepo) GetFloorZoneByID(id uuid.UUID) (*entity.FloorZone, error) {
+	logger.Info("GetFloorZoneByID repository method called")
+	var zone entity.FloorZone
+	err := r.db.Where("id = ?", id).First(&zone).Error
+	if err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+func (r *floorZoneRepo) GetFloorZonesByCoffeeShop(shopID uuid.UUID) ([]entity.FloorZone, error) {
+	logger.Info("GetFloorZonesByCoffeeShop repository method called")
+	var zones []entity.FloorZone
+	err := r.db.Where("coffee_shop_id = ?", shopID).Order("name").Find(&zones).Error
+	return zones, err
+}
+
+func (r *floorZoneRepo) UpdateFloorZone(zone *entity.FloorZone) error {
+	logger.Info("UpdateFloorZone repository method called")
+	return r.db.Save(zone).Error
+}
+
+// DeleteFloorZone removes a zone; its resources stay bookable without a zone
+func (r *floorZoneRepo) DeleteFloorZone(id uuid.UUID) error {
+	logger.Info("DeleteFloorZone repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.MeetingRoom{}).
+			Where("floor_zone_id = ?", id).
+			Update("floor_zone_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.FloorZone{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IFollowRepo interface {
+	FollowShop(follow *entity.ShopFollow) error
+	UnfollowShop(userID uuid.UUID, shopID uuid.UUID) (bool, error)
+	GetFollowedShops(userID uuid.UUID) ([]entity.CoffeeShop, error)
+	GetFeed(userID uuid.UUID, limit int, offset int) ([]FeedPost, error)
+	CountFollowers(shopIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+}
+
+type followRepo struct {
+	db *gorm.DB
+}
+
+func NewFollowRepo(db *gorm.DB) IFollowRepo {
+	return &followRepo{
+		db: db,
+	}
+}
+
+// FeedPost is a post of a followed shop together with the shop name
+type FeedPost struct {
+	entity.ShopPost `gorm:"embedded"`
+	ShopName        string
+}
+
+// followerRow is the follower count of a shop
+type followerRow struct {
+	CoffeeShopID  uuid.UUID
+	FollowerCount int64
+}
+
+// FollowShop follows a shop; following a shop twice is a no-op
+func (r *followRepo) FollowShop(follow *entity.ShopFollow) error {
+	logger.Info("FollowShop repository method called")
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(follow).Error
+}
+
+// UnfollowShop reports whether the user was following the shop
+func (r *followRepo) UnfollowShop(userID uuid.UUID, shopID uuid.UUID) (bool, error) {
+	logger.Info("UnfollowShop repository method called")
+	result := r.db.Where("user_id = ? AND coffee_shop_id = ?", userID, shopID).Delete(&entity.ShopFollow{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *followRepo) GetFollowedShops(userID uuid.UUID) ([]entity.CoffeeShop, error) {
+	logger.Info("GetFollowedShops repository method called")
+	var shops []entity.CoffeeShop
+	err := r.db.Joins("JOIN shop_follows ON shop_follows.coffee_shop_id = coffee_shops.id").
+		Where("shop_follows.user_id = ?", userID).
+		Order("shop_follows.created_at DESC").
+		Find(&shops).Error
+	return shops, err
+}
+
+// GetFeed returns the posts of the shops the user follows, newest first
+func (r *followRepo) GetFeed(userID uuid.UUID, limit int, offset int) ([]FeedPost, error) {
+	logger.Info("GetFeed repository method called")
+	var posts []FeedPost
+	err := r.db.Table("shop_posts").
+		Select("shop_posts.*, coffee_shops.name AS shop_name").
+		Joins("JOIN shop_follows ON shop_follows.coffee_shop_id = shop_posts.coffee_shop_id").
+		Joins("JOIN coffee_shops ON coffee_shops.id = shop_posts.coffee_shop_id").
+		Where("shop_follows.user_id = ?", userID).
+		Order("shop_posts.published_at DESC, shop_posts.id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&posts).Error
+	return posts, err
+}
+
+func (r *followRepo) CountFollowers(shopIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	logger.Info("CountFollowers repository method called")
+	result := make(map[uuid.UUID]int64)
+	if len(shopIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []followerRow
+	err := r.db.Model(&entity.ShopFollow{}).
+		Select("coffee_shop_id, COUNT(*) AS follower_count").
+		Where("coffee_shop_id IN ?", shopIDs).
+		Group("coffee_shop_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.CoffeeShopID] = row.FollowerCount
+	}
+	return result, nil
+}
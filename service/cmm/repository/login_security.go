@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ILoginSecurityRepo interface {
+	GetLockedThrottles(keys []entity.LoginThrottle, now time.Time) ([]entity.LoginThrottle, error)
+	RecordLoginFailure(scope string, identifier string, now time.Time, windowStart time.Time) (*entity.LoginThrottle, error)
+	LockThrottle(scope string, identifier string, lockedUntil time.Time) error
+	ClearThrottle(scope string, identifier string) error
+	RecordLoginIP(userID uuid.UUID, ip string, now time.Time) (known bool, firstLogin bool, err error)
+	CreateSecurityEvent(event *entity.SecurityEvent) error
+	GetSecurityEvents(filter SecurityEventFilter) ([]SecurityEventRecord, error)
+	ReviewSecurityEvent(id uuid.UUID, adminID uuid.UUID, reviewedAt time.Time) (bool, error)
+}
+
+type loginSecurityRepo struct {
+	db *gorm.DB
+}
+
+func NewLoginSecurityRepo(db *gorm.DB) ILoginSecurityRepo {
+	return &loginSecurityRepo{
+		db: db,
+	}
+}
+
+// SecurityEventFilter narrows the security events listed for admins
+type SecurityEventFilter struct {
+	Type       string
+	UserID     *uuid.UUID
+	Unreviewed bool
+	Limit      int
+	Offset     int
+}
+
+// SecurityEventRecord is a security event together with the user's name
+type SecurityEventRecord struct {
+	entity.SecurityEvent `gorm:"embedded"`
+	FullName             string
+}
+
+// GetLockedThrottles returns the throttles among keys that are locked at now
+func (r *loginSecurityRepo) GetLockedThrottles(keys []entity.LoginThrottle, now time.Time) ([]entity.LoginThrottle, error) {
+	logger.Info("GetLockedThrottles repository method called")
+	var throttles []entity.LoginThrottle
+	if len(keys) == 0 {
+		return throttles, nil
+	}
+
+	query := r.db.Where("locked_until > ?", now)
+	condition := r.db
+	for _, key := range keys {
+		condition = condition.Or("scope = ? AND identifier = ?", key.Scope, key.Identifier)
+	}
+	err := query.Where(condition).Find(&throttles).Error
+	return throttles, err
+}
+
+// RecordLoginFailure counts a failed login and returns the updated throttle. The count starts
+// over when neither a failure nor a lockout happened since windowStart; the upsert keeps
+// concurrent failures from being lost.
+func (r *loginSecurityRepo) RecordLoginFailure(scope string, identifier string, now time.Time, windowStart time.Time) (*entity.LoginThrottle, error) {
+	logger.Info("RecordLoginFailure repository method called")
+	throttle := entity.LoginThrottle{
+		Scope:        scope,
+		Identifier:   identifier,
+		Failures:     1,
+		LastFailedAt: now,
+	}
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "identifier"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures": gorm.Expr(
+					"CASE WHEN GREATEST(login_throttles.last_failed_at, COALESCE(login_throttles.locked_until, login_throttles.last_failed_at)) < ? THEN 1 ELSE login_throttles.failures + 1 END",
+					windowStart,
+				),
+				"last_failed_at": now,
+			}),
+		},
+		clause.Returning{},
+	).Create(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *loginSecurityRepo) LockThrottle(scope string, identifier string, lockedUntil time.Time) error {
+	logger.Info("LockThrottle repository method called")
+	return r.db.Model(&entity.LoginThrottle{}).
+		Where("scope = ? AND identifier = ?", scope, identifier).
+		Update("locked_until", lockedUntil).Error
+}
+
+func (r *loginSecurityRepo) ClearThrottle(scope string, identifier string) error {
+	logger.Info("ClearThrottle repository method called")
+	return r.db.Where("scope = ? AND identifier = ?", scope, identifier).
+		Delete(&entity.LoginThrottle{}).Error
+}
+
+// RecordLoginIP remembers that the user logged in from ip. It reports whether the IP was
+// already known and whether this is the user's first recorded login.
+func (r *loginSecurityRepo) RecordLoginIP(userID uuid.UUID, ip string, now time.Time) (bool, bool, error) {
+	logger.Info("RecordLoginIP repository method called")
+	var known, firstLogin bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.UserLoginIP{}).
+			Where("user_id = ? AND ip = ?", userID, ip).
+			Update("last_seen_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			known = true
+			return nil
+		}
+
+		var count int64
+		if err := tx.Model(&entity.UserLoginIP{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		firstLogin = count == 0
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.UserLoginIP{
+			UserID:      userID,
+			IP:          ip,
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+		}).Error
+	})
+	return known, firstLogin, err
+}
+
+func (r *loginSecurityRepo) CreateSecurityEvent(event *entity.SecurityEvent) error {
+	logger.Info("CreateSecurityEvent repository method called")
+	return r.db.Create(event).Error
+}
+
+// GetSecurityEvents returns the matching security events, newest first
+func (r *loginSecurityRepo) GetSecurityEvents(filter SecurityEventFilter) ([]SecurityEventRecord, error) {
+	logger.Info("GetSecurityEvents repository method called")
+	query := r.db.Table("security_events").
+		Select("security_events.*, users.full_name").
+		Joins("LEFT JOIN users ON users.id = security_events.user_id")
+	if filter.Type != "" {
+		query = query.Where("security_events.type = ?", filter.Type)
+	}
+	if filter.UserID != nil {
+		query = query.Where("security_events.user_id = ?", *filter.UserID)
+	}
+	if filter.Unreviewed {
+		query = query.Where("security_events.reviewed_at IS NULL")
+	}
+
+	var events []SecurityEventRecord
+	err := query.Order("security_events.created_at DESC, security_events.id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Scan(&events).Error
+	return events, err
+}
+
+func (r *loginSecurityRepo) ReviewSecurityEvent(id uuid.UUID, adminID uuid.UUID, reviewedAt time.Time) (bool, error) {
+	logger.Info("ReviewSecurityEvent repository method called")
+	result := r.db.Model(&entity.SecurityEvent{}).
+		Where("id = ? AND reviewed_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"reviewed_by": adminID,
+			"reviewed_at": reviewedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
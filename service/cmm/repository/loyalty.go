@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInsufficientPoints is returned by RedeemPoints when the user has fewer unexpired points
+var ErrInsufficientPoints = errors.New("insufficient points")
+
+type ILoyaltyRepo interface {
+	EarnPoints(entry *entity.PointEntry) (bool, error)
+	RedeemPoints(entry *entity.PointEntry, now time.Time) error
+	RefundRedemption(serviceID entity.ServiceType, serviceRefID uuid.UUID, now time.Time) (int, error)
+	ExpirePoints(userID uuid.UUID, now time.Time) (int, error)
+	GetPointsBalance(userID uuid.UUID, now time.Time) (int, error)
+	GetPointsExpiringBefore(userID uuid.UUID, now time.Time, before time.Time) (int, error)
+	GetEntriesByUser(userID uuid.UUID) ([]entity.PointEntry, error)
+}
+
+type loyaltyRepo struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepo(db *gorm.DB) ILoyaltyRepo {
+	return &loyaltyRepo{
+		db: db,
+	}
+}
+
+// EarnPoints adds an earn entry unless points were already earned for the same service reference
+func (r *loyaltyRepo) EarnPoints(entry *entity.PointEntry) (bool, error) {
+	logger.Info("EarnPoints repository method called")
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.PointEntry{}).
+			Where("type = ? AND service_id = ? AND service_ref_id = ?", entity.PointEarn, entry.ServiceID, entry.ServiceRefID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// RedeemPoints consumes unexpired earn entries, soonest expiry first, and records the redemption
+func (r *loyaltyRepo) RedeemPoints(entry *entity.PointEntry, now time.Time) error {
+	logger.Info("RedeemPoints repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var earned []entity.PointEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND type = ? AND remaining > 0 AND expires_at > ?", entry.UserID, entity.PointEarn, now).
+			Order("expires_at, created_at").
+			Find(&earned).Error; err != nil {
+			return err
+		}
+
+		needed := -entry.Points
+		var consumptions []entity.PointConsumption
+		for _, earn := range earned {
+			if needed == 0 {
+				break
+			}
+			take := earn.Remaining
+			if take > needed {
+				take = needed
+			}
+			if err := tx.Model(&entity.PointEntry{}).
+				Where("id = ?", earn.ID).
+				Update("remaining", gorm.Expr("remaining - ?", take)).Error; err != nil {
+				return err
+			}
+			consumptions = append(consumptions, entity.PointConsumption{
+				ID:            uuid.New(),
+				RedeemEntryID: entry.ID,
+				EarnEntryID:   earn.ID,
+				Points:        take,
+			})
+			needed -= take
+		}
+		if needed > 0 {
+			return ErrInsufficientPoints
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		return tx.Create(&consumptions).Error
+	})
+}
+
+// RefundRedemption gives the points of a redemption back to the earn entries they were taken from.
+// Points whose entry has expired in the meantime are expired again on the next ExpirePoints.
+func (r *loyaltyRepo) RefundRedemption(serviceID entity.ServiceType, serviceRefID uuid.UUID, now time.Time) (int, error) {
+	logger.Info("RefundRedemption repository method called")
+	refunded := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var redemption entity.PointEntry
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("type = ? AND service_id = ? AND service_ref_id = ?", entity.PointRedeem, serviceID, serviceRefID).
+			First(&redemption).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.PointEntry{}).
+			Where("type = ? AND source_entry_id = ?", entity.PointRefund, redemption.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		var consumptions []entity.PointConsumption
+		if err := tx.Where("redeem_entry_id = ?", redemption.ID).Find(&consumptions).Error; err != nil {
+			return err
+		}
+		for _, consumption := range consumptions {
+			if err := tx.Model(&entity.PointEntry{}).
+				Where("id = ?", consumption.EarnEntryID).
+				Update("remaining", gorm.Expr("remaining + ?", consumption.Points)).Error; err != nil {
+				return err
+			}
+		}
+
+		refunded = -redemption.Points
+		return tx.Create(&entity.PointEntry{
+			ID:            uuid.New(),
+			UserID:        redemption.UserID,
+			Type:          entity.PointRefund,
+			Points:        refunded,
+			ServiceID:     serviceID,
+			ServiceRefID:  serviceRefID,
+			SourceEntryID: &redemption.ID,
+			CreatedAt:     now,
+		}).Error
+	})
+	return refunded, err
+}
+
+// ExpirePoints writes off the remaining points of the user's expired earn entries
+func (r *loyaltyRepo) ExpirePoints(userID uuid.UUID, now time.Time) (int, error) {
+	logger.Info("ExpirePoints repository method called")
+	expired := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var earned []entity.PointEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND type = ? AND remaining > 0 AND expires_at <= ?", userID, entity.PointEarn, now).
+			Find(&earned).Error; err != nil {
+			return err
+		}
+
+		for _, earn := range earned {
+			if err := tx.Model(&entity.PointEntry{}).
+				Where("id = ?", earn.ID).
+				Update("remaining", 0).Error; err != nil {
+				return err
+			}
+			earnID := earn.ID
+			if err := tx.Create(&entity.PointEntry{
+				ID:            uuid.New(),
+				UserID:        userID,
+				Type:          entity.PointExpire,
+				Points:        -earn.Remaining,
+				ServiceID:     earn.ServiceID,
+				ServiceRefID:  earn.ServiceRefID,
+				SourceEntryID: &earnID,
+				CreatedAt:     now,
+			}).Error; err != nil {
+				return err
+			}
+			expired += earn.Remaining
+		}
+		return nil
+	})
+	return expired, err
+}
+
+func (r *loyaltyRepo) GetPointsBalance(userID uuid.UUID, now time.Time) (int, error) {
+	logger.Info("GetPointsBalance repository method called")
+	var balance int
+	err := r.db.Model(&entity.PointEntry{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND type = ? AND expires_at > ?", userID, entity.PointEarn, now).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *loyaltyRepo) GetPointsExpiringBefore(userID uuid.UUID, now time.Time, before time.Time) (int, error) {
+	logger.Info("GetPointsExpiringBefore repository method called")
+	var points int
+	err := r.db.Model(&entity.PointEntry{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND type = ? AND expires_at > ? AND expires_at <= ?", userID, entity.PointEarn, now, before).
+		Scan(&points).Error
+	return points, err
+}
+
+func (r *loyaltyRepo) GetEntriesByUser(userID uuid.UUID) ([]entity.PointEntry, error) {
+	logger.Info("GetEntriesByUser repository method called")
+	var entries []entity.PointEntry
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IMediaRepo interface {
+	CreateMedia(media *entity.Media) error
+	GetMediaByID(id uuid.UUID) (*entity.Media, error)
+	GetMediaByOwner(ownerType string, ownerID uuid.UUID) ([]entity.Media, error)
+	CountMediaByOwner(ownerType string, ownerID uuid.UUID) (int64, error)
+	DeleteMedia(id uuid.UUID) error
+}
+
+type mediaRepo struct {
+	db *gorm.DB
+}
+
+func NewMediaRepo(db *gorm.DB) IMediaRepo {
+	return &mediaRepo{
+		db: db,
+	}
+}
+
+func (r *mediaRepo) CreateMedia(media *entity.Media) error {
+	logger.Info("CreateMedia repository method called")
+	return r.db.Create(media).Error
+}
+
+func (r *mediaRepo) GetMediaByID(id uuid.UUID) (*entity.Media, error) {
+	logger.Info("GetMediaByID repository method called")
+	var media entity.Media
+	err := r.db.Where("id = ?", id).First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// GetMediaByOwner returns a gallery in upload order
+func (r *mediaRepo) GetMediaByOwner(ownerType string, ownerID uuid.UUID) ([]entity.Media, error) {
+	logger.Info("GetMediaByOwner repository method called")
+	var media []entity.Media
+	err := r.db.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Order("created_at ASC").
+		Find(&media).Error
+	return media, err
+}
+
+func (r *mediaRepo) CountMediaByOwner(ownerType string, ownerID uuid.UUID) (int64, error) {
+	logger.Info("CountMediaByOwner repository method called")
+	var count int64
+	err := r.db.Model(&entity.Media{}).
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *mediaRepo) DeleteMedia(id uuid.UUID) error {
+	logger.Info("DeleteMedia repository method called")
+	return r.db.Where("id = ?", id).Delete(&entity.Media{}).Error
+}
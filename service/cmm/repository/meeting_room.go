@@ -9,14 +9,44 @@ import (
 
 type IMeetingRoomRepo interface {
 	CreateMeetingRoom(room *entity.MeetingRoom) error
+	CreateMeetingRooms(rooms []entity.MeetingRoom) error
 	GetMeetingRoomByID(id uuid.UUID) (*entity.MeetingRoom, error)
 	GetMeetingRoomsByCoffeeShop(shopID uuid.UUID) ([]entity.MeetingRoom, error)
 	GetAvailableMeetingRooms(shopID uuid.UUID) ([]entity.MeetingRoom, error)
+	FindMeetingRooms(shopID uuid.UUID, resourceType string, floorZoneID *uuid.UUID) ([]entity.MeetingRoom, error)
+	SearchMeetingRooms(filter RoomSearchFilter) ([]RoomSearchResult, error)
 	UpdateMeetingRoom(room *entity.MeetingRoom) error
 	UpdateRoomAvailability(id uuid.UUID, available bool) error
 	DeleteMeetingRoom(id uuid.UUID) error
 }
 
+// Price units a room search compares and orders by
+const (
+	PriceUnitHour = "hour"
+	PriceUnitDay  = "day"
+)
+
+// RoomSearchFilter narrows a room search. Zero values leave a filter out.
+type RoomSearchFilter struct {
+	CoffeeShopID *uuid.UUID
+	Location     string
+	ResourceType string
+	Amenities    []string
+	MinCapacity  int
+	MaxCapacity  int
+	MinPrice     float64
+	MaxPrice     float64
+	PriceUnit    string
+	Limit        int
+	Offset       int
+}
+
+// RoomSearchResult is a room found by a search together with the name of its shop
+type RoomSearchResult struct {
+	entity.MeetingRoom `gorm:"embedded"`
+	ShopName           string
+}
+
 type meetingRoomRepo struct {
 	db *gorm.DB
 }
@@ -32,6 +62,11 @@ func (r *meetingRoomRepo) CreateMeetingRoom(room *entity.MeetingRoom) error {
 	return r.db.Create(room).Error
 }
 
+func (r *meetingRoomRepo) CreateMeetingRooms(rooms []entity.MeetingRoom) error {
+	logger.Info("CreateMeetingRooms repository method called")
+	return r.db.CreateInBatches(rooms, 100).Error
+}
+
 func (r *meetingRoomRepo) GetMeetingRoomByID(id uuid.UUID) (*entity.MeetingRoom, error) {
 	logger.Info("GetMeetingRoomByID repository method called")
 	var room entity.MeetingRoom
@@ -56,6 +91,80 @@ func (r *meetingRoomRepo) GetAvailableMeetingRooms(shopID uuid.UUID) ([]entity.M
 	return rooms, err
 }
 
+// FindMeetingRooms returns the bookable resources of a shop, optionally of one type and in one floor zone
+func (r *meetingRoomRepo) FindMeetingRooms(shopID uuid.UUID, resourceType string, floorZoneID *uuid.UUID) ([]entity.MeetingRoom, error) {
+	logger.Info("FindMeetingRooms repository method called")
+	var rooms []entity.MeetingRoom
+	query := r.db.Where("coffee_shop_id = ? AND available = ?", shopID, true)
+	if resourceType != "" {
+		query = query.Where("resource_type = ?", resourceType)
+	}
+	if floorZoneID != nil {
+		query = query.Where("floor_zone_id = ?", *floorZoneID)
+	}
+	err := query.Order("name").Find(&rooms).Error
+	return rooms, err
+}
+
+// SearchMeetingRooms finds available rooms across shops. Rooms must offer every requested amenity.
+func (r *meetingRoomRepo) SearchMeetingRooms(filter RoomSearchFilter) ([]RoomSearchResult, error) {
+	logger.Info("SearchMeetingRooms repository method called")
+	query := r.db.Table("meeting_rooms").
+		Select("meeting_rooms.*, coffee_shops.name AS shop_name").
+		Joins("JOIN coffee_shops ON coffee_shops.id = meeting_rooms.coffee_shop_id").
+		Where("meeting_rooms.available = ? AND coffee_shops.status = ?", true, entity.ShopApproved)
+
+	if filter.CoffeeShopID != nil {
+		query = query.Where("meeting_rooms.coffee_shop_id = ?", *filter.CoffeeShopID)
+	}
+	if filter.Location != "" {
+		query = query.Where("coffee_shops.location ILIKE ?", "%"+filter.Location+"%")
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("meeting_rooms.resource_type = ?", filter.ResourceType)
+	}
+	if len(filter.Amenities) > 0 {
+		query = query.Where(`meeting_rooms.id IN (
+			SELECT meeting_room_id FROM room_amenities
+			WHERE amenity_code IN ?
+			GROUP BY meeting_room_id
+			HAVING COUNT(DISTINCT amenity_code) = ?
+		)`, filter.Amenities, len(filter.Amenities))
+	}
+	if filter.MinCapacity > 0 {
+		query = query.Where("meeting_rooms.capacity >= ?", filter.MinCapacity)
+	}
+	if filter.MaxCapacity > 0 {
+		query = query.Where("meeting_rooms.capacity <= ?", filter.MaxCapacity)
+	}
+	price := roomPriceExpr(filter.PriceUnit)
+	if filter.MinPrice > 0 {
+		query = query.Where(price+" >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		query = query.Where(price+" <= ?", filter.MaxPrice)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var results []RoomSearchResult
+	err := query.Order(price + ", meeting_rooms.name").Scan(&results).Error
+	return results, err
+}
+
+// roomPriceExpr is what booking one unit of a room costs. Resources priced only by the day
+// charge a whole day for a single hour, and hourly-only resources charge 24 hours for a day.
+func roomPriceExpr(unit string) string {
+	if unit == PriceUnitDay {
+		return "(CASE WHEN meeting_rooms.price_per_day > 0 THEN meeting_rooms.price_per_day ELSE meeting_rooms.price_per_hour * 24 END)"
+	}
+	return "(CASE WHEN meeting_rooms.price_per_hour > 0 THEN meeting_rooms.price_per_hour ELSE meeting_rooms.price_per_day END)"
+}
+
 func (r *meetingRoomRepo) UpdateMeetingRoom(room *entity.MeetingRoom) error {
 	logger.Info("UpdateMeetingRoom repository method called")
 	return r.db.Save(room).Error
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type INotificationRepo interface {
+	CreateNotification(notification *entity.Notification) error
+	GetNotificationsByUser(userID uuid.UUID, unreadOnly bool) ([]entity.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(id uuid.UUID, userID uuid.UUID, readAt time.Time) (bool, error)
+	MarkAllRead(userID uuid.UUID, readAt time.Time) error
+}
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) INotificationRepo {
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) CreateNotification(notification *entity.Notification) error {
+	logger.Info("CreateNotification repository method called")
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepo) GetNotificationsByUser(userID uuid.UUID, unreadOnly bool) ([]entity.Notification, error) {
+	logger.Info("GetNotificationsByUser repository method called")
+	var notifications []entity.Notification
+	query := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("created_at DESC").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepo) CountUnread(userID uuid.UUID) (int64, error) {
+	logger.Info("CountUnread repository method called")
+	var count int64
+	err := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepo) MarkRead(id uuid.UUID, userID uuid.UUID, readAt time.Time) (bool, error) {
+	logger.Info("MarkRead repository method called")
+	result := r.db.Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", readAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *notificationRepo) MarkAllRead(userID uuid.UUID, readAt time.Time) error {
+	logger.Info("MarkAllRead repository method called")
+	return r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt).Error
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPassBookingOverlap is returned by ConsumePass when an unlimited pass already covers an overlapping booking
+var ErrPassBookingOverlap = errors.New("pass is already used for an overlapping booking")
+
+// errPassNotConsumed rolls back ConsumePass when the pass cannot pay for the booking
+var errPassNotConsumed = errors.New("pass cannot pay for the booking")
+
+type IPassRepo interface {
+	// Pass product methods
+	CreatePassProduct(product *entity.PassProduct) error
+	GetPassProductByID(id uuid.UUID) (*entity.PassProduct, error)
+	GetPassProductsByCoffeeShop(shopID uuid.UUID, activeOnly bool) ([]entity.PassProduct, error)
+	UpdatePassProductActive(id uuid.UUID, active bool) error
+
+	// User pass methods
+	PurchasePass(userPass *entity.UserPass, transaction *entity.Transaction, earning *entity.Earning) error
+	GetUserPassByID(id uuid.UUID) (*entity.UserPass, error)
+	GetUserPassesByUser(userID uuid.UUID) ([]entity.UserPass, error)
+	ExpirePasses(userID uuid.UUID, now time.Time) error
+
+	// Usage methods
+	ConsumePass(booking *entity.Booking, userPass *entity.UserPass, usage *entity.PassUsage) (bool, error)
+	RestorePassUsage(bookingID uuid.UUID) (bool, error)
+	GetUsagesByUserPass(userPassID uuid.UUID) ([]entity.PassUsage, error)
+}
+
+type passRepo struct {
+	db *gorm.DB
+}
+
+func NewPassRepo(db *gorm.DB) IPassRepo {
+	return &passRepo{
+		db: db,
+	}
+}
+
+func (r *passRepo) CreatePassProduct(product *entity.PassProduct) error {
+	logger.Info("CreatePassProduct repository method called")
+	return r.db.Create(product).Error
+}
+
+func (r *passRepo) GetPassProductByID(id uuid.UUID) (*entity.PassProduct, error) {
+	logger.Info("GetPassProductByID repository method called")
+	var product entity.PassProduct
+	err := r.db.Where("id = ?", id).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *passRepo) GetPassProductsByCoffeeShop(shopID uuid.UUID, activeOnly bool) ([]entity.PassProduct, error) {
+	logger.Info("GetPassProductsByCoffeeShop repository method called")
+	var products []entity.PassProduct
+	query := r.db.Where("coffee_shop_id = ?", shopID)
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("price").Find(&products).Error
+	return products, err
+}
+
+func (r *passRepo) UpdatePassProductActive(id uuid.UUID, active bool) error {
+	logger.Info("UpdatePassProductActive repository method called")
+	return r.db.Model(&entity.PassProduct{}).Where("id = ?", id).Update("active", active).Error
+}
+
+// PurchasePass charges the wallet and issues the pass with its transaction and the shop earning
+// in one database transaction
+func (r *passRepo) PurchasePass(userPass *entity.UserPass, transaction *entity.Transaction, earning *entity.Earning) error {
+	logger.Info("PurchasePass repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Wallet{}).
+			Where("user_id = ? AND balance >= ?", userPass.UserID, userPass.Price).
+			Update("balance", gorm.Expr("balance - ?", userPass.Price))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsufficientBalance
+		}
+
+		if err := tx.Create(userPass).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+		return tx.Create(earning).Error
+	})
+}
+
+func (r *passRepo) GetUserPassByID(id uuid.UUID) (*entity.UserPass, error) {
+	logger.Info("GetUserPassByID repository method called")
+	var userPass entity.UserPass
+	err := r.db.Where("id = ?", id).First(&userPass).Error
+	if err != nil {
+		return nil, err
+	}
+	return &userPass, nil
+}
+
+func (r *passRepo) GetUserPassesByUser(userID uuid.UUID) ([]entity.UserPass, error) {
+	logger.Info("GetUserPassesByUser repository method called")
+	var userPasses []entity.UserPass
+	err := r.db.Where("user_id = ?", userID).Order("purchased_at DESC").Find(&userPasses).Error
+	return userPasses, err
+}
+
+// ExpirePasses marks the user's active passes past their expiry as expired
+func (r *passRepo) ExpirePasses(userID uuid.UUID, now time.Time) error {
+	logger.Info("ExpirePasses repository method called")
+	return r.db.Model(&entity.UserPass{}).
+		Where("user_id = ? AND status = ? AND expires_at <= ?", userID, entity.UserPassActive, now).
+		Update("status", entity.UserPassExpired).Error
+}
+
+// ConsumePass creates a booking and charges it to a pass in one database transaction. It returns
+// false, without creating the booking, when the pass is no longer active, does not cover the
+// booking period, or has too few hours left.
+func (r *passRepo) ConsumePass(booking *entity.Booking, userPass *entity.UserPass, usage *entity.PassUsage) (bool, error) {
+	logger.Info("ConsumePass repository method called")
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(booking).Error; err != nil {
+			return err
+		}
+
+		var locked entity.UserPass
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND starts_at <= ? AND expires_at >= ?", userPass.ID, entity.UserPassActive, usage.StartTime, usage.EndTime).
+			First(&locked).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errPassNotConsumed
+		}
+		if err != nil {
+			return err
+		}
+
+		switch locked.Type {
+		case entity.PassHours:
+			if locked.RemainingHours < usage.Hours {
+				return errPassNotConsumed
+			}
+			if err := tx.Model(&entity.UserPass{}).
+				Where("id = ?", locked.ID).
+				Update("remaining_hours", gorm.Expr("remaining_hours - ?", usage.Hours)).Error; err != nil {
+				return err
+			}
+		case entity.PassUnlimited:
+			var count int64
+			if err := tx.Model(&entity.PassUsage{}).
+				Where("user_pass_id = ? AND status = ? AND start_time < ? AND end_time > ?", locked.ID, entity.PassUsageUsed, usage.EndTime, usage.StartTime).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrPassBookingOverlap
+			}
+		}
+
+		return tx.Create(usage).Error
+	})
+	if errors.Is(err, errPassNotConsumed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RestorePassUsage gives the hours of a booking back to its hours pass
+func (r *passRepo) RestorePassUsage(bookingID uuid.UUID) (bool, error) {
+	logger.Info("RestorePassUsage repository method called")
+	restored := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var usage entity.PassUsage
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("booking_id = ? AND status = ?", bookingID, entity.PassUsageUsed).
+			First(&usage).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&usage).Update("status", entity.PassUsageRestored).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.UserPass{}).
+			Where("id = ? AND type = ?", usage.UserPassID, entity.PassHours).
+			Update("remaining_hours", gorm.Expr("remaining_hours + ?", usage.Hours)).Error; err != nil {
+			return err
+		}
+
+		restored = true
+		return nil
+	})
+	return restored, err
+}
+
+func (r *passRepo) GetUsagesByUserPass(userPassID uuid.UUID) ([]entity.PassUsage, error) {
+	logger.Info("GetUsagesByUserPass repository method called")
+	var usages []entity.PassUsage
+	err := r.db.Where("user_pass_id = ?", userPassID).Order("start_time DESC").Find(&usages).Error
+	return usages, err
+}
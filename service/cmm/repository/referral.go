@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReferralRepo interface {
+	CreateReferral(referral *entity.Referral) error
+	GetReferralByReferee(refereeID uuid.UUID) (*entity.Referral, error)
+	GetReferralsByReferrer(referrerID uuid.UUID) ([]entity.Referral, error)
+	ClaimReferral(referral *entity.Referral, maxRewards int, credits []entity.Transaction, claimedAt time.Time) (bool, error)
+}
+
+type referralRepo struct {
+	db *gorm.DB
+}
+
+func NewReferralRepo(db *gorm.DB) IReferralRepo {
+	return &referralRepo{
+		db: db,
+	}
+}
+
+func (r *referralRepo) CreateReferral(referral *entity.Referral) error {
+	logger.Info("CreateReferral repository method called")
+	return r.db.Create(referral).Error
+}
+
+func (r *referralRepo) GetReferralByReferee(refereeID uuid.UUID) (*entity.Referral, error) {
+	logger.Info("GetReferralByReferee repository method called")
+	var referral entity.Referral
+	err := r.db.Where("referee_id = ?", refereeID).First(&referral).Error
+	if err != nil {
+		return nil, err
+	}
+	return &referral, nil
+}
+
+func (r *referralRepo) GetReferralsByReferrer(referrerID uuid.UUID) ([]entity.Referral, error) {
+	logger.Info("GetReferralsByReferrer repository method called")
+	var referrals []entity.Referral
+	err := r.db.Where("referrer_id = ?", referrerID).
+		Order("created_at DESC").
+		Find(&referrals).Error
+	return referrals, err
+}
+
+// ClaimReferral settles a pending referral in one database transaction. The referrer's referrals
+// are locked while the rewarded ones are counted, so concurrent claims cannot exceed maxRewards
+// (0 means no cap). Under the cap the referral is marked rewarded and each credit is added to its
+// user's wallet and recorded; over it the referral is marked capped without rewards. The final
+// status is set on referral. It returns false when the referral was already claimed.
+func (r *referralRepo) ClaimReferral(referral *entity.Referral, maxRewards int, credits []entity.Transaction, claimedAt time.Time) (bool, error) {
+	logger.Info("ClaimReferral repository method called")
+	claimed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var statuses []string
+		if err := tx.Model(&entity.Referral{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("referrer_id = ?", referral.ReferrerID).
+			Pluck("status", &statuses).Error; err != nil {
+			return err
+		}
+
+		status := entity.ReferralRewarded
+		if maxRewards > 0 {
+			rewarded := 0
+			for _, s := range statuses {
+				if s == entity.ReferralRewarded {
+					rewarded++
+				}
+			}
+			if rewarded >= maxRewards {
+				status = entity.ReferralCapped
+			}
+		}
+
+		updates := map[string]interface{}{
+			"status":             status,
+			"referrer_reward":    0,
+			"referee_reward":     0,
+			"trigger_service_id": referral.TriggerServiceID,
+			"trigger_ref_id":     referral.TriggerRefID,
+			"rewarded_at":        claimedAt,
+		}
+		if status == entity.ReferralRewarded {
+			updates["referrer_reward"] = referral.ReferrerReward
+			updates["referee_reward"] = referral.RefereeReward
+		}
+		result := tx.Model(&entity.Referral{}).
+			Where("id = ? AND status = ?", referral.ID, entity.ReferralPending).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		claimed = true
+		referral.Status = status
+		if status != entity.ReferralRewarded {
+			referral.ReferrerReward = 0
+			referral.RefereeReward = 0
+			return nil
+		}
+
+		for i := range credits {
+			result := tx.Model(&entity.Wallet{}).
+				Where("user_id = ?", credits[i].UserID).
+				Update("balance", gorm.Expr("balance + ?", credits[i].Amount))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("wallet not found")
+			}
+			if err := tx.Create(&credits[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
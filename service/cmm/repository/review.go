@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IReviewRepo interface {
+	CreateReview(review *entity.Review) error
+	GetReviewByID(id uuid.UUID) (*entity.Review, error)
+	GetReviewByBooking(bookingID uuid.UUID) (*entity.Review, error)
+	GetReviewsByCoffeeShop(shopID uuid.UUID) ([]entity.Review, error)
+	GetReviewsByMeetingRoom(roomID uuid.UUID) ([]entity.Review, error)
+	GetReviewsByCustomer(customerID uuid.UUID) ([]entity.Review, error)
+	GetReviews(status string) ([]entity.Review, error)
+	ReplyToReview(id uuid.UUID, reply string, repliedAt time.Time) error
+	SetReviewStatus(id uuid.UUID, status string, reason string, moderatorID uuid.UUID, moderatedAt time.Time) (bool, error)
+	GetShopRatings(shopIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error)
+	GetRoomRatings(roomIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error)
+}
+
+type reviewRepo struct {
+	db *gorm.DB
+}
+
+func NewReviewRepo(db *gorm.DB) IReviewRepo {
+	return &reviewRepo{
+		db: db,
+	}
+}
+
+// RatingSummary aggregates the published reviews of a shop or room
+type RatingSummary struct {
+	AverageRating float64
+	ReviewCount   int64
+}
+
+// ratingRow is a rating summary keyed by the shop or room it belongs to
+type ratingRow struct {
+	ID            uuid.UUID
+	AverageRating float64
+	ReviewCount   int64
+}
+
+func (r *reviewRepo) CreateReview(review *entity.Review) error {
+	logger.Info("CreateReview repository method called")
+	return r.db.Create(review).Error
+}
+
+func (r *reviewRepo) GetReviewByID(id uuid.UUID) (*entity.Review, error) {
+	logger.Info("GetReviewByID repository method called")
+	var review entity.Review
+	err := r.db.Where("id = ?", id).First(&review).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) GetReviewByBooking(bookingID uuid.UUID) (*entity.Review, error) {
+	logger.Info("GetReviewByBooking repository method called")
+	var review entity.Review
+	err := r.db.Where("booking_id = ?", bookingID).First(&review).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) GetReviewsByCoffeeShop(shopID uuid.UUID) ([]entity.Review, error) {
+	logger.Info("GetReviewsByCoffeeShop repository method called")
+	var reviews []entity.Review
+	err := r.db.Where("coffee_shop_id = ? AND status = ?", shopID, entity.ReviewPublished).
+		Order("created_at DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepo) GetReviewsByMeetingRoom(roomID uuid.UUID) ([]entity.Review, error) {
+	logger.Info("GetReviewsByMeetingRoom repository method called")
+	var reviews []entity.Review
+	err := r.db.Where("meeting_room_id = ? AND status = ?", roomID, entity.ReviewPublished).
+		Order("created_at DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepo) GetReviewsByCustomer(customerID uuid.UUID) ([]entity.Review, error) {
+	logger.Info("GetReviewsByCustomer repository method called")
+	var reviews []entity.Review
+	err := r.db.Where("customer_id = ?", customerID).Order("created_at DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+// GetReviews returns all reviews for moderation, optionally only those with the given status
+func (r *reviewRepo) GetReviews(status string) ([]entity.Review, error) {
+	logger.Info("GetReviews repository method called")
+	var reviews []entity.Review
+	query := r.db.Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepo) ReplyToReview(id uuid.UUID, reply string, repliedAt time.Time) error {
+	logger.Info("ReplyToReview repository method called")
+	return r.db.Model(&entity.Review{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"owner_reply": reply,
+		"replied_at":  repliedAt,
+	}).Error
+}
+
+// SetReviewStatus moves a review to the given status; false means it already had that status
+func (r *reviewRepo) SetReviewStatus(id uuid.UUID, status string, reason string, moderatorID uuid.UUID, moderatedAt time.Time) (bool, error) {
+	logger.Info("SetReviewStatus repository method called")
+	result := r.db.Model(&entity.Review{}).
+		Where("id = ? AND status != ?", id, status).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"hidden_reason": reason,
+			"moderated_by":  moderatorID,
+			"moderated_at":  moderatedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *reviewRepo) GetShopRatings(shopIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error) {
+	logger.Info("GetShopRatings repository method called")
+	return r.getRatings("coffee_shop_id", shopIDs)
+}
+
+func (r *reviewRepo) GetRoomRatings(roomIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error) {
+	logger.Info("GetRoomRatings repository method called")
+	return r.getRatings("meeting_room_id", roomIDs)
+}
+
+// getRatings summarizes published reviews grouped by the given ID column
+func (r *reviewRepo) getRatings(column string, ids []uuid.UUID) (map[uuid.UUID]RatingSummary, error) {
+	result := make(map[uuid.UUID]RatingSummary)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var rows []ratingRow
+	err := r.db.Model(&entity.Review{}).
+		Select(column+" AS id, AVG(rating) AS average_rating, COUNT(*) AS review_count").
+		Where(column+" IN ? AND status = ?", ids, entity.ReviewPublished).
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.ID] = RatingSummary{AverageRating: row.AverageRating, ReviewCount: row.ReviewCount}
+	}
+	return result, nil
+}
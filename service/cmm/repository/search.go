@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+// headlineOptions marks matched words in search headlines
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+type ISearchRepo interface {
+	SearchCoffeeShops(query string, limit int) ([]ShopSearchResult, error)
+	SearchMeetingRooms(query string, limit int) ([]RoomTextSearchResult, error)
+	SearchShopPosts(query string, limit int) ([]PostSearchResult, error)
+}
+
+type searchRepo struct {
+	db *gorm.DB
+}
+
+func NewSearchRepo(db *gorm.DB) ISearchRepo {
+	return &searchRepo{
+		db: db,
+	}
+}
+
+// ShopSearchResult is a shop matching a full-text query
+type ShopSearchResult struct {
+	entity.CoffeeShop `gorm:"embedded"`
+	Rank              float64
+	Headline          string
+}
+
+// RoomTextSearchResult is a room matching a full-text query by its own name or its shop
+type RoomTextSearchResult struct {
+	entity.MeetingRoom `gorm:"embedded"`
+	ShopName           string
+	Rank               float64
+}
+
+// PostSearchResult is a shop post matching a full-text query
+type PostSearchResult struct {
+	entity.ShopPost `gorm:"embedded"`
+	ShopName        string
+	Rank            float64
+	Headline        string
+}
+
+// SearchCoffeeShops ranks shops by name, location and description. The query uses web search syntax.
+func (r *searchRepo) SearchCoffeeShops(query string, limit int) ([]ShopSearchResult, error) {
+	logger.Info("SearchCoffeeShops repository method called")
+	var results []ShopSearchResult
+	err := r.db.Table("coffee_shops").
+		Select(`coffee_shops.*,
+			ts_rank(coffee_shops.search_vector, query) AS rank,
+			ts_headline('cmm_unaccent', coalesce(nullif(coffee_shops.description, ''), coffee_shops.location), query, ?) AS headline`,
+			headlineOptions).
+		Joins("CROSS JOIN websearch_to_tsquery('cmm_unaccent', ?) AS query", query).
+		Where("coffee_shops.status = ?", entity.ShopApproved).
+		Where("coffee_shops.search_vector @@ query").
+		Order("rank DESC, coffee_shops.name").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// SearchMeetingRooms finds available rooms whose name or shop matches, rooms matching by name first
+func (r *searchRepo) SearchMeetingRooms(query string, limit int) ([]RoomTextSearchResult, error) {
+	logger.Info("SearchMeetingRooms repository method called")
+	var results []RoomTextSearchResult
+	err := r.db.Table("meeting_rooms").
+		Select(`meeting_rooms.*, coffee_shops.name AS shop_name,
+			ts_rank(meeting_rooms.search_vector, query) + ts_rank(coffee_shops.search_vector, query) / 2 AS rank`).
+		Joins("JOIN coffee_shops ON coffee_shops.id = meeting_rooms.coffee_shop_id").
+		Joins("CROSS JOIN websearch_to_tsquery('cmm_unaccent', ?) AS query", query).
+		Where("meeting_rooms.available = ? AND coffee_shops.status = ?", true, entity.ShopApproved).
+		Where("meeting_rooms.search_vector @@ query OR coffee_shops.search_vector @@ query").
+		Order("rank DESC, meeting_rooms.name").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// SearchShopPosts ranks shop posts by title and content
+func (r *searchRepo) SearchShopPosts(query string, limit int) ([]PostSearchResult, error) {
+	logger.Info("SearchShopPosts repository method called")
+	var results []PostSearchResult
+	err := r.db.Table("shop_posts").
+		Select(`shop_posts.*, coffee_shops.name AS shop_name,
+			ts_rank(shop_posts.search_vector, query) AS rank,
+			ts_headline('cmm_unaccent', shop_posts.content, query, ?) AS headline`,
+			headlineOptions).
+		Joins("JOIN coffee_shops ON coffee_shops.id = shop_posts.coffee_shop_id").
+		Joins("CROSS JOIN websearch_to_tsquery('cmm_unaccent', ?) AS query", query).
+		Where("coffee_shops.status = ?", entity.ShopApproved).
+		Where("shop_posts.search_vector @@ query").
+		Order("rank DESC, shop_posts.published_at DESC").
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
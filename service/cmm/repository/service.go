@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IServiceRepo interface {
+	GetServiceByID(id entity.ServiceType) (*entity.Service, error)
+	GetAllServices() ([]entity.Service, error)
+}
+
+type serviceRepo struct {
+	db *gorm.DB
+}
+
+func NewServiceRepo(db *gorm.DB) IServiceRepo {
+	return &serviceRepo{
+		db: db,
+	}
+}
+
+func (r *serviceRepo) GetServiceByID(id entity.ServiceType) (*entity.Service, error) {
+	logger.Info("GetServiceByID repository method called")
+	var service entity.Service
+	err := r.db.Where("id = ?", id).First(&service).Error
+	if err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+func (r *serviceRepo) GetAllServices() ([]entity.Service, error) {
+	logger.Info("GetAllServices repository method called")
+	var services []entity.Service
+	err := r.db.Order("id ASC").Find(&services).Error
+	return services, err
+}
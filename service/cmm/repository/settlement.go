@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+// ErrEarningsAlreadySettled is returned when another settlement run claimed some of the earnings first
+var ErrEarningsAlreadySettled = errors.New("earnings were already settled by another run")
+
+type ISettlementRepo interface {
+	// Earning methods
+	CreateEarning(earning *entity.Earning) error
+	GetEarningByServiceRef(serviceID entity.ServiceType, serviceRefID uuid.UUID) (*entity.Earning, error)
+	GetUnsettledEarnings(periodStart, periodEnd time.Time) ([]entity.Earning, error)
+	GetEarningsByStatement(statementID uuid.UUID) ([]entity.Earning, error)
+	CountEarningsByCoffeeShop(shopID uuid.UUID) (int64, error)
+
+	// Settlement methods
+	CreateSettlement(settlement *entity.Settlement, statements []entity.PayoutStatement, statementEarnings map[uuid.UUID][]uuid.UUID) error
+	GetSettlementByID(id uuid.UUID) (*entity.Settlement, error)
+	GetAllSettlements() ([]entity.Settlement, error)
+
+	// Payout statement methods
+	GetStatementByID(id uuid.UUID) (*entity.PayoutStatement, error)
+	GetStatementsBySettlement(settlementID uuid.UUID) ([]entity.PayoutStatement, error)
+	GetStatementsByOwner(ownerID uuid.UUID) ([]entity.PayoutStatement, error)
+	MarkStatementPaid(id uuid.UUID, transaction *entity.Transaction) (bool, error)
+}
+
+type settlementRepo struct {
+	db *gorm.DB
+}
+
+func NewSettlementRepo(db *gorm.DB) ISettlementRepo {
+	return &settlementRepo{
+		db: db,
+	}
+}
+
+func (r *settlementRepo) CreateEarning(earning *entity.Earning) error {
+	logger.Info("CreateEarning repository method called")
+	return r.db.Create(earning).Error
+}
+
+func (r *settlementRepo) GetEarningByServiceRef(serviceID entity.ServiceType, serviceRefID uuid.UUID) (*entity.Earning, error) {
+	logger.Info("GetEarningByServiceRef repository method called")
+	var earning entity.Earning
+	err := r.db.Where("service_id = ? AND service_ref_id = ?", serviceID, serviceRefID).First(&earning).Error
+	if err != nil {
+		return nil, err
+	}
+	return &earning, nil
+}
+
+func (r *settlementRepo) GetUnsettledEarnings(periodStart, periodEnd time.Time) ([]entity.Earning, error) {
+	logger.Info("GetUnsettledEarnings repository method called")
+	var earnings []entity.Earning
+	err := r.db.Where("payout_statement_id IS NULL AND earned_at >= ? AND earned_at < ?", periodStart, periodEnd).
+		Order("earned_at ASC").Find(&earnings).Error
+	return earnings, err
+}
+
+func (r *settlementRepo) GetEarningsByStatement(statementID uuid.UUID) ([]entity.Earning, error) {
+	logger.Info("GetEarningsByStatement repository method called")
+	var earnings []entity.Earning
+	err := r.db.Where("payout_statement_id = ?", statementID).Order("earned_at ASC").Find(&earnings).Error
+	return earnings, err
+}
+
+func (r *settlementRepo) CountEarningsByCoffeeShop(shopID uuid.UUID) (int64, error) {
+	logger.Info("CountEarningsByCoffeeShop repository method called")
+	var count int64
+	err := r.db.Model(&entity.Earning{}).Where("coffee_shop_id = ?", shopID).Count(&count).Error
+	return count, err
+}
+
+// CreateSettlement stores a settlement run with its statements and links the
+// settled earnings to their statement in a single transaction
+func (r *settlementRepo) CreateSettlement(settlement *entity.Settlement, statements []entity.PayoutStatement, statementEarnings map[uuid.UUID][]uuid.UUID) error {
+	logger.Info("CreateSettlement repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(settlement).Error; err != nil {
+			return err
+		}
+		if len(statements) > 0 {
+			if err := tx.Create(&statements).Error; err != nil {
+				return err
+			}
+		}
+		for statementID, earningIDs := range statementEarnings {
+			result := tx.Model(&entity.Earning{}).
+				Where("id IN ? AND payout_statement_id IS NULL", earningIDs).
+				Update("payout_statement_id", statementID)
+			if result.Error != nil {
+				return result.Error
+			}
+			// A run that overlapped this one settled some earnings; roll back rather than pay them twice
+			if result.RowsAffected != int64(len(earningIDs)) {
+				return ErrEarningsAlreadySettled
+			}
+		}
+		return nil
+	})
+}
+
+func (r *settlementRepo) GetSettlementByID(id uuid.UUID) (*entity.Settlement, error) {
+	logger.Info("GetSettlementByID repository method called")
+	var settlement entity.Settlement
+	err := r.db.Where("id = ?", id).First(&settlement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+func (r *settlementRepo) GetAllSettlements() ([]entity.Settlement, error) {
+	logger.Info("GetAllSettlements repository method called")
+	var settlements []entity.Settlement
+	err := r.db.Order("created_at DESC").Find(&settlements).Error
+	return settlements, err
+}
+
+func (r *settlementRepo) GetStatementByID(id uuid.UUID) (*entity.PayoutStatement, error) {
+	logger.Info("GetStatementByID repository method called")
+	var statement entity.PayoutStatement
+	err := r.db.Where("id = ?", id).First(&statement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+func (r *settlementRepo) GetStatementsBySettlement(settlementID uuid.UUID) ([]entity.PayoutStatement, error) {
+	logger.Info("GetStatementsBySettlement repository method called")
+	var statements []entity.PayoutStatement
+	err := r.db.Where("settlement_id = ?", settlementID).Find(&statements).Error
+	return statements, err
+}
+
+func (r *settlementRepo) GetStatementsByOwner(ownerID uuid.UUID) ([]entity.PayoutStatement, error) {
+	logger.Info("GetStatementsByOwner repository method called")
+	var statements []entity.PayoutStatement
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC").Find(&statements).Error
+	return statements, err
+}
+
+// MarkStatementPaid pays out a pending statement: the statement is marked paid, the owner's
+// wallet is credited and the payout transaction is recorded in one database transaction
+func (r *settlementRepo) MarkStatementPaid(id uuid.UUID, transaction *entity.Transaction) (bool, error) {
+	logger.Info("MarkStatementPaid repository method called")
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.PayoutStatement{}).
+			Where("id = ? AND status = ?", id, "pending").
+			Updates(map[string]interface{}{"status": "paid", "paid_at": transaction.PaidAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		result = tx.Model(&entity.Wallet{}).
+			Where("user_id = ?", transaction.UserID).
+			Update("balance", gorm.Expr("balance + ?", transaction.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("owner wallet not found")
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IShopVerificationRepo interface {
+	SubmitVerification(verification *entity.ShopVerification) (bool, error)
+	GetVerification(shopID uuid.UUID) (*entity.ShopVerification, error)
+	GetVerifications(shopIDs []uuid.UUID) (map[uuid.UUID]entity.ShopVerification, error)
+	ReviewShop(shopID uuid.UUID, status string, reason string, reviewerID uuid.UUID, reviewedAt time.Time) (bool, error)
+	GetCoffeeShopsByStatus(status string) ([]entity.CoffeeShop, error)
+
+	CreateShopDocument(document *entity.ShopDocument) error
+	GetShopDocumentByID(id uuid.UUID) (*entity.ShopDocument, error)
+	GetShopDocuments(shopID uuid.UUID) ([]entity.ShopDocument, error)
+	DeleteShopDocument(id uuid.UUID) error
+}
+
+type shopVerificationRepo struct {
+	db *gorm.DB
+}
+
+func NewShopVerificationRepo(db *gorm.DB) IShopVerificationRepo {
+	return &shopVerificationRepo{
+		db: db,
+	}
+}
+
+// SubmitVerification saves the business details and puts a pending or rejected shop up for review.
+// It reports false when the shop is already submitted or approved.
+func (r *shopVerificationRepo) SubmitVerification(verification *entity.ShopVerification) (bool, error) {
+	logger.Info("SubmitVerification repository method called")
+	submitted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.CoffeeShop{}).
+			Where("id = ? AND status IN ?", verification.CoffeeShopID, []string{entity.ShopPending, entity.ShopRejected}).
+			Update("status", entity.ShopSubmitted)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// A resubmission replaces the earlier details and clears the previous review
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(verification).Error; err != nil {
+			return err
+		}
+		submitted = true
+		return nil
+	})
+	return submitted, err
+}
+
+func (r *shopVerificationRepo) GetVerification(shopID uuid.UUID) (*entity.ShopVerification, error) {
+	logger.Info("GetVerification repository method called")
+	var verification entity.ShopVerification
+	err := r.db.Where("coffee_shop_id = ?", shopID).First(&verification).Error
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+func (r *shopVerificationRepo) GetVerifications(shopIDs []uuid.UUID) (map[uuid.UUID]entity.ShopVerification, error) {
+	logger.Info("GetVerifications repository method called")
+	result := make(map[uuid.UUID]entity.ShopVerification, len(shopIDs))
+	if len(shopIDs) == 0 {
+		return result, nil
+	}
+
+	var verifications []entity.ShopVerification
+	if err := r.db.Where("coffee_shop_id IN ?", shopIDs).Find(&verifications).Error; err != nil {
+		return nil, err
+	}
+	for _, verification := range verifications {
+		result[verification.CoffeeShopID] = verification
+	}
+	return result, nil
+}
+
+// ReviewShop approves or rejects a submitted shop; false means the shop is not waiting for review
+func (r *shopVerificationRepo) ReviewShop(shopID uuid.UUID, status string, reason string, reviewerID uuid.UUID, reviewedAt time.Time) (bool, error) {
+	logger.Info("ReviewShop repository method called")
+	reviewed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.CoffeeShop{}).
+			Where("id = ? AND status = ?", shopID, entity.ShopSubmitted).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&entity.ShopVerification{}).
+			Where("coffee_shop_id = ?", shopID).
+			Updates(map[string]interface{}{
+				"rejection_reason": reason,
+				"reviewed_by":      reviewerID,
+				"reviewed_at":      reviewedAt,
+			}).Error; err != nil {
+			return err
+		}
+		reviewed = true
+		return nil
+	})
+	return reviewed, err
+}
+
+// GetCoffeeShopsByStatus lists shops in a verification status, oldest first
+func (r *shopVerificationRepo) GetCoffeeShopsByStatus(status string) ([]entity.CoffeeShop, error) {
+	logger.Info("GetCoffeeShopsByStatus repository method called")
+	var shops []entity.CoffeeShop
+	err := r.db.Where("status = ?", status).Order("created_at ASC").Find(&shops).Error
+	return shops, err
+}
+
+func (r *shopVerificationRepo) CreateShopDocument(document *entity.ShopDocument) error {
+	logger.Info("CreateShopDocument repository method called")
+	return r.db.Create(document).Error
+}
+
+func (r *shopVerificationRepo) GetShopDocumentByID(id uuid.UUID) (*entity.ShopDocument, error) {
+	logger.Info("GetShopDocumentByID repository method called")
+	var document entity.ShopDocument
+	err := r.db.Where("id = ?", id).First(&document).Error
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (r *shopVerificationRepo) GetShopDocuments(shopID uuid.UUID) ([]entity.ShopDocument, error) {
+	logger.Info("GetShopDocuments repository method called")
+	var documents []entity.ShopDocument
+	err := r.db.Where("coffee_shop_id = ?", shopID).Order("created_at ASC").Find(&documents).Error
+	return documents, err
+}
+
+func (r *shopVerificationRepo) DeleteShopDocument(id uuid.UUID) error {
+	logger.Info("DeleteShopDocument repository method called")
+	return r.db.Where("id = ?", id).Delete(&entity.ShopDocument{}).Error
+}
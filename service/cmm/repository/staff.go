@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+type IStaffRepo interface {
+	CreateStaff(staff *entity.ShopStaff) error
+	GetStaffByID(id uuid.UUID) (*entity.ShopStaff, error)
+	GetStaffMember(shopID uuid.UUID, userID uuid.UUID) (*entity.ShopStaff, error)
+	GetStaffByShop(shopID uuid.UUID) ([]StaffMember, error)
+	GetStaffByUser(userID uuid.UUID, status string) ([]StaffShop, error)
+	UpdateStaffPermissions(id uuid.UUID, permissions []string) error
+	AcceptInvitation(id uuid.UUID, userID uuid.UUID, acceptedAt time.Time) (bool, error)
+	DeleteStaff(id uuid.UUID) error
+}
+
+type staffRepo struct {
+	db *gorm.DB
+}
+
+func NewStaffRepo(db *gorm.DB) IStaffRepo {
+	return &staffRepo{
+		db: db,
+	}
+}
+
+// StaffMember is a staff record together with the staff user's name and email
+type StaffMember struct {
+	entity.ShopStaff `gorm:"embedded"`
+	FullName         string
+	Email            string
+}
+
+// StaffShop is a staff record together with the shop name
+type StaffShop struct {
+	entity.ShopStaff `gorm:"embedded"`
+	ShopName         string
+}
+
+func (r *staffRepo) CreateStaff(staff *entity.ShopStaff) error {
+	logger.Info("CreateStaff repository method called")
+	return r.db.Create(staff).Error
+}
+
+func (r *staffRepo) GetStaffByID(id uuid.UUID) (*entity.ShopStaff, error) {
+	logger.Info("GetStaffByID repository method called")
+	var staff entity.ShopStaff
+	err := r.db.Where("id = ?", id).First(&staff).Error
+	if err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+// GetStaffMember returns the user's staff record at a shop, whether invited or active
+func (r *staffRepo) GetStaffMember(shopID uuid.UUID, userID uuid.UUID) (*entity.ShopStaff, error) {
+	logger.Info("GetStaffMember repository method called")
+	var staff entity.ShopStaff
+	err := r.db.Where("coffee_shop_id = ? AND user_id = ?", shopID, userID).First(&staff).Error
+	if err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *staffRepo) GetStaffByShop(shopID uuid.UUID) ([]StaffMember, error) {
+	logger.Info("GetStaffByShop repository method called")
+	var members []StaffMember
+	err := r.db.Table("shop_staffs").
+		Select("shop_staffs.*, users.full_name, users.email").
+		Joins("JOIN users ON users.id = shop_staffs.user_id").
+		Where("shop_staffs.coffee_shop_id = ?", shopID).
+		Order("shop_staffs.created_at ASC").
+		Scan(&members).Error
+	return members, err
+}
+
+func (r *staffRepo) GetStaffByUser(userID uuid.UUID, status string) ([]StaffShop, error) {
+	logger.Info("GetStaffByUser repository method called")
+	var shops []StaffShop
+	err := r.db.Table("shop_staffs").
+		Select("shop_staffs.*, coffee_shops.name AS shop_name").
+		Joins("JOIN coffee_shops ON coffee_shops.id = shop_staffs.coffee_shop_id").
+		Where("shop_staffs.user_id = ? AND shop_staffs.status = ?", userID, status).
+		Order("shop_staffs.created_at DESC").
+		Scan(&shops).Error
+	return shops, err
+}
+
+func (r *staffRepo) UpdateStaffPermissions(id uuid.UUID, permissions []string) error {
+	logger.Info("UpdateStaffPermissions repository method called")
+	return r.db.Model(&entity.ShopStaff{ID: id}).
+		Select("permissions").
+		Updates(&entity.ShopStaff{Permissions: permissions}).Error
+}
+
+// AcceptInvitation activates an invitation addressed to the user; false means there is no such pending invitation
+func (r *staffRepo) AcceptInvitation(id uuid.UUID, userID uuid.UUID, acceptedAt time.Time) (bool, error) {
+	logger.Info("AcceptInvitation repository method called")
+	result := r.db.Model(&entity.ShopStaff{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, entity.StaffInvited).
+		Updates(map[string]interface{}{
+			"status":      entity.StaffActive,
+			"accepted_at": acceptedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *staffRepo) DeleteStaff(id uuid.UUID) error {
+	logger.Info("DeleteStaff repository method called")
+	return r.db.Where("id = ?", id).Delete(&entity.ShopStaff{}).Error
+}
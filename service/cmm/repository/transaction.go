@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
@@ -13,6 +15,8 @@ type ITransactionRepo interface {
 	GetTransactionsByUser(userID uuid.UUID) ([]entity.Transaction, error)
 	GetTransactionsByService(serviceID int, serviceRefID uuid.UUID) ([]entity.Transaction, error)
 	UpdateTransactionStatus(id uuid.UUID, status string) error
+	CountCompletedByServices(userID uuid.UUID, serviceIDs []entity.ServiceType) (int64, error)
+	SumSpendSince(userID uuid.UUID, serviceIDs []entity.ServiceType, since time.Time) (float64, error)
 }
 
 type transactionRepo struct {
@@ -59,3 +63,23 @@ func (r *transactionRepo) UpdateTransactionStatus(id uuid.UUID, status string) e
 	logger.Info("UpdateTransactionStatus repository method called")
 	return r.db.Model(&entity.Transaction{}).Where("id = ?", id).Update("status", status).Error
 }
+
+func (r *transactionRepo) CountCompletedByServices(userID uuid.UUID, serviceIDs []entity.ServiceType) (int64, error) {
+	logger.Info("CountCompletedByServices repository method called")
+	var count int64
+	err := r.db.Model(&entity.Transaction{}).
+		Where("user_id = ? AND service_id IN ? AND status = ?", userID, serviceIDs, "completed").
+		Count(&count).Error
+	return count, err
+}
+
+// SumSpendSince returns what the user paid for the given services since a time, net of refunds
+func (r *transactionRepo) SumSpendSince(userID uuid.UUID, serviceIDs []entity.ServiceType, since time.Time) (float64, error) {
+	logger.Info("SumSpendSince repository method called")
+	var total float64
+	err := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND service_id IN ? AND status IN ? AND paid_at >= ?", userID, serviceIDs, []string{"completed", "refunded"}, since).
+		Scan(&total).Error
+	return total, err
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"gorm.io/gorm"
@@ -12,6 +14,12 @@ type IUserRepo interface {
 	GetUserByEmail(email string) (*entity.User, error)
 	CreateUser(user *entity.User) error
 	CreateWallet(wallet *entity.Wallet) error
+	GetUserByID(id uuid.UUID) (*entity.User, error)
+	GetUserByReferralCode(code string) (*entity.User, error)
+	UpdateUserProfile(user *entity.User) error
+	UpdatePassword(id uuid.UUID, passwordHash string) error
+	UpdateEmail(id uuid.UUID, email string, verifiedAt time.Time) error
+	MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error
 }
 
 type userRepo struct {
@@ -112,3 +120,53 @@ func (r *userRepo) CreateWallet(wallet *entity.Wallet) error {
 	logger.Infof("Wallet created successfully for user ID: %s", wallet.UserID)
 	return nil
 }
+
+func (r *userRepo) GetUserByID(id uuid.UUID) (*entity.User, error) {
+	logger.Info("GetUserByID repository method called")
+	var user entity.User
+	err := r.db.Where("id = ?", id).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepo) GetUserByReferralCode(code string) (*entity.User, error) {
+	logger.Info("GetUserByReferralCode repository method called")
+	var user entity.User
+	err := r.db.Where("referral_code = ?", code).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUserProfile saves the editable profile fields; email and password have their own flows
+func (r *userRepo) UpdateUserProfile(user *entity.User) error {
+	logger.Info("UpdateUserProfile repository method called")
+	return r.db.Model(user).
+		Select("full_name", "phone", "avatar_url", "preferred_language").
+		Updates(user).Error
+}
+
+func (r *userRepo) UpdatePassword(id uuid.UUID, passwordHash string) error {
+	logger.Info("UpdatePassword repository method called")
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+}
+
+// UpdateEmail switches to an address the user has just confirmed, so it is stored as verified
+func (r *userRepo) UpdateEmail(id uuid.UUID, email string, verifiedAt time.Time) error {
+	logger.Info("UpdateEmail repository method called")
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":             email,
+		"email_verified_at": verifiedAt,
+	}).Error
+}
+
+// MarkEmailVerified records when the user's email was verified, keeping an earlier verification
+func (r *userRepo) MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error {
+	logger.Info("MarkEmailVerified repository method called")
+	return r.db.Model(&entity.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", verifiedAt).Error
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserTokenRepo interface {
+	CreateUserToken(token *entity.UserToken) error
+	GetPendingUserToken(userID uuid.UUID, purpose string, now time.Time) (*entity.UserToken, error)
+	ConsumeUserToken(tokenHash string, purpose string, now time.Time) (*entity.UserToken, error)
+	DeleteUserTokens(userID uuid.UUID, purpose string) error
+}
+
+type userTokenRepo struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepo(db *gorm.DB) IUserTokenRepo {
+	return &userTokenRepo{
+		db: db,
+	}
+}
+
+func (r *userTokenRepo) CreateUserToken(token *entity.UserToken) error {
+	logger.Info("CreateUserToken repository method called")
+	return r.db.Create(token).Error
+}
+
+// GetPendingUserToken returns the latest unused, unexpired token of the user for the purpose
+func (r *userTokenRepo) GetPendingUserToken(userID uuid.UUID, purpose string, now time.Time) (*entity.UserToken, error) {
+	logger.Info("GetPendingUserToken repository method called")
+	var token entity.UserToken
+	err := r.db.Where("user_id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", userID, purpose, now).
+		Order("created_at DESC").
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ConsumeUserToken marks an unused, unexpired token as used and returns it. The conditional
+// update makes sure a token can only be consumed once; gorm.ErrRecordNotFound is returned otherwise.
+func (r *userTokenRepo) ConsumeUserToken(tokenHash string, purpose string, now time.Time) (*entity.UserToken, error) {
+	logger.Info("ConsumeUserToken repository method called")
+	var token entity.UserToken
+	result := r.db.Model(&token).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &token, nil
+}
+
+// DeleteUserTokens removes the unused tokens of the user for the purpose
+func (r *userTokenRepo) DeleteUserTokens(userID uuid.UUID, purpose string) error {
+	logger.Info("DeleteUserTokens repository method called")
+	return r.db.Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Delete(&entity.UserToken{}).Error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,10 +17,20 @@ type IVoucherRepo interface {
 	GetAllVouchers() ([]entity.Voucher, error)
 	GetValidVouchers() ([]entity.Voucher, error)
 	UpdateVoucher(voucher *entity.Voucher) error
-	IncrementUsedCount(id uuid.UUID) error
 	DeleteVoucher(id uuid.UUID) error
+	RedeemVoucher(redemption *entity.VoucherRedemption, perUserLimit int) (bool, error)
+	ReleaseRedemption(serviceID entity.ServiceType, serviceRefID uuid.UUID) (bool, error)
+	CountUserRedemptions(voucherID, userID uuid.UUID) (int64, error)
+	GetRedemptionsByVoucher(voucherID uuid.UUID) ([]entity.VoucherRedemption, error)
+	CreateShopVoucher(voucher *entity.Voucher, meetingRoomIDs []uuid.UUID) error
+	UpdateShopVoucher(voucher *entity.Voucher, meetingRoomIDs []uuid.UUID) error
+	GetVouchersByCoffeeShop(shopID uuid.UUID) ([]entity.Voucher, error)
+	GetVoucherMeetingRoomIDs(voucherID uuid.UUID) ([]uuid.UUID, error)
 }
 
+// errVoucherUserLimitReached rolls back a redemption that would exceed the per-user limit
+var errVoucherUserLimitReached = errors.New("voucher per-user limit reached")
+
 type voucherRepo struct {
 	db *gorm.DB
 }
@@ -62,28 +73,187 @@ func (r *voucherRepo) GetAllVouchers() ([]entity.Voucher, error) {
 	return vouchers, err
 }
 
+// GetValidVouchers returns the currently usable platform vouchers. Campaign codes and shop
+// vouchers are handed out to their own audiences, so they are never listed publicly.
 func (r *voucherRepo) GetValidVouchers() ([]entity.Voucher, error) {
 	logger.Info("GetValidVouchers repository method called")
 	now := time.Now()
 	var vouchers []entity.Voucher
-	err := r.db.Where("valid_from <= ? AND valid_to >= ? AND (max_uses = 0 OR used_count < max_uses)",
-		now, now).Find(&vouchers).Error
+	err := r.db.Where("campaign_id IS NULL AND coffee_shop_id IS NULL").
+		Where("valid_from <= ? AND valid_to >= ? AND (max_uses = 0 OR used_count < max_uses)", now, now).
+		Find(&vouchers).Error
 	return vouchers, err
 }
 
 func (r *voucherRepo) UpdateVoucher(voucher *entity.Voucher) error {
 	logger.Info("UpdateVoucher repository method called")
-	return r.db.Save(voucher).Error
-}
-
-func (r *voucherRepo) IncrementUsedCount(id uuid.UUID) error {
-	logger.Info("IncrementUsedCount repository method called")
-	return r.db.Model(&entity.Voucher{}).
-		Where("id = ?", id).
-		Update("used_count", gorm.Expr("used_count + 1")).Error
+	// used_count is only moved by redemptions, so an edit cannot overwrite a concurrent one
+	return r.db.Model(voucher).
+		Select("code", "discount_type", "discount_percent", "discount_amount", "max_discount", "min_order_amount",
+			"max_uses", "per_user_limit", "first_time_only", "valid_from", "valid_to").
+		Updates(voucher).Error
 }
 
 func (r *voucherRepo) DeleteVoucher(id uuid.UUID) error {
 	logger.Info("DeleteVoucher repository method called")
 	return r.db.Delete(&entity.Voucher{}, "id = ?", id).Error
 }
+
+// RedeemVoucher records a redemption and consumes one use of the voucher in a single
+// transaction. It returns false when the voucher or the user's allowance is used up.
+func (r *voucherRepo) RedeemVoucher(redemption *entity.VoucherRedemption, perUserLimit int) (bool, error) {
+	logger.Info("RedeemVoucher repository method called")
+	redeemed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The conditional update locks the voucher row until commit, so concurrent
+		// redemptions of the same voucher are serialized
+		result := tx.Model(&entity.Voucher{}).
+			Where("id = ? AND (max_uses = 0 OR used_count < max_uses)", redemption.VoucherID).
+			Update("used_count", gorm.Expr("used_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if perUserLimit > 0 {
+			var count int64
+			if err := tx.Model(&entity.VoucherRedemption{}).
+				Where("voucher_id = ? AND user_id = ? AND status = ?",
+					redemption.VoucherID, redemption.UserID, entity.RedemptionRedeemed).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count >= int64(perUserLimit) {
+				return errVoucherUserLimitReached
+			}
+		}
+
+		if err := tx.Create(redemption).Error; err != nil {
+			return err
+		}
+		redeemed = true
+		return nil
+	})
+	if errors.Is(err, errVoucherUserLimitReached) {
+		return false, nil
+	}
+	return redeemed, err
+}
+
+// ReleaseRedemption marks the redemption for a service reference as released and gives the use back to the voucher
+func (r *voucherRepo) ReleaseRedemption(serviceID entity.ServiceType, serviceRefID uuid.UUID) (bool, error) {
+	logger.Info("ReleaseRedemption repository method called")
+	released := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var redemption entity.VoucherRedemption
+		if err := tx.Where("service_id = ? AND service_ref_id = ? AND status = ?",
+			serviceID, serviceRefID, entity.RedemptionRedeemed).
+			First(&redemption).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		result := tx.Model(&entity.VoucherRedemption{}).
+			Where("id = ? AND status = ?", redemption.ID, entity.RedemptionRedeemed).
+			Updates(map[string]interface{}{"status": entity.RedemptionReleased, "released_at": time.Now()})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Model(&entity.Voucher{}).
+			Where("id = ? AND used_count > 0", redemption.VoucherID).
+			Update("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+			return err
+		}
+		released = true
+		return nil
+	})
+	return released, err
+}
+
+func (r *voucherRepo) CountUserRedemptions(voucherID, userID uuid.UUID) (int64, error) {
+	logger.Info("CountUserRedemptions repository method called")
+	var count int64
+	err := r.db.Model(&entity.VoucherRedemption{}).
+		Where("voucher_id = ? AND user_id = ? AND status = ?", voucherID, userID, entity.RedemptionRedeemed).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *voucherRepo) GetRedemptionsByVoucher(voucherID uuid.UUID) ([]entity.VoucherRedemption, error) {
+	logger.Info("GetRedemptionsByVoucher repository method called")
+	var redemptions []entity.VoucherRedemption
+	err := r.db.Where("voucher_id = ?", voucherID).
+		Order("redeemed_at DESC").
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+// CreateShopVoucher creates a shop voucher together with the meeting rooms it is limited to
+func (r *voucherRepo) CreateShopVoucher(voucher *entity.Voucher, meetingRoomIDs []uuid.UUID) error {
+	logger.Info("CreateShopVoucher repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(voucher).Error; err != nil {
+			return err
+		}
+		if len(meetingRoomIDs) == 0 {
+			return nil
+		}
+		rooms := make([]entity.VoucherMeetingRoom, 0, len(meetingRoomIDs))
+		for _, roomID := range meetingRoomIDs {
+			rooms = append(rooms, entity.VoucherMeetingRoom{VoucherID: voucher.ID, MeetingRoomID: roomID})
+		}
+		return tx.Create(&rooms).Error
+	})
+}
+
+// UpdateShopVoucher saves the editable fields of a shop voucher and, when meetingRoomIDs is not nil,
+// replaces the meeting rooms it is limited to. The used count is left to redemptions.
+func (r *voucherRepo) UpdateShopVoucher(voucher *entity.Voucher, meetingRoomIDs []uuid.UUID) error {
+	logger.Info("UpdateShopVoucher repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(voucher).
+			Select("code", "discount_type", "discount_percent", "discount_amount", "max_discount", "min_order_amount",
+				"max_uses", "per_user_limit", "first_time_only", "valid_from", "valid_to").
+			Updates(voucher).Error; err != nil {
+			return err
+		}
+		if meetingRoomIDs == nil {
+			return nil
+		}
+
+		if err := tx.Where("voucher_id = ?", voucher.ID).Delete(&entity.VoucherMeetingRoom{}).Error; err != nil {
+			return err
+		}
+		if len(meetingRoomIDs) == 0 {
+			return nil
+		}
+		rooms := make([]entity.VoucherMeetingRoom, 0, len(meetingRoomIDs))
+		for _, roomID := range meetingRoomIDs {
+			rooms = append(rooms, entity.VoucherMeetingRoom{VoucherID: voucher.ID, MeetingRoomID: roomID})
+		}
+		return tx.Create(&rooms).Error
+	})
+}
+
+func (r *voucherRepo) GetVouchersByCoffeeShop(shopID uuid.UUID) ([]entity.Voucher, error) {
+	logger.Info("GetVouchersByCoffeeShop repository method called")
+	var vouchers []entity.Voucher
+	err := r.db.Where("coffee_shop_id = ?", shopID).
+		Order("valid_to DESC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+func (r *voucherRepo) GetVoucherMeetingRoomIDs(voucherID uuid.UUID) ([]uuid.UUID, error) {
+	logger.Info("GetVoucherMeetingRoomIDs repository method called")
+	var roomIDs []uuid.UUID
+	err := r.db.Model(&entity.VoucherMeetingRoom{}).
+		Where("voucher_id = ?", voucherID).
+		Pluck("meeting_room_id", &roomIDs).Error
+	return roomIDs, err
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+)
+
+// CampaignStats summarizes code usage of a voucher campaign
+type CampaignStats struct {
+	TotalCodes    int64   `gorm:"column:total_codes"`
+	RedeemedCodes int64   `gorm:"column:redeemed_codes"`
+	Redemptions   int64   `gorm:"column:redemptions"`
+	DiscountTotal float64 `gorm:"column:discount_total"`
+}
+
+type IVoucherCampaignRepo interface {
+	CreateCampaign(campaign *entity.VoucherCampaign, vouchers []entity.Voucher) error
+	GetCampaignByID(id uuid.UUID) (*entity.VoucherCampaign, error)
+	GetAllCampaigns() ([]entity.VoucherCampaign, error)
+	GetCampaignVouchers(campaignID uuid.UUID) ([]entity.Voucher, error)
+	GetCampaignStats(campaignID uuid.UUID) (*CampaignStats, error)
+	GetExistingCodes(codes []string) ([]string, error)
+}
+
+type voucherCampaignRepo struct {
+	db *gorm.DB
+}
+
+func NewVoucherCampaignRepo(db *gorm.DB) IVoucherCampaignRepo {
+	return &voucherCampaignRepo{
+		db: db,
+	}
+}
+
+// voucherBatchSize keeps each insert well below the postgres parameter limit
+const voucherBatchSize = 500
+
+func (r *voucherCampaignRepo) CreateCampaign(campaign *entity.VoucherCampaign, vouchers []entity.Voucher) error {
+	logger.Info("CreateCampaign repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(campaign).Error; err != nil {
+			return err
+		}
+		if len(vouchers) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&vouchers, voucherBatchSize).Error
+	})
+}
+
+func (r *voucherCampaignRepo) GetCampaignByID(id uuid.UUID) (*entity.VoucherCampaign, error) {
+	logger.Info("GetCampaignByID repository method called")
+	var campaign entity.VoucherCampaign
+	err := r.db.Where("id = ?", id).First(&campaign).Error
+	if err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+func (r *voucherCampaignRepo) GetAllCampaigns() ([]entity.VoucherCampaign, error) {
+	logger.Info("GetAllCampaigns repository method called")
+	var campaigns []entity.VoucherCampaign
+	err := r.db.Order("created_at DESC").Find(&campaigns).Error
+	return campaigns, err
+}
+
+func (r *voucherCampaignRepo) GetCampaignVouchers(campaignID uuid.UUID) ([]entity.Voucher, error) {
+	logger.Info("GetCampaignVouchers repository method called")
+	var vouchers []entity.Voucher
+	err := r.db.Where("campaign_id = ?", campaignID).
+		Order("code").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+func (r *voucherCampaignRepo) GetCampaignStats(campaignID uuid.UUID) (*CampaignStats, error) {
+	logger.Info("GetCampaignStats repository method called")
+	var stats CampaignStats
+	err := r.db.Raw(`
+		SELECT
+			COUNT(*) AS total_codes,
+			COUNT(*) FILTER (WHERE v.used_count > 0) AS redeemed_codes,
+			COALESCE(SUM(r.redemptions), 0) AS redemptions,
+			COALESCE(SUM(r.discount_total), 0) AS discount_total
+		FROM vouchers v
+		LEFT JOIN (
+			SELECT voucher_id, COUNT(*) AS redemptions, SUM(discount_amount) AS discount_total
+			FROM voucher_redemptions
+			WHERE status = ?
+			GROUP BY voucher_id
+		) r ON r.voucher_id = v.id
+		WHERE v.campaign_id = ?
+	`, entity.RedemptionRedeemed, campaignID).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *voucherCampaignRepo) GetExistingCodes(codes []string) ([]string, error) {
+	logger.Info("GetExistingCodes repository method called")
+	var existing []string
+	if len(codes) == 0 {
+		return existing, nil
+	}
+	err := r.db.Model(&entity.Voucher{}).
+		Where("code IN ?", codes).
+		Pluck("code", &existing).Error
+	return existing, err
+}
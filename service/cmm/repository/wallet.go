@@ -1,10 +1,20 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned by Transfer when the transfer is not allowed
+var (
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrTransferLimitExceeded = errors.New("daily transfer limit exceeded")
 )
 
 type IWalletRepo interface {
@@ -18,6 +28,10 @@ type IWalletRepo interface {
 	GetTopupByID(id uuid.UUID) (*entity.Topup, error)
 	GetTopupsByUser(userID uuid.UUID) ([]entity.Topup, error)
 	UpdateTopupStatus(id uuid.UUID, status string) error
+
+	// Transfer methods
+	Transfer(transfer *entity.WalletTransfer, transactions []entity.Transaction, limitSince time.Time, dailyLimit float64, dailyCount int) error
+	GetTransfersByUser(userID uuid.UUID) ([]entity.WalletTransfer, error)
 }
 
 type walletRepo struct {
@@ -85,3 +99,68 @@ func (r *walletRepo) UpdateTopupStatus(id uuid.UUID, status string) error {
 	logger.Info("UpdateTopupStatus repository method called")
 	return r.db.Model(&entity.Topup{}).Where("id = ?", id).Update("status", status).Error
 }
+
+// Transfer moves balance between two wallets and records the transfer with its transactions
+// in one database transaction. Both wallets are locked first, so concurrent transfers can
+// neither overdraw the sender nor get past the daily limit.
+func (r *walletRepo) Transfer(transfer *entity.WalletTransfer, transactions []entity.Transaction, limitSince time.Time, dailyLimit float64, dailyCount int) error {
+	logger.Info("Transfer repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock in a fixed order so opposite transfers cannot deadlock
+		var wallets []entity.Wallet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id IN ?", []uuid.UUID{transfer.SenderID, transfer.RecipientID}).
+			Order("user_id").
+			Find(&wallets).Error; err != nil {
+			return err
+		}
+		if len(wallets) != 2 {
+			return gorm.ErrRecordNotFound
+		}
+		for _, wallet := range wallets {
+			if wallet.UserID == transfer.SenderID && wallet.Balance < transfer.Amount {
+				return ErrInsufficientBalance
+			}
+		}
+
+		var usage struct {
+			Total float64
+			Count int64
+		}
+		if err := tx.Model(&entity.WalletTransfer{}).
+			Select("COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+			Where("sender_id = ? AND created_at >= ?", transfer.SenderID, limitSince).
+			Scan(&usage).Error; err != nil {
+			return err
+		}
+		if (dailyLimit > 0 && usage.Total+transfer.Amount > dailyLimit) ||
+			(dailyCount > 0 && usage.Count >= int64(dailyCount)) {
+			return ErrTransferLimitExceeded
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where("user_id = ?", transfer.SenderID).
+			Update("balance", gorm.Expr("balance - ?", transfer.Amount)).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Wallet{}).
+			Where("user_id = ?", transfer.RecipientID).
+			Update("balance", gorm.Expr("balance + ?", transfer.Amount)).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(transfer).Error; err != nil {
+			return err
+		}
+		return tx.Create(&transactions).Error
+	})
+}
+
+func (r *walletRepo) GetTransfersByUser(userID uuid.UUID) ([]entity.WalletTransfer, error) {
+	logger.Info("GetTransfersByUser repository method called")
+	var transfers []entity.WalletTransfer
+	err := r.db.Where("sender_id = ? OR recipient_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	return transfers, err
+}
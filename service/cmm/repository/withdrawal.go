@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IWithdrawalRepo interface {
+	// Payout account methods
+	CreatePayoutAccount(account *entity.PayoutAccount) error
+	GetPayoutAccountByID(id uuid.UUID) (*entity.PayoutAccount, error)
+	GetPayoutAccountsByUser(userID uuid.UUID) ([]entity.PayoutAccount, error)
+	DeletePayoutAccount(id uuid.UUID, userID uuid.UUID) (bool, error)
+
+	// Withdrawal methods
+	CreateWithdrawal(withdrawal *entity.Withdrawal, transaction *entity.Transaction) error
+	GetWithdrawalByID(id uuid.UUID) (*entity.Withdrawal, error)
+	GetWithdrawalsByUser(userID uuid.UUID) ([]entity.Withdrawal, error)
+	GetWithdrawals(status string) ([]entity.Withdrawal, error)
+	ApproveWithdrawal(id uuid.UUID, adminID uuid.UUID, processedAt time.Time) (bool, error)
+	MarkWithdrawalPaid(id uuid.UUID, adminID uuid.UUID, paidAt time.Time) (bool, error)
+	RejectWithdrawal(id uuid.UUID, adminID uuid.UUID, reason string, processedAt time.Time) (bool, error)
+	GetWithdrawalEvents(withdrawalID uuid.UUID) ([]entity.WithdrawalEvent, error)
+}
+
+type withdrawalRepo struct {
+	db *gorm.DB
+}
+
+func NewWithdrawalRepo(db *gorm.DB) IWithdrawalRepo {
+	return &withdrawalRepo{
+		db: db,
+	}
+}
+
+func (r *withdrawalRepo) CreatePayoutAccount(account *entity.PayoutAccount) error {
+	logger.Info("CreatePayoutAccount repository method called")
+	return r.db.Create(account).Error
+}
+
+func (r *withdrawalRepo) GetPayoutAccountByID(id uuid.UUID) (*entity.PayoutAccount, error) {
+	logger.Info("GetPayoutAccountByID repository method called")
+	var account entity.PayoutAccount
+	err := r.db.Where("id = ?", id).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *withdrawalRepo) GetPayoutAccountsByUser(userID uuid.UUID) ([]entity.PayoutAccount, error) {
+	logger.Info("GetPayoutAccountsByUser repository method called")
+	var accounts []entity.PayoutAccount
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&accounts).Error
+	return accounts, err
+}
+
+// DeletePayoutAccount removes an account of the user. Accounts that withdrawals were made to
+// are kept so the withdrawal history stays intact.
+func (r *withdrawalRepo) DeletePayoutAccount(id uuid.UUID, userID uuid.UUID) (bool, error) {
+	logger.Info("DeletePayoutAccount repository method called")
+	result := r.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		Where("NOT EXISTS (?)", r.db.Model(&entity.Withdrawal{}).
+			Select("1").
+			Where("payout_account_id = ?", id)).
+		Delete(&entity.PayoutAccount{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CreateWithdrawal moves the amount from the wallet balance to the held balance and records
+// the withdrawal with its pending transaction in one database transaction
+func (r *withdrawalRepo) CreateWithdrawal(withdrawal *entity.Withdrawal, transaction *entity.Transaction) error {
+	logger.Info("CreateWithdrawal repository method called")
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Wallet{}).
+			Where("user_id = ? AND balance >= ?", withdrawal.UserID, withdrawal.Amount).
+			Updates(map[string]interface{}{
+				"balance":      gorm.Expr("balance - ?", withdrawal.Amount),
+				"held_balance": gorm.Expr("held_balance + ?", withdrawal.Amount),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsufficientBalance
+		}
+
+		if err := tx.Create(withdrawal).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+		return appendWithdrawalEvent(tx, withdrawal.ID, entity.WithdrawalRequested, withdrawal.UserID, "", withdrawal.CreatedAt)
+	})
+}
+
+func (r *withdrawalRepo) GetWithdrawalByID(id uuid.UUID) (*entity.Withdrawal, error) {
+	logger.Info("GetWithdrawalByID repository method called")
+	var withdrawal entity.Withdrawal
+	err := r.db.Where("id = ?", id).First(&withdrawal).Error
+	if err != nil {
+		return nil, err
+	}
+	return &withdrawal, nil
+}
+
+func (r *withdrawalRepo) GetWithdrawalsByUser(userID uuid.UUID) ([]entity.Withdrawal, error) {
+	logger.Info("GetWithdrawalsByUser repository method called")
+	var withdrawals []entity.Withdrawal
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&withdrawals).Error
+	return withdrawals, err
+}
+
+// GetWithdrawals returns all withdrawals, optionally filtered by status, oldest first
+func (r *withdrawalRepo) GetWithdrawals(status string) ([]entity.Withdrawal, error) {
+	logger.Info("GetWithdrawals repository method called")
+	var withdrawals []entity.Withdrawal
+	query := r.db.Model(&entity.Withdrawal{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at").Find(&withdrawals).Error
+	return withdrawals, err
+}
+
+// ApproveWithdrawal moves a requested withdrawal to approved
+func (r *withdrawalRepo) ApproveWithdrawal(id uuid.UUID, adminID uuid.UUID, processedAt time.Time) (bool, error) {
+	logger.Info("ApproveWithdrawal repository method called")
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Withdrawal{}).
+			Where("id = ? AND status = ?", id, entity.WithdrawalRequested).
+			Updates(map[string]interface{}{
+				"status":       entity.WithdrawalApproved,
+				"processed_by": adminID,
+				"processed_at": processedAt,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := appendWithdrawalEvent(tx, id, entity.WithdrawalApproved, adminID, "", processedAt); err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// MarkWithdrawalPaid settles an approved withdrawal: the held amount leaves the wallet
+// and the pending transaction is completed
+func (r *withdrawalRepo) MarkWithdrawalPaid(id uuid.UUID, adminID uuid.UUID, paidAt time.Time) (bool, error) {
+	logger.Info("MarkWithdrawalPaid repository method called")
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		withdrawal, err := lockWithdrawal(tx, id, entity.WithdrawalApproved)
+		if err != nil || withdrawal == nil {
+			return err
+		}
+
+		if err := tx.Model(withdrawal).Updates(map[string]interface{}{
+			"status":       entity.WithdrawalPaid,
+			"processed_by": adminID,
+			"paid_at":      paidAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Wallet{}).
+			Where("user_id = ?", withdrawal.UserID).
+			Update("held_balance", gorm.Expr("held_balance - ?", withdrawal.Amount)).Error; err != nil {
+			return err
+		}
+		if err := updateWithdrawalTransaction(tx, id, "completed", paidAt); err != nil {
+			return err
+		}
+		if err := appendWithdrawalEvent(tx, id, entity.WithdrawalPaid, adminID, "", paidAt); err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// RejectWithdrawal returns the held amount of a requested or approved withdrawal to the
+// wallet balance and cancels the pending transaction
+func (r *withdrawalRepo) RejectWithdrawal(id uuid.UUID, adminID uuid.UUID, reason string, processedAt time.Time) (bool, error) {
+	logger.Info("RejectWithdrawal repository method called")
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		withdrawal, err := lockWithdrawal(tx, id, entity.WithdrawalRequested, entity.WithdrawalApproved)
+		if err != nil || withdrawal == nil {
+			return err
+		}
+
+		if err := tx.Model(withdrawal).Updates(map[string]interface{}{
+			"status":        entity.WithdrawalRejected,
+			"reject_reason": reason,
+			"processed_by":  adminID,
+			"processed_at":  processedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Wallet{}).
+			Where("user_id = ?", withdrawal.UserID).
+			Updates(map[string]interface{}{
+				"balance":      gorm.Expr("balance + ?", withdrawal.Amount),
+				"held_balance": gorm.Expr("held_balance - ?", withdrawal.Amount),
+			}).Error; err != nil {
+			return err
+		}
+		if err := updateWithdrawalTransaction(tx, id, "cancelled", processedAt); err != nil {
+			return err
+		}
+		if err := appendWithdrawalEvent(tx, id, entity.WithdrawalRejected, adminID, reason, processedAt); err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// GetWithdrawalEvents returns the status history of a withdrawal, oldest first
+func (r *withdrawalRepo) GetWithdrawalEvents(withdrawalID uuid.UUID) ([]entity.WithdrawalEvent, error) {
+	logger.Info("GetWithdrawalEvents repository method called")
+	var events []entity.WithdrawalEvent
+	err := r.db.Where("withdrawal_id = ?", withdrawalID).Order("created_at").Find(&events).Error
+	return events, err
+}
+
+// lockWithdrawal locks a withdrawal for update if it is in one of the given statuses.
+// It returns nil without an error when the withdrawal is in another status.
+func lockWithdrawal(tx *gorm.DB, id uuid.UUID, statuses ...string) (*entity.Withdrawal, error) {
+	var withdrawal entity.Withdrawal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status IN ?", id, statuses).
+		First(&withdrawal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &withdrawal, nil
+}
+
+func updateWithdrawalTransaction(tx *gorm.DB, withdrawalID uuid.UUID, status string, at time.Time) error {
+	return tx.Model(&entity.Transaction{}).
+		Where("service_id = ? AND service_ref_id = ?", entity.ServiceWithdrawal, withdrawalID).
+		Updates(map[string]interface{}{
+			"status":  status,
+			"paid_at": at,
+		}).Error
+}
+
+// appendWithdrawalEvent records a status change of a withdrawal in its history
+func appendWithdrawalEvent(tx *gorm.DB, withdrawalID uuid.UUID, status string, actorID uuid.UUID, note string, at time.Time) error {
+	return tx.Create(&entity.WithdrawalEvent{
+		ID:           uuid.New(),
+		WithdrawalID: withdrawalID,
+		Status:       status,
+		ActorID:      &actorID,
+		Note:         note,
+		CreatedAt:    at,
+	}).Error
+}
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/model/response"
@@ -20,29 +22,45 @@ type IBookingUsecase interface {
 	GetCustomerBookings(ctx context.Context, customerID uuid.UUID) ([]response.BookingResponse, error)
 	CancelBooking(ctx context.Context, customerID uuid.UUID, bookingID uuid.UUID) error
 	GetRoomBookings(ctx context.Context, roomID uuid.UUID) ([]response.BookingResponse, error)
+	CompleteBooking(ctx context.Context, ownerID uuid.UUID, bookingID uuid.UUID) error
 }
 
 type bookingUsecase struct {
 	bookingRepo     repository.IBookingRepo
 	meetingRoomRepo repository.IMeetingRoomRepo
+	coffeeShopRepo  repository.ICoffeeShopRepo
 	walletRepo      repository.IWalletRepo
 	voucherRepo     repository.IVoucherRepo
 	transactionRepo repository.ITransactionRepo
+	referralUsecase IReferralUsecase
+	loyaltyUsecase  ILoyaltyUsecase
+	passUsecase     IPassUsecase
+	staffRepo       repository.IStaffRepo
 }
 
 func NewBookingUsecase(
 	bookingRepo repository.IBookingRepo,
 	meetingRoomRepo repository.IMeetingRoomRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
 	walletRepo repository.IWalletRepo,
 	voucherRepo repository.IVoucherRepo,
 	transactionRepo repository.ITransactionRepo,
+	referralUsecase IReferralUsecase,
+	loyaltyUsecase ILoyaltyUsecase,
+	passUsecase IPassUsecase,
+	staffRepo repository.IStaffRepo,
 ) IBookingUsecase {
 	return &bookingUsecase{
 		bookingRepo:     bookingRepo,
 		meetingRoomRepo: meetingRoomRepo,
+		coffeeShopRepo:  coffeeShopRepo,
 		walletRepo:      walletRepo,
 		voucherRepo:     voucherRepo,
 		transactionRepo: transactionRepo,
+		referralUsecase: referralUsecase,
+		loyaltyUsecase:  loyaltyUsecase,
+		passUsecase:     passUsecase,
+		staffRepo:       staffRepo,
 	}
 }
 
@@ -72,6 +90,14 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, customerID uuid.UUID
 		return nil, errors.New("meeting room is not available")
 	}
 
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(room.CoffeeShopID)
+	if err != nil {
+		return nil, err
+	}
+	if shop.Status != entity.ShopApproved {
+		return nil, errors.New("coffee shop is not accepting bookings yet")
+	}
+
 	// Check availability
 	available, err := u.bookingRepo.CheckRoomAvailability(req.MeetingRoomID, req.StartTime, req.EndTime)
 	if err != nil {
@@ -82,13 +108,22 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, customerID uuid.UUID
 	}
 
 	// Calculate price
-	duration := req.EndTime.Sub(req.StartTime).Hours()
-	totalPrice := room.PricePerHour * duration
+	totalPrice := calculateBookingPrice(room, req.StartTime, req.EndTime)
+
+	// Bookings paid with a pass are not charged to the wallet
+	usePass := req.PassID != uuid.Nil
+	if usePass {
+		if req.VoucherCode != "" || req.RedeemPoints > 0 {
+			return nil, errors.New("a pass cannot be combined with a voucher or points")
+		}
+		totalPrice = 0
+	}
 
 	// Apply voucher if provided
-	var voucherID *uuid.UUID
+	var voucher *entity.Voucher
+	var discount float64
 	if req.VoucherCode != "" {
-		voucher, err := u.voucherRepo.GetVoucherByCode(req.VoucherCode)
+		voucher, err = u.voucherRepo.GetVoucherByCode(req.VoucherCode)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, errors.New("invalid voucher code")
@@ -97,23 +132,33 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, customerID uuid.UUID
 		}
 
 		// Validate voucher
-		now := time.Now()
-		if now.Before(voucher.ValidFrom) || now.After(voucher.ValidTo) {
-			return nil, errors.New("voucher is not valid at this time")
+		if err := checkVoucherUsable(voucher, entity.ServiceBooking); err != nil {
+			return nil, err
+		}
+		if err := checkVoucherScope(u.voucherRepo, voucher, room); err != nil {
+			return nil, err
 		}
-		if voucher.MaxUses > 0 && voucher.UsedCount >= voucher.MaxUses {
-			return nil, errors.New("voucher has reached maximum uses")
+		if err := checkVoucherEligibility(u.voucherRepo, u.transactionRepo, voucher, customerID); err != nil {
+			return nil, err
 		}
 
 		// Apply discount
-		discount := totalPrice * float64(voucher.DiscountPercent) / 100
-		totalPrice -= discount
-		voucherID = &voucher.ID
+		calculation, err := calculateVoucherDiscount(voucher, totalPrice)
+		if err != nil {
+			return nil, err
+		}
+		discount = calculation.DiscountAmount
+		totalPrice = calculation.FinalAmount
+	}
 
-		// Increment voucher usage
-		if err := u.voucherRepo.IncrementUsedCount(voucher.ID); err != nil {
-			log.Errorw("Failed to increment voucher usage", "error", err)
+	// Redeem loyalty points on what is left after the voucher
+	var pointsDiscount float64
+	if req.RedeemPoints > 0 {
+		pointsDiscount, err = u.loyaltyUsecase.QuoteRedemption(ctx, customerID, req.RedeemPoints, totalPrice)
+		if err != nil {
+			return nil, err
 		}
+		totalPrice = mathutil.RoundToFloat(totalPrice-pointsDiscount, 2)
 	}
 
 	// Check wallet balance
@@ -127,54 +172,102 @@ func (u *bookingUsecase) CreateBooking(ctx context.Context, customerID uuid.UUID
 
 	// Create booking
 	booking := &entity.Booking{
-		ID:            uuid.New(),
-		CustomerID:    customerID,
-		MeetingRoomID: req.MeetingRoomID,
-		StartTime:     req.StartTime,
-		EndTime:       req.EndTime,
-		TotalPrice:    totalPrice,
-		Status:        "booked",
-		CreatedAt:     time.Now(),
-	}
-	if voucherID != nil {
-		booking.VoucherID = *voucherID
+		ID:             uuid.New(),
+		CustomerID:     customerID,
+		MeetingRoomID:  req.MeetingRoomID,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		TotalPrice:     totalPrice,
+		DiscountAmount: discount,
+		PointsRedeemed: req.RedeemPoints,
+		PointsDiscount: pointsDiscount,
+		Status:         "booked",
+		CreatedAt:      time.Now(),
 	}
 
-	if err := u.bookingRepo.CreateBooking(booking); err != nil {
-		return nil, err
+	// Reserve the voucher before payment; the redemption is released if the booking fails
+	if voucher != nil {
+		booking.VoucherID = voucher.ID
+		redeemed, err := u.voucherRepo.RedeemVoucher(&entity.VoucherRedemption{
+			ID:             uuid.New(),
+			VoucherID:      voucher.ID,
+			UserID:         customerID,
+			ServiceID:      entity.ServiceBooking,
+			ServiceRefID:   booking.ID,
+			DiscountAmount: discount,
+			Status:         entity.RedemptionRedeemed,
+			RedeemedAt:     time.Now(),
+		}, voucher.PerUserLimit)
+		if err != nil {
+			return nil, err
+		}
+		if !redeemed {
+			return nil, errors.New("voucher is no longer available")
+		}
 	}
 
-	// Deduct from wallet
-	if err := u.walletRepo.DeductBalance(customerID, totalPrice); err != nil {
-		log.Errorw("Failed to deduct balance", "error", err)
-		return nil, errors.New("payment failed")
+	if booking.PointsRedeemed > 0 {
+		if err := u.loyaltyUsecase.RedeemPoints(ctx, customerID, booking.PointsRedeemed, entity.ServiceBooking, booking.ID); err != nil {
+			u.releaseVoucher(ctx, booking)
+			return nil, err
+		}
 	}
 
-	// Create transaction record
-	transaction := &entity.Transaction{
-		ID:           uuid.New(),
-		UserID:       customerID,
-		ServiceID:    1, // 1 for booking
-		ServiceRefID: booking.ID,
-		Amount:       totalPrice,
-		PaidAt:       time.Now(),
-		Status:       "completed",
+	if usePass {
+		// The pass usage references the booking, so both are created together
+		booking.UserPassID = req.PassID
+		err = u.passUsecase.ChargeBooking(ctx, customerID, req.PassID, room, booking)
+	} else {
+		err = u.bookingRepo.CreateBooking(booking)
 	}
-	if err := u.transactionRepo.CreateTransaction(transaction); err != nil {
-		log.Errorw("Failed to create transaction", "error", err)
+	if err != nil {
+		u.releaseVoucher(ctx, booking)
+		u.refundPoints(ctx, booking)
+		return nil, err
+	}
+
+	if !usePass {
+		// Deduct from wallet
+		if err := u.walletRepo.DeductBalance(customerID, totalPrice); err != nil {
+			log.Errorw("Failed to deduct balance", "error", err)
+			if _, err := u.bookingRepo.CancelBooking(booking.ID, nil); err != nil {
+				log.Errorw("Failed to cancel unpaid booking", "error", err)
+			}
+			u.releaseVoucher(ctx, booking)
+			u.refundPoints(ctx, booking)
+			return nil, errors.New("payment failed")
+		}
+
+		// Create transaction record
+		transaction := &entity.Transaction{
+			ID:           uuid.New(),
+			UserID:       customerID,
+			ServiceID:    entity.ServiceBooking,
+			ServiceRefID: booking.ID,
+			Amount:       totalPrice,
+			PaidAt:       time.Now(),
+			Status:       "completed",
+		}
+		if err := u.transactionRepo.CreateTransaction(transaction); err != nil {
+			log.Errorw("Failed to create transaction", "error", err)
+		}
 	}
 
 	return &response.BookingResponse{
-		ID:            booking.ID,
-		CustomerID:    booking.CustomerID,
-		MeetingRoomID: booking.MeetingRoomID,
-		RoomName:      room.Name,
-		StartTime:     booking.StartTime,
-		EndTime:       booking.EndTime,
-		TotalPrice:    booking.TotalPrice,
-		VoucherID:     booking.VoucherID,
-		Status:        booking.Status,
-		CreatedAt:     booking.CreatedAt,
+		ID:             booking.ID,
+		CustomerID:     booking.CustomerID,
+		MeetingRoomID:  booking.MeetingRoomID,
+		RoomName:       room.Name,
+		StartTime:      booking.StartTime,
+		EndTime:        booking.EndTime,
+		TotalPrice:     booking.TotalPrice,
+		DiscountAmount: booking.DiscountAmount,
+		VoucherID:      booking.VoucherID,
+		PointsRedeemed: booking.PointsRedeemed,
+		PointsDiscount: booking.PointsDiscount,
+		PassID:         booking.UserPassID,
+		Status:         booking.Status,
+		CreatedAt:      booking.CreatedAt,
 	}, nil
 }
 
@@ -194,16 +287,20 @@ func (u *bookingUsecase) GetBooking(ctx context.Context, bookingID uuid.UUID) (*
 	}
 
 	return &response.BookingResponse{
-		ID:            booking.ID,
-		CustomerID:    booking.CustomerID,
-		MeetingRoomID: booking.MeetingRoomID,
-		RoomName:      roomName,
-		StartTime:     booking.StartTime,
-		EndTime:       booking.EndTime,
-		TotalPrice:    booking.TotalPrice,
-		VoucherID:     booking.VoucherID,
-		Status:        booking.Status,
-		CreatedAt:     booking.CreatedAt,
+		ID:             booking.ID,
+		CustomerID:     booking.CustomerID,
+		MeetingRoomID:  booking.MeetingRoomID,
+		RoomName:       roomName,
+		StartTime:      booking.StartTime,
+		EndTime:        booking.EndTime,
+		TotalPrice:     booking.TotalPrice,
+		DiscountAmount: booking.DiscountAmount,
+		VoucherID:      booking.VoucherID,
+		PointsRedeemed: booking.PointsRedeemed,
+		PointsDiscount: booking.PointsDiscount,
+		PassID:         booking.UserPassID,
+		Status:         booking.Status,
+		CreatedAt:      booking.CreatedAt,
 	}, nil
 }
 
@@ -222,16 +319,20 @@ func (u *bookingUsecase) GetCustomerBookings(ctx context.Context, customerID uui
 		}
 
 		result = append(result, response.BookingResponse{
-			ID:            booking.ID,
-			CustomerID:    booking.CustomerID,
-			MeetingRoomID: booking.MeetingRoomID,
-			RoomName:      roomName,
-			StartTime:     booking.StartTime,
-			EndTime:       booking.EndTime,
-			TotalPrice:    booking.TotalPrice,
-			VoucherID:     booking.VoucherID,
-			Status:        booking.Status,
-			CreatedAt:     booking.CreatedAt,
+			ID:             booking.ID,
+			CustomerID:     booking.CustomerID,
+			MeetingRoomID:  booking.MeetingRoomID,
+			RoomName:       roomName,
+			StartTime:      booking.StartTime,
+			EndTime:        booking.EndTime,
+			TotalPrice:     booking.TotalPrice,
+			DiscountAmount: booking.DiscountAmount,
+			VoucherID:      booking.VoucherID,
+			PointsRedeemed: booking.PointsRedeemed,
+			PointsDiscount: booking.PointsDiscount,
+			PassID:         booking.UserPassID,
+			Status:         booking.Status,
+			CreatedAt:      booking.CreatedAt,
 		})
 	}
 
@@ -256,37 +357,48 @@ func (u *bookingUsecase) CancelBooking(ctx context.Context, customerID uuid.UUID
 	if booking.Status == "cancelled" {
 		return errors.New("booking is already cancelled")
 	}
-
-	// Only allow cancellation if booking is at least 24 hours away
-	if time.Until(booking.StartTime) < 24*time.Hour {
-		return errors.New("cannot cancel booking less than 24 hours before start time")
+	if booking.Status == "completed" {
+		return errors.New("cannot cancel a completed booking")
 	}
 
-	// Cancel booking
-	if err := u.bookingRepo.CancelBooking(bookingID); err != nil {
+	// Higher membership tiers can cancel closer to the start time
+	window, err := u.loyaltyUsecase.CancellationWindow(ctx, customerID)
+	if err != nil {
 		return err
 	}
+	if time.Until(booking.StartTime) < window {
+		return fmt.Errorf("cannot cancel booking less than %d hours before start time", int(window.Hours()))
+	}
 
-	// Refund to wallet
-	if err := u.walletRepo.UpdateBalance(customerID, booking.TotalPrice); err != nil {
-		log.Errorw("Failed to refund balance", "error", err)
-		return errors.New("failed to process refund")
+	// Pass bookings get their hours back instead of a wallet refund
+	var refund *entity.Transaction
+	if booking.UserPassID == uuid.Nil {
+		refund = &entity.Transaction{
+			ID:           uuid.New(),
+			UserID:       customerID,
+			ServiceID:    entity.ServiceBooking,
+			ServiceRefID: booking.ID,
+			Amount:       -booking.TotalPrice, // Negative for refund
+			PaidAt:       time.Now(),
+			Status:       "refunded",
+		}
 	}
 
-	// Create refund transaction
-	transaction := &entity.Transaction{
-		ID:           uuid.New(),
-		UserID:       customerID,
-		ServiceID:    1,
-		ServiceRefID: booking.ID,
-		Amount:       -booking.TotalPrice, // Negative for refund
-		PaidAt:       time.Now(),
-		Status:       "refunded",
+	// The booking is only cancelled, and refunded, if it is still booked, so a cancel racing
+	// the owner completing it cannot pay out twice
+	cancelled, err := u.bookingRepo.CancelBooking(bookingID, refund)
+	if err != nil {
+		log.Errorw("Failed to cancel booking", "error", err)
+		return errors.New("failed to process refund")
 	}
-	if err := u.transactionRepo.CreateTransaction(transaction); err != nil {
-		log.Errorw("Failed to create refund transaction", "error", err)
+	if !cancelled {
+		return errors.New("booking can no longer be cancelled")
 	}
 
+	u.releaseVoucher(ctx, booking)
+	u.refundPoints(ctx, booking)
+	u.restorePass(ctx, booking)
+
 	return nil
 }
 
@@ -299,17 +411,134 @@ func (u *bookingUsecase) GetRoomBookings(ctx context.Context, roomID uuid.UUID)
 	var result []response.BookingResponse
 	for _, booking := range bookings {
 		result = append(result, response.BookingResponse{
-			ID:            booking.ID,
-			CustomerID:    booking.CustomerID,
-			MeetingRoomID: booking.MeetingRoomID,
-			StartTime:     booking.StartTime,
-			EndTime:       booking.EndTime,
-			TotalPrice:    booking.TotalPrice,
-			VoucherID:     booking.VoucherID,
-			Status:        booking.Status,
-			CreatedAt:     booking.CreatedAt,
+			ID:             booking.ID,
+			CustomerID:     booking.CustomerID,
+			MeetingRoomID:  booking.MeetingRoomID,
+			StartTime:      booking.StartTime,
+			EndTime:        booking.EndTime,
+			TotalPrice:     booking.TotalPrice,
+			DiscountAmount: booking.DiscountAmount,
+			VoucherID:      booking.VoucherID,
+			PointsRedeemed: booking.PointsRedeemed,
+			PointsDiscount: booking.PointsDiscount,
+			PassID:         booking.UserPassID,
+			Status:         booking.Status,
+			CreatedAt:      booking.CreatedAt,
 		})
 	}
 
 	return result, nil
 }
+
+func (u *bookingUsecase) CompleteBooking(ctx context.Context, ownerID uuid.UUID, bookingID uuid.UUID) error {
+	log := logger.EnhanceWith(ctx)
+	log.Info("CompleteBooking usecase called")
+
+	booking, err := u.bookingRepo.GetBookingByID(bookingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("booking not found")
+		}
+		return err
+	}
+
+	// Verify ownership
+	room, err := u.meetingRoomRepo.GetMeetingRoomByID(booking.MeetingRoomID)
+	if err != nil {
+		return err
+	}
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(room.CoffeeShopID)
+	if err != nil {
+		return err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageBookings)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New("unauthorized to complete this booking")
+	}
+
+	if booking.Status != "booked" {
+		return errors.New("only booked bookings can be completed")
+	}
+	if time.Now().Before(booking.StartTime) {
+		return errors.New("cannot complete a booking before it starts")
+	}
+
+	// Snapshot the commission rate in effect now
+	ratePercent, err := currentCommissionRate(u.coffeeShopRepo, shop.ID)
+	if err != nil {
+		return err
+	}
+
+	// Shop vouchers are paid for by the owner, platform vouchers by the platform
+	fundedBy := entity.DiscountFundedByPlatform
+	if booking.VoucherID != uuid.Nil {
+		voucher, err := u.voucherRepo.GetVoucherByID(booking.VoucherID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if voucher != nil && voucher.CoffeeShopID != nil {
+			fundedBy = entity.DiscountFundedByShop
+		}
+	}
+
+	// Pass bookings were earned when the pass was sold
+	var earning *entity.Earning
+	if booking.UserPassID == uuid.Nil {
+		// Points are redeemed at the platform's cost, so the owner is paid as if they were cash
+		paidAmount := mathutil.RoundToFloat(booking.TotalPrice+booking.PointsDiscount, 2)
+		earning = newEarning(shop, ratePercent, entity.ServiceBooking, booking.ID, paidAmount, booking.DiscountAmount, fundedBy)
+	}
+
+	completed, err := u.bookingRepo.CompleteBooking(bookingID, earning)
+	if err != nil {
+		log.Errorw("Failed to complete booking", "error", err)
+		return errors.New("failed to complete booking")
+	}
+	if !completed {
+		return errors.New("only booked bookings can be completed")
+	}
+
+	// A completed booking can no longer be refunded, so it may unlock a referral reward
+	if err := u.referralUsecase.RewardReferral(ctx, booking.CustomerID, entity.ServiceBooking, booking.ID, booking.TotalPrice); err != nil {
+		log.Errorw("Failed to reward referral", "error", err)
+	}
+
+	if err := u.loyaltyUsecase.AwardPoints(ctx, booking.CustomerID, entity.ServiceBooking, booking.ID, booking.TotalPrice); err != nil {
+		log.Errorw("Failed to award loyalty points", "error", err)
+	}
+
+	return nil
+}
+
+// releaseVoucher gives a booking's voucher redemption back so it no longer counts against the limits
+func (u *bookingUsecase) releaseVoucher(ctx context.Context, booking *entity.Booking) {
+	if booking.VoucherID == uuid.Nil {
+		return
+	}
+	if _, err := u.voucherRepo.ReleaseRedemption(entity.ServiceBooking, booking.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to release voucher redemption", "error", err)
+	}
+}
+
+// refundPoints gives back the loyalty points redeemed on a booking
+func (u *bookingUsecase) refundPoints(ctx context.Context, booking *entity.Booking) {
+	if booking.PointsRedeemed == 0 {
+		return
+	}
+	if err := u.loyaltyUsecase.RefundPoints(ctx, entity.ServiceBooking, booking.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to refund loyalty points", "error", err)
+	}
+}
+
+// restorePass gives the hours of a booking back to the pass it was paid with
+func (u *bookingUsecase) restorePass(ctx context.Context, booking *entity.Booking) {
+	if booking.UserPassID == uuid.Nil {
+		return
+	}
+	if err := u.passUsecase.RestoreBooking(ctx, booking.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to restore pass hours", "error", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/pkg/utils/timeutils"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/model/response"
@@ -19,7 +21,8 @@ type ICoffeeShopUsecase interface {
 	GetCoffeeShop(ctx context.Context, shopID uuid.UUID) (*response.CoffeeShopResponse, error)
 	GetCoffeeShopsByOwner(ctx context.Context, ownerID uuid.UUID) ([]response.CoffeeShopResponse, error)
 	GetAllCoffeeShops(ctx context.Context) ([]response.CoffeeShopResponse, error)
-	UpdateCoffeeShop(ctx context.Context, ownerID uuid.UUID, req request.UpdateCoffeeShop) error
+	GetNearbyCoffeeShops(ctx context.Context, req request.NearbyCoffeeShops) ([]response.NearbyCoffeeShopResponse, error)
+	UpdateCoffeeShop(ctx context.Context, userID uuid.UUID, req request.UpdateCoffeeShop) error
 	DeleteCoffeeShop(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) error
 
 	SetCommissionRate(ctx context.Context, req request.SetCommissionRate) error
@@ -28,11 +31,28 @@ type ICoffeeShopUsecase interface {
 
 type coffeeShopUsecase struct {
 	coffeeShopRepo repository.ICoffeeShopRepo
+	reviewRepo     repository.IReviewRepo
+	followRepo     repository.IFollowRepo
+	mediaRepo      repository.IMediaRepo
+	staffRepo      repository.IStaffRepo
+	settlementRepo repository.ISettlementRepo
 }
 
-func NewCoffeeShopUsecase(coffeeShopRepo repository.ICoffeeShopRepo) ICoffeeShopUsecase {
+func NewCoffeeShopUsecase(
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	reviewRepo repository.IReviewRepo,
+	followRepo repository.IFollowRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
+	settlementRepo repository.ISettlementRepo,
+) ICoffeeShopUsecase {
 	return &coffeeShopUsecase{
 		coffeeShopRepo: coffeeShopRepo,
+		reviewRepo:     reviewRepo,
+		followRepo:     followRepo,
+		mediaRepo:      mediaRepo,
+		staffRepo:      staffRepo,
+		settlementRepo: settlementRepo,
 	}
 }
 
@@ -45,6 +65,11 @@ func (u *coffeeShopUsecase) CreateCoffeeShop(ctx context.Context, ownerID uuid.U
 		Name:        req.Name,
 		Location:    req.Location,
 		Description: req.Description,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		OpenTime:    req.OpenTime,
+		CloseTime:   req.CloseTime,
+		Status:      entity.ShopPending,
 		CreatedAt:   time.Now(),
 	}
 
@@ -52,14 +77,7 @@ func (u *coffeeShopUsecase) CreateCoffeeShop(ctx context.Context, ownerID uuid.U
 		return nil, err
 	}
 
-	return &response.CoffeeShopResponse{
-		ID:          shop.ID,
-		OwnerID:     shop.OwnerID,
-		Name:        shop.Name,
-		Location:    shop.Location,
-		Description: shop.Description,
-		CreatedAt:   shop.CreatedAt,
-	}, nil
+	return toCoffeeShopResponse(shop), nil
 }
 
 func (u *coffeeShopUsecase) GetCoffeeShop(ctx context.Context, shopID uuid.UUID) (*response.CoffeeShopResponse, error) {
@@ -70,15 +88,23 @@ func (u *coffeeShopUsecase) GetCoffeeShop(ctx context.Context, shopID uuid.UUID)
 		}
 		return nil, err
 	}
+	// Shops are hidden from the public until an admin approves them
+	if shop.Status != entity.ShopApproved {
+		return nil, errors.New("coffee shop not found")
+	}
 
-	return &response.CoffeeShopResponse{
-		ID:          shop.ID,
-		OwnerID:     shop.OwnerID,
-		Name:        shop.Name,
-		Location:    shop.Location,
-		Description: shop.Description,
-		CreatedAt:   shop.CreatedAt,
-	}, nil
+	result := []response.CoffeeShopResponse{*toCoffeeShopResponse(shop)}
+	if err := u.attachRatings(result); err != nil {
+		return nil, err
+	}
+
+	media, err := u.mediaRepo.GetMediaByOwner(entity.MediaOwnerCoffeeShop, shop.ID)
+	if err != nil {
+		return nil, err
+	}
+	result[0].Media = toMediaResponses(media)
+
+	return &result[0], nil
 }
 
 func (u *coffeeShopUsecase) GetCoffeeShopsByOwner(ctx context.Context, ownerID uuid.UUID) ([]response.CoffeeShopResponse, error) {
@@ -88,42 +114,95 @@ func (u *coffeeShopUsecase) GetCoffeeShopsByOwner(ctx context.Context, ownerID u
 	}
 
 	var result []response.CoffeeShopResponse
+	shopIDs := make([]uuid.UUID, 0, len(shops))
 	for _, shop := range shops {
-		result = append(result, response.CoffeeShopResponse{
-			ID:          shop.ID,
-			OwnerID:     shop.OwnerID,
-			Name:        shop.Name,
-			Location:    shop.Location,
-			Description: shop.Description,
-			CreatedAt:   shop.CreatedAt,
-		})
+		result = append(result, *toCoffeeShopResponse(&shop))
+		shopIDs = append(shopIDs, shop.ID)
+	}
+	if err := u.attachRatings(result); err != nil {
+		return nil, err
+	}
+
+	followers, err := u.followRepo.CountFollowers(shopIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		count := followers[result[i].ID]
+		result[i].FollowerCount = &count
 	}
 
 	return result, nil
 }
 
 func (u *coffeeShopUsecase) GetAllCoffeeShops(ctx context.Context) ([]response.CoffeeShopResponse, error) {
-	shops, err := u.coffeeShopRepo.GetAllCoffeeShops()
+	shops, err := u.coffeeShopRepo.GetApprovedCoffeeShops()
 	if err != nil {
 		return nil, err
 	}
 
 	var result []response.CoffeeShopResponse
 	for _, shop := range shops {
-		result = append(result, response.CoffeeShopResponse{
-			ID:          shop.ID,
-			OwnerID:     shop.OwnerID,
-			Name:        shop.Name,
-			Location:    shop.Location,
-			Description: shop.Description,
-			CreatedAt:   shop.CreatedAt,
+		result = append(result, *toCoffeeShopResponse(&shop))
+	}
+	if err := u.attachRatings(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetNearbyCoffeeShops lists shops within the radius of a point, closest first
+func (u *coffeeShopUsecase) GetNearbyCoffeeShops(ctx context.Context, req request.NearbyCoffeeShops) ([]response.NearbyCoffeeShopResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetNearbyCoffeeShops usecase called")
+
+	filter := repository.NearbyShopFilter{
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		RadiusKm:  req.Radius,
+		Limit:     req.Limit,
+	}
+	if filter.RadiusKm == 0 {
+		filter.RadiusKm = 5
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 20
+	}
+
+	now := time.Now()
+	if req.OpenNow {
+		filter.OpenAt = timeutils.TimeInGMT07String(now, "15:04")
+	}
+	if req.RoomsAvailable {
+		filter.FreeAt = &now
+	}
+
+	shops, err := u.coffeeShopRepo.FindNearbyCoffeeShops(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	shopResponses := make([]response.CoffeeShopResponse, 0, len(shops))
+	for _, shop := range shops {
+		shopResponses = append(shopResponses, *toCoffeeShopResponse(&shop.CoffeeShop))
+	}
+	if err := u.attachRatings(shopResponses); err != nil {
+		return nil, err
+	}
+
+	result := make([]response.NearbyCoffeeShopResponse, 0, len(shops))
+	for i, shop := range shops {
+		result = append(result, response.NearbyCoffeeShopResponse{
+			CoffeeShopResponse: shopResponses[i],
+			DistanceKm:         mathutil.RoundToFloat(shop.DistanceKm, 2),
 		})
 	}
 
 	return result, nil
 }
 
-func (u *coffeeShopUsecase) UpdateCoffeeShop(ctx context.Context, ownerID uuid.UUID, req request.UpdateCoffeeShop) error {
+// UpdateCoffeeShop changes the shop details; the owner and staff with the manage_shop permission may do this
+func (u *coffeeShopUsecase) UpdateCoffeeShop(ctx context.Context, userID uuid.UUID, req request.UpdateCoffeeShop) error {
 	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(req.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -132,7 +211,11 @@ func (u *coffeeShopUsecase) UpdateCoffeeShop(ctx context.Context, ownerID uuid.U
 		return err
 	}
 
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, userID, entity.PermissionManageShop)
+	if err != nil {
+		return err
+	}
+	if !allowed {
 		return errors.New("unauthorized to update this coffee shop")
 	}
 
@@ -145,6 +228,14 @@ func (u *coffeeShopUsecase) UpdateCoffeeShop(ctx context.Context, ownerID uuid.U
 	if req.Description != "" {
 		shop.Description = req.Description
 	}
+	if req.Latitude != nil {
+		shop.Latitude = req.Latitude
+		shop.Longitude = req.Longitude
+	}
+	if req.OpenTime != "" {
+		shop.OpenTime = req.OpenTime
+		shop.CloseTime = req.CloseTime
+	}
 
 	return u.coffeeShopRepo.UpdateCoffeeShop(shop)
 }
@@ -161,6 +252,14 @@ func (u *coffeeShopUsecase) DeleteCoffeeShop(ctx context.Context, ownerID uuid.U
 	if shop.OwnerID != ownerID {
 		return errors.New("unauthorized to delete this coffee shop")
 	}
+	// Earnings are what the shop is paid out from, so a shop that has earned anything stays
+	earnings, err := u.settlementRepo.CountEarningsByCoffeeShop(shopID)
+	if err != nil {
+		return err
+	}
+	if earnings > 0 {
+		return errors.New("coffee shop has earnings and cannot be deleted")
+	}
 
 	return u.coffeeShopRepo.DeleteCoffeeShop(shopID)
 }
@@ -213,3 +312,36 @@ func (u *coffeeShopUsecase) GetCommissionRate(ctx context.Context, shopID uuid.U
 		RatePercent:  rate.RatePercent,
 	}, nil
 }
+
+// attachRatings fills in the rating summary of the given shops
+func (u *coffeeShopUsecase) attachRatings(shops []response.CoffeeShopResponse) error {
+	shopIDs := make([]uuid.UUID, 0, len(shops))
+	for _, shop := range shops {
+		shopIDs = append(shopIDs, shop.ID)
+	}
+	ratings, err := u.reviewRepo.GetShopRatings(shopIDs)
+	if err != nil {
+		return err
+	}
+	for i := range shops {
+		shops[i].AverageRating = mathutil.RoundToFloat(ratings[shops[i].ID].AverageRating, 1)
+		shops[i].ReviewCount = ratings[shops[i].ID].ReviewCount
+	}
+	return nil
+}
+
+func toCoffeeShopResponse(shop *entity.CoffeeShop) *response.CoffeeShopResponse {
+	return &response.CoffeeShopResponse{
+		ID:          shop.ID,
+		OwnerID:     shop.OwnerID,
+		Name:        shop.Name,
+		Location:    shop.Location,
+		Description: shop.Description,
+		Latitude:    shop.Latitude,
+		Longitude:   shop.Longitude,
+		OpenTime:    shop.OpenTime,
+		CloseTime:   shop.CloseTime,
+		Status:      shop.Status,
+		CreatedAt:   shop.CreatedAt,
+	}
+}
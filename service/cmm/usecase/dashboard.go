@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+// maxDashboardDays bounds the range of a single dashboard query
+const maxDashboardDays = 366
+
+type IDashboardUsecase interface {
+	GetShopDashboard(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.DashboardQuery) (*response.ShopDashboardResponse, error)
+	GetShopOccupancy(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.DashboardQuery) (*response.ShopOccupancyResponse, error)
+}
+
+type dashboardUsecase struct {
+	analyticsRepo  repository.IAnalyticsRepo
+	coffeeShopRepo repository.ICoffeeShopRepo
+	staffRepo      repository.IStaffRepo
+}
+
+func NewDashboardUsecase(
+	analyticsRepo repository.IAnalyticsRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) IDashboardUsecase {
+	return &dashboardUsecase{
+		analyticsRepo:  analyticsRepo,
+		coffeeShopRepo: coffeeShopRepo,
+		staffRepo:      staffRepo,
+	}
+}
+
+func (u *dashboardUsecase) GetShopDashboard(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.DashboardQuery) (*response.ShopDashboardResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetShopDashboard usecase called")
+
+	if err := validateDashboardQuery(&req); err != nil {
+		return nil, err
+	}
+	if err := u.verifyShopOwner(ownerID, shopID); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.analyticsRepo.GetShopPeriodStats(shopID, req.From, req.To, req.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.ShopDashboardResponse{
+		CoffeeShopID: shopID,
+		From:         req.From,
+		To:           req.To,
+		Interval:     req.Interval,
+		Periods:      make([]response.DashboardPeriod, 0, len(stats)),
+	}
+
+	var totalHours float64
+	for _, stat := range stats {
+		period := response.DashboardPeriod{
+			Period:          stat.Period,
+			Revenue:         mathutil.RoundToFloat(stat.Revenue, 2),
+			Bookings:        stat.Bookings,
+			VoucherUses:     stat.VoucherUses,
+			VoucherDiscount: mathutil.RoundToFloat(stat.VoucherDiscount, 2),
+			CommissionPaid:  mathutil.RoundToFloat(stat.Commission, 2),
+			OwnerEarnings:   mathutil.RoundToFloat(stat.OwnerEarnings, 2),
+		}
+		if stat.Bookings > 0 {
+			period.AverageBookingHours = mathutil.RoundToFloat(stat.BookedHours/float64(stat.Bookings), 2)
+		}
+		result.Periods = append(result.Periods, period)
+
+		result.Totals.Revenue += stat.Revenue
+		result.Totals.Bookings += stat.Bookings
+		result.Totals.VoucherUses += stat.VoucherUses
+		result.Totals.VoucherDiscount += stat.VoucherDiscount
+		result.Totals.CommissionPaid += stat.Commission
+		result.Totals.OwnerEarnings += stat.OwnerEarnings
+		totalHours += stat.BookedHours
+	}
+
+	result.Totals.Period = req.From
+	result.Totals.Revenue = mathutil.RoundToFloat(result.Totals.Revenue, 2)
+	result.Totals.VoucherDiscount = mathutil.RoundToFloat(result.Totals.VoucherDiscount, 2)
+	result.Totals.CommissionPaid = mathutil.RoundToFloat(result.Totals.CommissionPaid, 2)
+	result.Totals.OwnerEarnings = mathutil.RoundToFloat(result.Totals.OwnerEarnings, 2)
+	if result.Totals.Bookings > 0 {
+		result.Totals.AverageBookingHours = mathutil.RoundToFloat(totalHours/float64(result.Totals.Bookings), 2)
+	}
+
+	return result, nil
+}
+
+func (u *dashboardUsecase) GetShopOccupancy(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.DashboardQuery) (*response.ShopOccupancyResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetShopOccupancy usecase called")
+
+	if err := validateDashboardQuery(&req); err != nil {
+		return nil, err
+	}
+	if err := u.verifyShopOwner(ownerID, shopID); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.analyticsRepo.GetShopRoomOccupancy(shopID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	availableHours := req.To.Sub(req.From).Hours()
+	result := &response.ShopOccupancyResponse{
+		CoffeeShopID:   shopID,
+		From:           req.From,
+		To:             req.To,
+		AvailableHours: availableHours,
+		Rooms:          make([]response.RoomOccupancyResponse, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		result.Rooms = append(result.Rooms, response.RoomOccupancyResponse{
+			MeetingRoomID: stat.MeetingRoomID,
+			RoomName:      stat.RoomName,
+			Bookings:      stat.Bookings,
+			BookedHours:   mathutil.RoundToFloat(stat.BookedHours, 2),
+			OccupancyRate: mathutil.Percent(stat.BookedHours, availableHours, 2),
+		})
+	}
+
+	return result, nil
+}
+
+func (u *dashboardUsecase) verifyShopOwner(ownerID uuid.UUID, shopID uuid.UUID) error {
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("coffee shop not found")
+		}
+		return err
+	}
+
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionViewReports)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New("unauthorized to view this coffee shop dashboard")
+	}
+	return nil
+}
+
+// validateDashboardQuery checks the range and defaults the interval to day
+func validateDashboardQuery(req *request.DashboardQuery) error {
+	if req.Interval == "" {
+		req.Interval = "day"
+	}
+	if req.Interval != "day" && req.Interval != "week" && req.Interval != "month" {
+		return errors.New("interval must be one of day, week or month")
+	}
+	if !req.To.After(req.From) {
+		return errors.New("to must be after from")
+	}
+	if req.To.Sub(req.From).Hours() > maxDashboardDays*24 {
+		return errors.New("date range is too large")
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IFloorZoneUsecase interface {
+	CreateFloorZone(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreateFloorZone) (*response.FloorZoneResponse, error)
+	GetFloorZones(ctx context.Context, shopID uuid.UUID) ([]response.FloorZoneResponse, error)
+	UpdateFloorZone(ctx context.Context, ownerID uuid.UUID, zoneID uuid.UUID, req request.UpdateFloorZone) (*response.FloorZoneResponse, error)
+	DeleteFloorZone(ctx context.Context, ownerID uuid.UUID, zoneID uuid.UUID) error
+}
+
+type floorZoneUsecase struct {
+	floorZoneRepo  repository.IFloorZoneRepo
+	coffeeShopRepo repository.ICoffeeShopRepo
+	staffRepo      repository.IStaffRepo
+}
+
+func NewFloorZoneUsecase(
+	floorZoneRepo repository.IFloorZoneRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) IFloorZoneUsecase {
+	return &floorZoneUsecase{
+		floorZoneRepo:  floorZoneRepo,
+		coffeeShopRepo: coffeeShopRepo,
+		staffRepo:      staffRepo,
+	}
+}
+
+func (u *floorZoneUsecase) CreateFloorZone(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreateFloorZone) (*response.FloorZoneResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreateFloorZone usecase called")
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized to manage this coffee shop")
+	}
+
+	zone := &entity.FloorZone{
+		ID:           uuid.New(),
+		CoffeeShopID: shop.ID,
+		Name:         req.Name,
+		Description:  req.Description,
+	}
+	if err := u.floorZoneRepo.CreateFloorZone(zone); err != nil {
+		return nil, err
+	}
+
+	return toFloorZoneResponse(zone), nil
+}
+
+func (u *floorZoneUsecase) GetFloorZones(ctx context.Context, shopID uuid.UUID) ([]response.FloorZoneResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetFloorZones usecase called")
+
+	zones, err := u.floorZoneRepo.GetFloorZonesByCoffeeShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.FloorZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, *toFloorZoneResponse(&zone))
+	}
+
+	return result, nil
+}
+
+func (u *floorZoneUsecase) UpdateFloorZone(ctx context.Context, ownerID uuid.UUID, zoneID uuid.UUID, req request.UpdateFloorZone) (*response.FloorZoneResponse, error) {
+	logger.EnhanceWith(ctx).Info("UpdateFloorZone usecase called")
+
+	zone, err := u.getOwnedFloorZone(ownerID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		zone.Name = req.Name
+	}
+	if req.Description != nil {
+		zone.Description = *req.Description
+	}
+
+	if err := u.floorZoneRepo.UpdateFloorZone(zone); err != nil {
+		return nil, err
+	}
+
+	return toFloorZoneResponse(zone), nil
+}
+
+// DeleteFloorZone removes a zone. Its resources stay bookable without a zone.
+func (u *floorZoneUsecase) DeleteFloorZone(ctx context.Context, ownerID uuid.UUID, zoneID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeleteFloorZone usecase called")
+
+	if _, err := u.getOwnedFloorZone(ownerID, zoneID); err != nil {
+		return err
+	}
+
+	return u.floorZoneRepo.DeleteFloorZone(zoneID)
+}
+
+func (u *floorZoneUsecase) getOwnedFloorZone(ownerID uuid.UUID, zoneID uuid.UUID) (*entity.FloorZone, error) {
+	zone, err := u.floorZoneRepo.GetFloorZoneByID(zoneID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("floor zone not found")
+		}
+		return nil, err
+	}
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(zone.CoffeeShopID)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized to manage this floor zone")
+	}
+
+	return zone, nil
+}
+
+func toFloorZoneResponse(zone *entity.FloorZone) *response.FloorZoneResponse {
+	return &response.FloorZoneResponse{
+		ID:           zone.ID,
+		CoffeeShopID: zone.CoffeeShopID,
+		Name:         zone.Name,
+		Description:  zone.Description,
+		CreatedAt:    zone.CreatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IFollowUsecase interface {
+	FollowShop(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error
+	UnfollowShop(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error
+	GetFollowedShops(ctx context.Context, userID uuid.UUID) ([]response.CoffeeShopResponse, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, req request.FeedQuery) (*response.FeedResponse, error)
+	GetFollowerCount(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) (*response.FollowerCountResponse, error)
+}
+
+type followUsecase struct {
+	followRepo     repository.IFollowRepo
+	coffeeShopRepo repository.ICoffeeShopRepo
+	staffRepo      repository.IStaffRepo
+}
+
+func NewFollowUsecase(
+	followRepo repository.IFollowRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	staffRepo repository.IStaffRepo,
+) IFollowUsecase {
+	return &followUsecase{
+		followRepo:     followRepo,
+		coffeeShopRepo: coffeeShopRepo,
+		staffRepo:      staffRepo,
+	}
+}
+
+func (u *followUsecase) FollowShop(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("FollowShop usecase called")
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("coffee shop not found")
+		}
+		return err
+	}
+	if shop.Status != entity.ShopApproved {
+		return errors.New("coffee shop not found")
+	}
+
+	return u.followRepo.FollowShop(&entity.ShopFollow{
+		UserID:       userID,
+		CoffeeShopID: shopID,
+		CreatedAt:    time.Now(),
+	})
+}
+
+func (u *followUsecase) UnfollowShop(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("UnfollowShop usecase called")
+
+	removed, err := u.followRepo.UnfollowShop(userID, shopID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("not following this coffee shop")
+	}
+	return nil
+}
+
+func (u *followUsecase) GetFollowedShops(ctx context.Context, userID uuid.UUID) ([]response.CoffeeShopResponse, error) {
+	shops, err := u.followRepo.GetFollowedShops(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.CoffeeShopResponse, 0, len(shops))
+	for _, shop := range shops {
+		result = append(result, *toCoffeeShopResponse(&shop))
+	}
+	return result, nil
+}
+
+// GetFeed pages through the posts of followed shops, newest first
+func (u *followUsecase) GetFeed(ctx context.Context, userID uuid.UUID, req request.FeedQuery) (*response.FeedResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	// Fetch one extra post to tell whether there is a next page
+	posts, err := u.followRepo.GetFeed(userID, limit+1, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	result := &response.FeedResponse{
+		Posts:   make([]response.ShopPostResponse, 0, len(posts)),
+		Limit:   limit,
+		Offset:  req.Offset,
+		HasMore: hasMore,
+	}
+	for _, post := range posts {
+		result.Posts = append(result.Posts, response.ShopPostResponse{
+			ID:           post.ID,
+			CoffeeShopID: post.CoffeeShopID,
+			ShopName:     post.ShopName,
+			Title:        post.Title,
+			Content:      post.Content,
+			PublishedAt:  post.PublishedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (u *followUsecase) GetFollowerCount(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) (*response.FollowerCountResponse, error) {
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionViewReports)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized to view followers of this coffee shop")
+	}
+
+	counts, err := u.followRepo.CountFollowers([]uuid.UUID{shopID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.FollowerCountResponse{
+		CoffeeShopID:  shopID,
+		FollowerCount: counts[shopID],
+	}, nil
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+const (
+	// tierSpendPeriod is the rolling window the membership tier is computed over
+	tierSpendPeriod = 12
+	// pointsExpiringSoonDays is how far ahead the summary warns about expiring points
+	pointsExpiringSoonDays = 30
+)
+
+// spendServices are the services whose payments count towards tiers
+var spendServices = []entity.ServiceType{entity.ServiceBooking, entity.ServiceFoodOrder, entity.ServiceEventTicket}
+
+// loyaltyTier describes what a membership tier requires and unlocks
+type loyaltyTier struct {
+	Name         string
+	MinSpend     float64
+	BonusPercent int
+	CancelHours  int
+}
+
+type ILoyaltyUsecase interface {
+	GetLoyaltySummary(ctx context.Context, userID uuid.UUID) (*response.LoyaltyResponse, error)
+	GetPointsHistory(ctx context.Context, userID uuid.UUID) ([]response.PointEntryResponse, error)
+	GetPointsBalance(ctx context.Context, userID uuid.UUID) (int, error)
+	GetTier(ctx context.Context, userID uuid.UUID) (string, error)
+	CancellationWindow(ctx context.Context, userID uuid.UUID) (time.Duration, error)
+	QuoteRedemption(ctx context.Context, userID uuid.UUID, points int, orderAmount float64) (float64, error)
+	RedeemPoints(ctx context.Context, userID uuid.UUID, points int, serviceID entity.ServiceType, serviceRefID uuid.UUID) error
+	RefundPoints(ctx context.Context, serviceID entity.ServiceType, serviceRefID uuid.UUID) error
+	AwardPoints(ctx context.Context, userID uuid.UUID, serviceID entity.ServiceType, serviceRefID uuid.UUID, paidAmount float64) error
+}
+
+type loyaltyUsecase struct {
+	loyaltyRepo     repository.ILoyaltyRepo
+	transactionRepo repository.ITransactionRepo
+	cfg             config.LoyaltyCfg
+}
+
+func NewLoyaltyUsecase(
+	loyaltyRepo repository.ILoyaltyRepo,
+	transactionRepo repository.ITransactionRepo,
+	cfg config.LoyaltyCfg,
+) ILoyaltyUsecase {
+	return &loyaltyUsecase{
+		loyaltyRepo:     loyaltyRepo,
+		transactionRepo: transactionRepo,
+		cfg:             cfg,
+	}
+}
+
+func (u *loyaltyUsecase) GetLoyaltySummary(ctx context.Context, userID uuid.UUID) (*response.LoyaltyResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetLoyaltySummary usecase called")
+
+	points, err := u.GetPointsBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiringSoon, err := u.loyaltyRepo.GetPointsExpiringBefore(userID, now, now.AddDate(0, 0, pointsExpiringSoonDays))
+	if err != nil {
+		return nil, err
+	}
+
+	spend, tier, err := u.currentTier(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &response.LoyaltyResponse{
+		Points:                  points,
+		PointValue:              u.cfg.PointValue,
+		PointsValue:             mathutil.RoundToFloat(float64(points)*u.cfg.PointValue, 2),
+		PointsExpiringSoon:      expiringSoon,
+		Tier:                    tier.Name,
+		RollingSpend:            spend,
+		EarnBonusPercent:        tier.BonusPercent,
+		CancellationWindowHours: tier.CancelHours,
+	}
+	if next := u.nextTier(tier); next != nil {
+		summary.NextTier = next.Name
+		summary.SpendToNextTier = mathutil.RoundToFloat(next.MinSpend-spend, 2)
+	}
+
+	return summary, nil
+}
+
+func (u *loyaltyUsecase) GetPointsHistory(ctx context.Context, userID uuid.UUID) ([]response.PointEntryResponse, error) {
+	if _, err := u.loyaltyRepo.ExpirePoints(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.loyaltyRepo.GetEntriesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.PointEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, response.PointEntryResponse{
+			ID:           entry.ID,
+			Type:         entry.Type,
+			Points:       entry.Points,
+			ServiceID:    int(entry.ServiceID),
+			ServiceRefID: entry.ServiceRefID,
+			ExpiresAt:    entry.ExpiresAt,
+			CreatedAt:    entry.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// GetPointsBalance expires what is due and returns the points the user can redeem
+func (u *loyaltyUsecase) GetPointsBalance(ctx context.Context, userID uuid.UUID) (int, error) {
+	now := time.Now()
+	if _, err := u.loyaltyRepo.ExpirePoints(userID, now); err != nil {
+		return 0, err
+	}
+	return u.loyaltyRepo.GetPointsBalance(userID, now)
+}
+
+func (u *loyaltyUsecase) GetTier(ctx context.Context, userID uuid.UUID) (string, error) {
+	_, tier, err := u.currentTier(userID)
+	if err != nil {
+		return "", err
+	}
+	return tier.Name, nil
+}
+
+// CancellationWindow returns how long before the start a booking of the user can still be cancelled for free
+func (u *loyaltyUsecase) CancellationWindow(ctx context.Context, userID uuid.UUID) (time.Duration, error) {
+	_, tier, err := u.currentTier(userID)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(tier.CancelHours) * time.Hour, nil
+}
+
+// QuoteRedemption validates redeeming points on an order and returns the discount they are worth
+func (u *loyaltyUsecase) QuoteRedemption(ctx context.Context, userID uuid.UUID, points int, orderAmount float64) (float64, error) {
+	if points <= 0 {
+		return 0, nil
+	}
+	if u.cfg.PointValue <= 0 {
+		return 0, errors.New("points redemption is not available")
+	}
+
+	maxPoints := int(math.Floor(orderAmount * float64(u.cfg.MaxRedeemPercent) / 100 / u.cfg.PointValue))
+	if points > maxPoints {
+		return 0, fmt.Errorf("at most %d points can be redeemed on this order", maxPoints)
+	}
+
+	balance, err := u.GetPointsBalance(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if points > balance {
+		return 0, errors.New("insufficient points")
+	}
+
+	return mathutil.RoundToFloat(float64(points)*u.cfg.PointValue, 2), nil
+}
+
+func (u *loyaltyUsecase) RedeemPoints(ctx context.Context, userID uuid.UUID, points int, serviceID entity.ServiceType, serviceRefID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("RedeemPoints usecase called")
+
+	now := time.Now()
+	err := u.loyaltyRepo.RedeemPoints(&entity.PointEntry{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Type:         entity.PointRedeem,
+		Points:       -points,
+		ServiceID:    serviceID,
+		ServiceRefID: serviceRefID,
+		CreatedAt:    now,
+	}, now)
+	if errors.Is(err, repository.ErrInsufficientPoints) {
+		return errors.New("insufficient points")
+	}
+	return err
+}
+
+// RefundPoints gives back the points redeemed on a cancelled order
+func (u *loyaltyUsecase) RefundPoints(ctx context.Context, serviceID entity.ServiceType, serviceRefID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("RefundPoints usecase called")
+
+	_, err := u.loyaltyRepo.RefundRedemption(serviceID, serviceRefID, time.Now())
+	return err
+}
+
+// AwardPoints credits points for a completed order, with the bonus of the user's tier
+func (u *loyaltyUsecase) AwardPoints(ctx context.Context, userID uuid.UUID, serviceID entity.ServiceType, serviceRefID uuid.UUID, paidAmount float64) error {
+	logger.EnhanceWith(ctx).Info("AwardPoints usecase called")
+
+	if u.cfg.SpendPerPoint <= 0 {
+		return nil
+	}
+	_, tier, err := u.currentTier(userID)
+	if err != nil {
+		return err
+	}
+
+	points := int(math.Floor(paidAmount / u.cfg.SpendPerPoint * float64(100+tier.BonusPercent) / 100))
+	if points <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	expiresAt := now.AddDate(0, 0, u.cfg.PointsExpiryDays)
+	_, err = u.loyaltyRepo.EarnPoints(&entity.PointEntry{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Type:         entity.PointEarn,
+		Points:       points,
+		Remaining:    points,
+		ServiceID:    serviceID,
+		ServiceRefID: serviceRefID,
+		ExpiresAt:    &expiresAt,
+		CreatedAt:    now,
+	})
+	return err
+}
+
+// currentTier returns the user's spend over the rolling period and the tier it reaches
+func (u *loyaltyUsecase) currentTier(userID uuid.UUID) (float64, loyaltyTier, error) {
+	since := time.Now().AddDate(0, -tierSpendPeriod, 0)
+	spend, err := u.transactionRepo.SumSpendSince(userID, spendServices, since)
+	if err != nil {
+		return 0, loyaltyTier{}, err
+	}
+	spend = mathutil.RoundToFloat(spend, 2)
+
+	tiers := u.tiers()
+	for i := len(tiers) - 1; i > 0; i-- {
+		if spend >= tiers[i].MinSpend {
+			return spend, tiers[i], nil
+		}
+	}
+	return spend, tiers[0], nil
+}
+
+func (u *loyaltyUsecase) nextTier(current loyaltyTier) *loyaltyTier {
+	tiers := u.tiers()
+	for i := range tiers {
+		if tiers[i].Name == current.Name && i+1 < len(tiers) {
+			return &tiers[i+1]
+		}
+	}
+	return nil
+}
+
+// tiers lists the membership tiers from lowest to highest
+func (u *loyaltyUsecase) tiers() []loyaltyTier {
+	return []loyaltyTier{
+		{Name: entity.TierMember, CancelHours: u.cfg.MemberCancelHours},
+		{Name: entity.TierSilver, MinSpend: u.cfg.SilverSpend, BonusPercent: u.cfg.SilverBonusPercent, CancelHours: u.cfg.SilverCancelHours},
+		{Name: entity.TierGold, MinSpend: u.cfg.GoldSpend, BonusPercent: u.cfg.GoldBonusPercent, CancelHours: u.cfg.GoldCancelHours},
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+// fakeLoyaltyRepo holds a fixed points balance and records earned points. Methods the
+// tests do not need are left to the embedded interface.
+type fakeLoyaltyRepo struct {
+	repository.ILoyaltyRepo
+	balance int
+	earned  []entity.PointEntry
+}
+
+func (r *fakeLoyaltyRepo) ExpirePoints(userID uuid.UUID, now time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeLoyaltyRepo) GetPointsBalance(userID uuid.UUID, now time.Time) (int, error) {
+	return r.balance, nil
+}
+
+func (r *fakeLoyaltyRepo) GetPointsExpiringBefore(userID uuid.UUID, now time.Time, before time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeLoyaltyRepo) EarnPoints(entry *entity.PointEntry) (bool, error) {
+	r.earned = append(r.earned, *entry)
+	return true, nil
+}
+
+// fakeSpendRepo reports a fixed spend for tier calculation
+type fakeSpendRepo struct {
+	repository.ITransactionRepo
+	spend float64
+}
+
+func (r *fakeSpendRepo) SumSpendSince(userID uuid.UUID, serviceIDs []entity.ServiceType, since time.Time) (float64, error) {
+	return r.spend, nil
+}
+
+var testLoyaltyCfg = config.LoyaltyCfg{
+	SpendPerPoint:      1000,
+	PointValue:         10,
+	PointsExpiryDays:   365,
+	MaxRedeemPercent:   50,
+	SilverSpend:        2000,
+	GoldSpend:          10000,
+	SilverBonusPercent: 10,
+	GoldBonusPercent:   25,
+	MemberCancelHours:  24,
+	SilverCancelHours:  12,
+	GoldCancelHours:    4,
+}
+
+func TestQuoteRedemption(t *testing.T) {
+	tests := []struct {
+		name         string
+		pointValue   float64
+		balance      int
+		points       int
+		orderAmount  float64
+		wantDiscount float64
+		wantErr      bool
+	}{
+		{name: "no points", pointValue: 10, balance: 100, points: 0, orderAmount: 1000, wantDiscount: 0},
+		{name: "within limits", pointValue: 10, balance: 100, points: 30, orderAmount: 1000, wantDiscount: 300},
+		{name: "at the order limit", pointValue: 10, balance: 100, points: 50, orderAmount: 1000, wantDiscount: 500},
+		{name: "over the order limit", pointValue: 10, balance: 100, points: 51, orderAmount: 1000, wantErr: true},
+		{name: "order limit rounds down", pointValue: 10, balance: 100, points: 5, orderAmount: 99, wantErr: true},
+		{name: "over the balance", pointValue: 10, balance: 20, points: 30, orderAmount: 1000, wantErr: true},
+		{name: "rounded to cents", pointValue: 0.333, balance: 100, points: 3, orderAmount: 100, wantDiscount: 1},
+		{name: "redemption disabled", pointValue: 0, balance: 100, points: 10, orderAmount: 1000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testLoyaltyCfg
+			cfg.PointValue = tt.pointValue
+			u := NewLoyaltyUsecase(&fakeLoyaltyRepo{balance: tt.balance}, &fakeSpendRepo{}, cfg)
+
+			discount, err := u.QuoteRedemption(context.Background(), uuid.New(), tt.points, tt.orderAmount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("QuoteRedemption() = %v, want an error", discount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuoteRedemption() error = %v", err)
+			}
+			if discount != tt.wantDiscount {
+				t.Errorf("QuoteRedemption() = %v, want %v", discount, tt.wantDiscount)
+			}
+		})
+	}
+}
+
+func TestLoyaltyTiers(t *testing.T) {
+	tests := []struct {
+		spend       float64
+		wantTier    string
+		wantWindow  time.Duration
+		wantNext    string
+		wantToNext  float64
+		paidAmount  float64
+		wantAwarded int
+	}{
+		{spend: 0, wantTier: entity.TierMember, wantWindow: 24 * time.Hour, wantNext: entity.TierSilver, wantToNext: 2000, paidAmount: 2500, wantAwarded: 2},
+		{spend: 1999.994, wantTier: entity.TierMember, wantWindow: 24 * time.Hour, wantNext: entity.TierSilver, wantToNext: 0.01, paidAmount: 999, wantAwarded: 0},
+		{spend: 1999.996, wantTier: entity.TierSilver, wantWindow: 12 * time.Hour, wantNext: entity.TierGold, wantToNext: 8000, paidAmount: 25000, wantAwarded: 27},
+		{spend: 9999.99, wantTier: entity.TierSilver, wantWindow: 12 * time.Hour, wantNext: entity.TierGold, wantToNext: 0.01, paidAmount: 1000, wantAwarded: 1},
+		{spend: 10000, wantTier: entity.TierGold, wantWindow: 4 * time.Hour, paidAmount: 4000, wantAwarded: 5},
+	}
+
+	for _, tt := range tests {
+		loyaltyRepo := &fakeLoyaltyRepo{}
+		u := NewLoyaltyUsecase(loyaltyRepo, &fakeSpendRepo{spend: tt.spend}, testLoyaltyCfg)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		summary, err := u.GetLoyaltySummary(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetLoyaltySummary() with spend %v error = %v", tt.spend, err)
+		}
+		if summary.Tier != tt.wantTier || summary.NextTier != tt.wantNext || summary.SpendToNextTier != tt.wantToNext {
+			t.Errorf("spend %v: tier %q, next %q in %v; want %q, next %q in %v",
+				tt.spend, summary.Tier, summary.NextTier, summary.SpendToNextTier, tt.wantTier, tt.wantNext, tt.wantToNext)
+		}
+
+		window, err := u.CancellationWindow(ctx, userID)
+		if err != nil {
+			t.Fatalf("CancellationWindow() error = %v", err)
+		}
+		if window != tt.wantWindow {
+			t.Errorf("spend %v: cancellation window %v, want %v", tt.spend, window, tt.wantWindow)
+		}
+
+		// Points are earned per SpendPerPoint with the tier's bonus, rounded down
+		if err := u.AwardPoints(ctx, userID, entity.ServiceBooking, uuid.New(), tt.paidAmount); err != nil {
+			t.Fatalf("AwardPoints() error = %v", err)
+		}
+		awarded := 0
+		for _, entry := range loyaltyRepo.earned {
+			awarded += entry.Points
+		}
+		if awarded != tt.wantAwarded {
+			t.Errorf("spend %v: paying %v awarded %d points, want %d", tt.spend, tt.paidAmount, awarded, tt.wantAwarded)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/storage"
+	"github.com/leehai1107/cmm_server/pkg/utils/imageutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+// mediaExtensions maps the accepted image types, sniffed from the file content, to file extensions
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type IMediaUsecase interface {
+	UploadMedia(ctx context.Context, userID uuid.UUID, req request.UploadMedia, data []byte) (*response.MediaResponse, error)
+	GetGallery(ctx context.Context, ownerType string, ownerID uuid.UUID) ([]response.MediaResponse, error)
+	DeleteMedia(ctx context.Context, userID uuid.UUID, mediaID uuid.UUID) error
+}
+
+type mediaUsecase struct {
+	mediaRepo       repository.IMediaRepo
+	coffeeShopRepo  repository.ICoffeeShopRepo
+	meetingRoomRepo repository.IMeetingRoomRepo
+	postRepo        repository.IPostRepo
+	staffRepo       repository.IStaffRepo
+	storage         storage.Storage
+}
+
+func NewMediaUsecase(
+	mediaRepo repository.IMediaRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	postRepo repository.IPostRepo,
+	staffRepo repository.IStaffRepo,
+	storage storage.Storage,
+) IMediaUsecase {
+	return &mediaUsecase{
+		mediaRepo:       mediaRepo,
+		coffeeShopRepo:  coffeeShopRepo,
+		meetingRoomRepo: meetingRoomRepo,
+		postRepo:        postRepo,
+		staffRepo:       staffRepo,
+		storage:         storage,
+	}
+}
+
+// UploadMedia stores an image in the gallery of a shop, room or post owned by the user
+func (u *mediaUsecase) UploadMedia(ctx context.Context, userID uuid.UUID, req request.UploadMedia, data []byte) (*response.MediaResponse, error) {
+	log := logger.EnhanceWith(ctx)
+	log.Info("UploadMedia usecase called")
+
+	cfg := config.StorageConfig()
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if int64(len(data)) > cfg.MaxUploadBytes {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", cfg.MaxUploadBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, errors.New("unsupported file type, only JPEG, PNG, GIF and WebP images are allowed")
+	}
+
+	ownerID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		return nil, errors.New("invalid owner ID")
+	}
+	if err := u.checkMediaOwner(req.OwnerType, ownerID, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := u.mediaRepo.CountMediaByOwner(req.OwnerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(cfg.MaxPerOwner) {
+		return nil, fmt.Errorf("gallery is full, at most %d images are allowed", cfg.MaxPerOwner)
+	}
+
+	media := &entity.Media{
+		ID:          uuid.New(),
+		OwnerType:   req.OwnerType,
+		OwnerID:     ownerID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		UploadedBy:  userID,
+		CreatedAt:   time.Now(),
+	}
+	prefix := fmt.Sprintf("media/%s/%s/%s", req.OwnerType, ownerID, media.ID)
+	media.StorageKey = prefix + extension
+
+	// WebP cannot be decoded with the standard library, so it is stored without a thumbnail
+	var thumbnail []byte
+	if contentType != "image/webp" {
+		img, err := imageutil.Decode(data, cfg.MaxPixels)
+		if errors.Is(err, imageutil.ErrTooManyPixels) {
+			return nil, fmt.Errorf("image exceeds the %d pixel limit", cfg.MaxPixels)
+		}
+		if err != nil {
+			return nil, errors.New("invalid image file")
+		}
+		media.Width = img.Bounds().Dx()
+		media.Height = img.Bounds().Dy()
+
+		thumbnail, err = imageutil.Thumbnail(img, cfg.ThumbnailWidth)
+		if err != nil {
+			return nil, err
+		}
+		media.ThumbnailKey = prefix + "_thumb.jpg"
+	}
+
+	if err := u.storage.Put(ctx, media.StorageKey, data, contentType); err != nil {
+		return nil, err
+	}
+	media.URL = u.storage.URL(media.StorageKey)
+
+	if thumbnail != nil {
+		if err := u.storage.Put(ctx, media.ThumbnailKey, thumbnail, "image/jpeg"); err != nil {
+			u.deleteObjects(ctx, media.StorageKey)
+			return nil, err
+		}
+		media.ThumbnailURL = u.storage.URL(media.ThumbnailKey)
+	}
+
+	if err := u.mediaRepo.CreateMedia(media); err != nil {
+		u.deleteObjects(ctx, media.StorageKey, media.ThumbnailKey)
+		return nil, err
+	}
+
+	return toMediaResponse(media), nil
+}
+
+func (u *mediaUsecase) GetGallery(ctx context.Context, ownerType string, ownerID uuid.UUID) ([]response.MediaResponse, error) {
+	media, err := u.mediaRepo.GetMediaByOwner(ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return toMediaResponses(media), nil
+}
+
+// DeleteMedia removes an image from a gallery owned by the user, together with its stored files
+func (u *mediaUsecase) DeleteMedia(ctx context.Context, userID uuid.UUID, mediaID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeleteMedia usecase called")
+
+	media, err := u.mediaRepo.GetMediaByID(mediaID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("media not found")
+		}
+		return err
+	}
+
+	if err := u.checkMediaOwner(media.OwnerType, media.OwnerID, userID); err != nil {
+		return err
+	}
+
+	if err := u.mediaRepo.DeleteMedia(media.ID); err != nil {
+		return err
+	}
+
+	u.deleteObjects(ctx, media.StorageKey, media.ThumbnailKey)
+	return nil
+}
+
+// checkMediaOwner verifies the user manages the coffee shop the gallery belongs to. Staff need
+// the permission for the shop details, rooms or posts the gallery shows.
+func (u *mediaUsecase) checkMediaOwner(ownerType string, ownerID uuid.UUID, userID uuid.UUID) error {
+	var shopID uuid.UUID
+	var permission string
+	switch ownerType {
+	case entity.MediaOwnerCoffeeShop:
+		permission = entity.PermissionManageShop
+		shopID = ownerID
+	case entity.MediaOwnerMeetingRoom:
+		permission = entity.PermissionManageRooms
+		room, err := u.meetingRoomRepo.GetMeetingRoomByID(ownerID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("meeting room not found")
+			}
+			return err
+		}
+		shopID = room.CoffeeShopID
+	case entity.MediaOwnerShopPost:
+		permission = entity.PermissionManageMenu
+		post, err := u.postRepo.GetShopPostByID(ownerID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("post not found")
+			}
+			return err
+		}
+		shopID = post.CoffeeShopID
+	default:
+		return errors.New("invalid media owner type")
+	}
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("coffee shop not found")
+		}
+		return err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, userID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.New("unauthorized to manage media of this coffee shop")
+	}
+	return nil
+}
+
+// deleteObjects removes stored files; failures only leave orphaned files behind, so they are logged
+func (u *mediaUsecase) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := u.storage.Delete(ctx, key); err != nil {
+			logger.EnhanceWith(ctx).Errorw("Failed to delete stored media", "key", key, "error", err)
+		}
+	}
+}
+
+func toMediaResponse(media *entity.Media) *response.MediaResponse {
+	return &response.MediaResponse{
+		ID:           media.ID,
+		OwnerType:    media.OwnerType,
+		OwnerID:      media.OwnerID,
+		URL:          media.URL,
+		ThumbnailURL: media.ThumbnailURL,
+		ContentType:  media.ContentType,
+		Size:         media.Size,
+		Width:        media.Width,
+		Height:       media.Height,
+		CreatedAt:    media.CreatedAt,
+	}
+}
+
+func toMediaResponses(media []entity.Media) []response.MediaResponse {
+	result := make([]response.MediaResponse, 0, len(media))
+	for i := range media {
+		result = append(result, *toMediaResponse(&media[i]))
+	}
+	return result
+}
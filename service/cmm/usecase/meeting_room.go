@@ -3,9 +3,14 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/model/response"
@@ -20,20 +25,42 @@ type IMeetingRoomUsecase interface {
 	GetAvailableMeetingRooms(ctx context.Context, shopID uuid.UUID) ([]response.MeetingRoomResponse, error)
 	UpdateMeetingRoom(ctx context.Context, ownerID uuid.UUID, req request.UpdateMeetingRoom) error
 	DeleteMeetingRoom(ctx context.Context, ownerID uuid.UUID, roomID uuid.UUID) error
+	BulkCreateResources(ctx context.Context, ownerID uuid.UUID, req request.BulkCreateResources) ([]response.MeetingRoomResponse, error)
+	GetResourceAvailability(ctx context.Context, shopID uuid.UUID, req request.ResourceAvailability) ([]response.ResourceAvailabilityResponse, error)
+	SearchMeetingRooms(ctx context.Context, req request.SearchMeetingRooms) ([]response.MeetingRoomResponse, error)
+	GetAmenities(ctx context.Context) ([]response.AmenityResponse, error)
 }
 
 type meetingRoomUsecase struct {
 	meetingRoomRepo repository.IMeetingRoomRepo
 	coffeeShopRepo  repository.ICoffeeShopRepo
+	floorZoneRepo   repository.IFloorZoneRepo
+	bookingRepo     repository.IBookingRepo
+	amenityRepo     repository.IAmenityRepo
+	reviewRepo      repository.IReviewRepo
+	mediaRepo       repository.IMediaRepo
+	staffRepo       repository.IStaffRepo
 }
 
 func NewMeetingRoomUsecase(
 	meetingRoomRepo repository.IMeetingRoomRepo,
 	coffeeShopRepo repository.ICoffeeShopRepo,
+	floorZoneRepo repository.IFloorZoneRepo,
+	bookingRepo repository.IBookingRepo,
+	amenityRepo repository.IAmenityRepo,
+	reviewRepo repository.IReviewRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
 ) IMeetingRoomUsecase {
 	return &meetingRoomUsecase{
 		meetingRoomRepo: meetingRoomRepo,
 		coffeeShopRepo:  coffeeShopRepo,
+		floorZoneRepo:   floorZoneRepo,
+		bookingRepo:     bookingRepo,
+		amenityRepo:     amenityRepo,
+		reviewRepo:      reviewRepo,
+		mediaRepo:       mediaRepo,
+		staffRepo:       staffRepo,
 	}
 }
 
@@ -49,32 +76,55 @@ func (u *meetingRoomUsecase) CreateMeetingRoom(ctx context.Context, ownerID uuid
 		return nil, err
 	}
 
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
 		return nil, errors.New("unauthorized to create room for this coffee shop")
 	}
 
+	if req.PricePerHour <= 0 && req.PricePerDay <= 0 {
+		return nil, errors.New("price_per_hour or price_per_day is required")
+	}
+	if err := u.checkFloorZone(req.FloorZoneID, shop.ID); err != nil {
+		return nil, err
+	}
+	amenities, err := u.checkAmenities(req.Amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceType := req.ResourceType
+	if resourceType == "" {
+		resourceType = entity.ResourceMeetingRoom
+	}
+
 	room := &entity.MeetingRoom{
 		ID:           uuid.New(),
 		CoffeeShopID: req.CoffeeShopID,
+		FloorZoneID:  req.FloorZoneID,
+		ResourceType: resourceType,
 		Name:         req.Name,
 		Capacity:     req.Capacity,
 		PricePerHour: req.PricePerHour,
+		PricePerDay:  req.PricePerDay,
 		Available:    true,
 	}
 
 	if err := u.meetingRoomRepo.CreateMeetingRoom(room); err != nil {
 		return nil, err
 	}
+	if err := u.amenityRepo.SetRoomAmenities([]uuid.UUID{room.ID}, amenities); err != nil {
+		return nil, err
+	}
 
-	return &response.MeetingRoomResponse{
-		ID:           room.ID,
-		CoffeeShopID: room.CoffeeShopID,
-		ShopName:     shop.Name,
-		Name:         room.Name,
-		Capacity:     room.Capacity,
-		PricePerHour: room.PricePerHour,
-		Available:    room.Available,
-	}, nil
+	result := []response.MeetingRoomResponse{*toMeetingRoomResponse(room, shop.Name)}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
+	}
+
+	return &result[0], nil
 }
 
 func (u *meetingRoomUsecase) GetMeetingRoom(ctx context.Context, roomID uuid.UUID) (*response.MeetingRoomResponse, error) {
@@ -92,15 +142,18 @@ func (u *meetingRoomUsecase) GetMeetingRoom(ctx context.Context, roomID uuid.UUI
 		shopName = shop.Name
 	}
 
-	return &response.MeetingRoomResponse{
-		ID:           room.ID,
-		CoffeeShopID: room.CoffeeShopID,
-		ShopName:     shopName,
-		Name:         room.Name,
-		Capacity:     room.Capacity,
-		PricePerHour: room.PricePerHour,
-		Available:    room.Available,
-	}, nil
+	result := []response.MeetingRoomResponse{*toMeetingRoomResponse(room, shopName)}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
+	}
+
+	media, err := u.mediaRepo.GetMediaByOwner(entity.MediaOwnerMeetingRoom, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	result[0].Media = toMediaResponses(media)
+
+	return &result[0], nil
 }
 
 func (u *meetingRoomUsecase) GetMeetingRoomsByCoffeeShop(ctx context.Context, shopID uuid.UUID) ([]response.MeetingRoomResponse, error) {
@@ -117,15 +170,10 @@ func (u *meetingRoomUsecase) GetMeetingRoomsByCoffeeShop(ctx context.Context, sh
 
 	var result []response.MeetingRoomResponse
 	for _, room := range rooms {
-		result = append(result, response.MeetingRoomResponse{
-			ID:           room.ID,
-			CoffeeShopID: room.CoffeeShopID,
-			ShopName:     shopName,
-			Name:         room.Name,
-			Capacity:     room.Capacity,
-			PricePerHour: room.PricePerHour,
-			Available:    room.Available,
-		})
+		result = append(result, *toMeetingRoomResponse(&room, shopName))
+	}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -145,15 +193,10 @@ func (u *meetingRoomUsecase) GetAvailableMeetingRooms(ctx context.Context, shopI
 
 	var result []response.MeetingRoomResponse
 	for _, room := range rooms {
-		result = append(result, response.MeetingRoomResponse{
-			ID:           room.ID,
-			CoffeeShopID: room.CoffeeShopID,
-			ShopName:     shopName,
-			Name:         room.Name,
-			Capacity:     room.Capacity,
-			PricePerHour: room.PricePerHour,
-			Available:    room.Available,
-		})
+		result = append(result, *toMeetingRoomResponse(&room, shopName))
+	}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -173,7 +216,11 @@ func (u *meetingRoomUsecase) UpdateMeetingRoom(ctx context.Context, ownerID uuid
 	if err != nil {
 		return err
 	}
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return err
+	}
+	if !allowed {
 		return errors.New("unauthorized to update this meeting room")
 	}
 
@@ -187,10 +234,29 @@ func (u *meetingRoomUsecase) UpdateMeetingRoom(ctx context.Context, ownerID uuid
 	if req.PricePerHour > 0 {
 		room.PricePerHour = req.PricePerHour
 	}
+	if req.PricePerDay != nil {
+		room.PricePerDay = *req.PricePerDay
+	}
+	if req.FloorZoneID != nil {
+		if err := u.checkFloorZone(req.FloorZoneID, room.CoffeeShopID); err != nil {
+			return err
+		}
+		room.FloorZoneID = req.FloorZoneID
+	}
 	if req.Available != nil {
 		room.Available = *req.Available
 	}
 
+	if req.Amenities != nil {
+		amenities, err := u.checkAmenities(*req.Amenities)
+		if err != nil {
+			return err
+		}
+		if err := u.amenityRepo.SetRoomAmenities([]uuid.UUID{room.ID}, amenities); err != nil {
+			return err
+		}
+	}
+
 	return u.meetingRoomRepo.UpdateMeetingRoom(room)
 }
 
@@ -208,9 +274,329 @@ func (u *meetingRoomUsecase) DeleteMeetingRoom(ctx context.Context, ownerID uuid
 	if err != nil {
 		return err
 	}
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return err
+	}
+	if !allowed {
 		return errors.New("unauthorized to delete this meeting room")
 	}
 
 	return u.meetingRoomRepo.DeleteMeetingRoom(roomID)
 }
+
+// BulkCreateResources creates a run of numbered resources, typically the desks or seats of a zone
+func (u *meetingRoomUsecase) BulkCreateResources(ctx context.Context, ownerID uuid.UUID, req request.BulkCreateResources) ([]response.MeetingRoomResponse, error) {
+	logger.EnhanceWith(ctx).Info("BulkCreateResources usecase called")
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(req.CoffeeShopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageRooms)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized to create resources for this coffee shop")
+	}
+
+	if req.PricePerHour <= 0 && req.PricePerDay <= 0 {
+		return nil, errors.New("price_per_hour or price_per_day is required")
+	}
+	if err := u.checkFloorZone(req.FloorZoneID, shop.ID); err != nil {
+		return nil, err
+	}
+	amenities, err := u.checkAmenities(req.Amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := req.Capacity
+	if capacity == 0 {
+		capacity = 1
+	}
+	startNumber := req.StartNumber
+	if startNumber == 0 {
+		startNumber = 1
+	}
+
+	rooms := make([]entity.MeetingRoom, 0, req.Count)
+	for i := 0; i < req.Count; i++ {
+		rooms = append(rooms, entity.MeetingRoom{
+			ID:           uuid.New(),
+			CoffeeShopID: shop.ID,
+			FloorZoneID:  req.FloorZoneID,
+			ResourceType: req.ResourceType,
+			Name:         fmt.Sprintf("%s%d", req.NamePrefix, startNumber+i),
+			Capacity:     capacity,
+			PricePerHour: req.PricePerHour,
+			PricePerDay:  req.PricePerDay,
+			Available:    true,
+		})
+	}
+
+	if err := u.meetingRoomRepo.CreateMeetingRooms(rooms); err != nil {
+		return nil, err
+	}
+
+	roomIDs := make([]uuid.UUID, 0, len(rooms))
+	result := make([]response.MeetingRoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+		result = append(result, *toMeetingRoomResponse(&room, shop.Name))
+	}
+	if err := u.amenityRepo.SetRoomAmenities(roomIDs, amenities); err != nil {
+		return nil, err
+	}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetResourceAvailability lists the shop's resources with whether each one is free for the whole period
+func (u *meetingRoomUsecase) GetResourceAvailability(ctx context.Context, shopID uuid.UUID, req request.ResourceAvailability) ([]response.ResourceAvailabilityResponse, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+
+	var floorZoneID *uuid.UUID
+	if req.FloorZoneID != "" {
+		zoneID, err := uuid.Parse(req.FloorZoneID)
+		if err != nil {
+			return nil, errors.New("invalid floor zone ID")
+		}
+		floorZoneID = &zoneID
+	}
+
+	rooms, err := u.meetingRoomRepo.FindMeetingRooms(shopID, req.ResourceType, floorZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	roomIDs := make([]uuid.UUID, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+	}
+	bookedIDs, err := u.bookingRepo.GetBookedRoomIDs(roomIDs, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	booked := make(map[uuid.UUID]bool, len(bookedIDs))
+	for _, id := range bookedIDs {
+		booked[id] = true
+	}
+
+	roomResponses := make([]response.MeetingRoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		roomResponses = append(roomResponses, *toMeetingRoomResponse(&room, shop.Name))
+	}
+	if err := u.attachRoomDetails(roomResponses); err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ResourceAvailabilityResponse, 0, len(rooms))
+	for i, room := range rooms {
+		result = append(result, response.ResourceAvailabilityResponse{
+			MeetingRoomResponse: roomResponses[i],
+			Free:                !booked[room.ID],
+			Price:               calculateBookingPrice(&room, req.StartTime, req.EndTime),
+		})
+	}
+
+	return result, nil
+}
+
+// SearchMeetingRooms finds available rooms across shops matching the amenity, capacity and price filters
+func (u *meetingRoomUsecase) SearchMeetingRooms(ctx context.Context, req request.SearchMeetingRooms) ([]response.MeetingRoomResponse, error) {
+	logger.EnhanceWith(ctx).Info("SearchMeetingRooms usecase called")
+
+	if req.MaxCapacity > 0 && req.MaxCapacity < req.MinCapacity {
+		return nil, errors.New("max_capacity must not be less than min_capacity")
+	}
+	if req.MaxPrice > 0 && req.MaxPrice < req.MinPrice {
+		return nil, errors.New("max_price must not be less than min_price")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	priceUnit := req.PriceUnit
+	if priceUnit == "" {
+		priceUnit = repository.PriceUnitHour
+	}
+
+	var shopID *uuid.UUID
+	if req.CoffeeShopID != "" {
+		id, err := uuid.Parse(req.CoffeeShopID)
+		if err != nil {
+			return nil, errors.New("invalid coffee shop ID")
+		}
+		shopID = &id
+	}
+
+	rooms, err := u.meetingRoomRepo.SearchMeetingRooms(repository.RoomSearchFilter{
+		CoffeeShopID: shopID,
+		Location:     strings.TrimSpace(req.Location),
+		ResourceType: req.ResourceType,
+		Amenities:    normalizeAmenityCodes(req.Amenities),
+		MinCapacity:  req.MinCapacity,
+		MaxCapacity:  req.MaxCapacity,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		PriceUnit:    priceUnit,
+		Limit:        limit,
+		Offset:       req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.MeetingRoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		result = append(result, *toMeetingRoomResponse(&room.MeetingRoom, room.ShopName))
+	}
+	if err := u.attachRoomDetails(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (u *meetingRoomUsecase) GetAmenities(ctx context.Context) ([]response.AmenityResponse, error) {
+	amenities, err := u.amenityRepo.GetAllAmenities()
+	if err != nil {
+		return nil, err
+	}
+	return toAmenityResponses(amenities), nil
+}
+
+// checkAmenities normalizes amenity codes and makes sure they are all in the catalog
+func (u *meetingRoomUsecase) checkAmenities(codes []string) ([]string, error) {
+	codes = normalizeAmenityCodes(codes)
+	if len(codes) == 0 {
+		return codes, nil
+	}
+	count, err := u.amenityRepo.CountAmenities(codes)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) != len(codes) {
+		return nil, errors.New("unknown amenity")
+	}
+	return codes, nil
+}
+
+// attachRoomDetails fills in the amenities and rating summary of the given rooms
+func (u *meetingRoomUsecase) attachRoomDetails(rooms []response.MeetingRoomResponse) error {
+	roomIDs := make([]uuid.UUID, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+	}
+	amenities, err := u.amenityRepo.GetAmenitiesByRooms(roomIDs)
+	if err != nil {
+		return err
+	}
+	ratings, err := u.reviewRepo.GetRoomRatings(roomIDs)
+	if err != nil {
+		return err
+	}
+	for i := range rooms {
+		rooms[i].Amenities = toAmenityResponses(amenities[rooms[i].ID])
+		rooms[i].AverageRating = mathutil.RoundToFloat(ratings[rooms[i].ID].AverageRating, 1)
+		rooms[i].ReviewCount = ratings[rooms[i].ID].ReviewCount
+	}
+	return nil
+}
+
+// normalizeAmenityCodes splits comma-separated codes, lowercases them and drops blanks and duplicates
+func normalizeAmenityCodes(codes []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(codes))
+	for _, value := range codes {
+		for _, code := range strings.Split(value, ",") {
+			code = strings.ToLower(strings.TrimSpace(code))
+			if code == "" || seen[code] {
+				continue
+			}
+			seen[code] = true
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+func toAmenityResponses(amenities []entity.Amenity) []response.AmenityResponse {
+	result := make([]response.AmenityResponse, 0, len(amenities))
+	for _, amenity := range amenities {
+		result = append(result, response.AmenityResponse{Code: amenity.Code, Name: amenity.Name})
+	}
+	return result
+}
+
+// checkFloorZone makes sure an optional floor zone belongs to the shop
+func (u *meetingRoomUsecase) checkFloorZone(floorZoneID *uuid.UUID, shopID uuid.UUID) error {
+	if floorZoneID == nil {
+		return nil
+	}
+	zone, err := u.floorZoneRepo.GetFloorZoneByID(*floorZoneID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("floor zone not found")
+		}
+		return err
+	}
+	if zone.CoffeeShopID != shopID {
+		return errors.New("floor zone does not belong to this coffee shop")
+	}
+	return nil
+}
+
+// calculateBookingPrice prices a booking of a resource. Resources with a day rate are charged
+// per started day, where a partial day costs the hourly price but never more than the day rate.
+func calculateBookingPrice(room *entity.MeetingRoom, startTime, endTime time.Time) float64 {
+	hours := endTime.Sub(startTime).Hours()
+	if room.PricePerDay <= 0 {
+		return mathutil.RoundToFloat(room.PricePerHour*hours, 2)
+	}
+
+	days := math.Floor(hours / 24)
+	price := days * room.PricePerDay
+	if rest := hours - days*24; rest > 0 {
+		partial := rest * room.PricePerHour
+		if room.PricePerHour <= 0 || partial > room.PricePerDay {
+			partial = room.PricePerDay
+		}
+		price += partial
+	}
+	return mathutil.RoundToFloat(price, 2)
+}
+
+func toMeetingRoomResponse(room *entity.MeetingRoom, shopName string) *response.MeetingRoomResponse {
+	return &response.MeetingRoomResponse{
+		ID:           room.ID,
+		CoffeeShopID: room.CoffeeShopID,
+		ShopName:     shopName,
+		FloorZoneID:  room.FloorZoneID,
+		ResourceType: room.ResourceType,
+		Name:         room.Name,
+		Capacity:     room.Capacity,
+		PricePerHour: room.PricePerHour,
+		PricePerDay:  room.PricePerDay,
+		Available:    room.Available,
+	}
+}
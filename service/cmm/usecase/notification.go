@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/websocket"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+type INotificationUsecase interface {
+	Notify(ctx context.Context, userID uuid.UUID, notificationType string, title string, body string, refID *uuid.UUID) error
+	GetMyNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) (*response.NotificationListResponse, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.INotificationRepo
+}
+
+func NewNotificationUsecase(
+	notificationRepo repository.INotificationRepo,
+) INotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Notify stores a notification and pushes it to the user's notification websocket connections without waiting on them
+func (u *notificationUsecase) Notify(ctx context.Context, userID uuid.UUID, notificationType string, title string, body string, refID *uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("Notify usecase called")
+
+	notification := &entity.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		RefID:     refID,
+		CreatedAt: time.Now(),
+	}
+	if err := u.notificationRepo.CreateNotification(notification); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(toNotificationResponse(notification))
+	if err != nil {
+		return err
+	}
+	websocket.SendNotification(userID.String(), string(content))
+
+	return nil
+}
+
+func (u *notificationUsecase) GetMyNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) (*response.NotificationListResponse, error) {
+	notifications, err := u.notificationRepo.GetNotificationsByUser(userID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := u.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.NotificationListResponse{
+		Unread:        unread,
+		Notifications: make([]response.NotificationResponse, 0, len(notifications)),
+	}
+	for _, notification := range notifications {
+		result.Notifications = append(result.Notifications, toNotificationResponse(&notification))
+	}
+
+	return result, nil
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) error {
+	updated, err := u.notificationRepo.MarkRead(notificationID, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("notification not found or already read")
+	}
+	return nil
+}
+
+func (u *notificationUsecase) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return u.notificationRepo.MarkAllRead(userID, time.Now())
+}
+
+func toNotificationResponse(notification *entity.Notification) response.NotificationResponse {
+	return response.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		RefID:     notification.RefID,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
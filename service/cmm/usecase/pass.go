@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IPassUsecase interface {
+	// Owner methods
+	CreatePassProduct(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreatePassProduct) (*response.PassProductResponse, error)
+	DeactivatePassProduct(ctx context.Context, ownerID uuid.UUID, productID uuid.UUID) error
+	GetShopPassProducts(ctx context.Context, shopID uuid.UUID) ([]response.PassProductResponse, error)
+
+	// Customer methods
+	PurchasePass(ctx context.Context, userID uuid.UUID, req request.PurchasePass) (*response.UserPassResponse, error)
+	GetMyPasses(ctx context.Context, userID uuid.UUID) ([]response.UserPassResponse, error)
+	GetMyPass(ctx context.Context, userID uuid.UUID, userPassID uuid.UUID) (*response.UserPassResponse, error)
+
+	// Booking methods
+	ChargeBooking(ctx context.Context, userID uuid.UUID, userPassID uuid.UUID, room *entity.MeetingRoom, booking *entity.Booking) error
+	RestoreBooking(ctx context.Context, bookingID uuid.UUID) error
+}
+
+type passUsecase struct {
+	passRepo       repository.IPassRepo
+	coffeeShopRepo repository.ICoffeeShopRepo
+}
+
+func NewPassUsecase(
+	passRepo repository.IPassRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+) IPassUsecase {
+	return &passUsecase{
+		passRepo:       passRepo,
+		coffeeShopRepo: coffeeShopRepo,
+	}
+}
+
+func (u *passUsecase) CreatePassProduct(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreatePassProduct) (*response.PassProductResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreatePassProduct usecase called")
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	if shop.OwnerID != ownerID {
+		return nil, errors.New("unauthorized to sell passes for this coffee shop")
+	}
+
+	hours := req.Hours
+	if req.Type == entity.PassHours {
+		if hours <= 0 {
+			return nil, errors.New("hours must be greater than 0 for an hours pass")
+		}
+	} else {
+		hours = 0
+	}
+
+	product := &entity.PassProduct{
+		ID:           uuid.New(),
+		CoffeeShopID: shop.ID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  req.Description,
+		Type:         req.Type,
+		Hours:        hours,
+		ValidityDays: req.ValidityDays,
+		Price:        mathutil.RoundToFloat(req.Price, 2),
+		Active:       true,
+		CreatedAt:    time.Now(),
+	}
+	if err := u.passRepo.CreatePassProduct(product); err != nil {
+		return nil, err
+	}
+
+	return toPassProductResponse(product), nil
+}
+
+func (u *passUsecase) DeactivatePassProduct(ctx context.Context, ownerID uuid.UUID, productID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeactivatePassProduct usecase called")
+
+	product, err := u.passRepo.GetPassProductByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("pass not found")
+		}
+		return err
+	}
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(product.CoffeeShopID)
+	if err != nil {
+		return err
+	}
+	if shop.OwnerID != ownerID {
+		return errors.New("unauthorized to update this pass")
+	}
+
+	// Passes already sold stay valid; the product is only taken off sale
+	return u.passRepo.UpdatePassProductActive(productID, false)
+}
+
+func (u *passUsecase) GetShopPassProducts(ctx context.Context, shopID uuid.UUID) ([]response.PassProductResponse, error) {
+	products, err := u.passRepo.GetPassProductsByCoffeeShop(shopID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.PassProductResponse, 0, len(products))
+	for _, product := range products {
+		result = append(result, *toPassProductResponse(&product))
+	}
+
+	return result, nil
+}
+
+func (u *passUsecase) PurchasePass(ctx context.Context, userID uuid.UUID, req request.PurchasePass) (*response.UserPassResponse, error) {
+	log := logger.EnhanceWith(ctx)
+	log.Info("PurchasePass usecase called")
+
+	product, err := u.passRepo.GetPassProductByID(req.PassProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("pass not found")
+		}
+		return nil, err
+	}
+	if !product.Active {
+		return nil, errors.New("pass is no longer on sale")
+	}
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(product.CoffeeShopID)
+	if err != nil {
+		return nil, err
+	}
+	if shop.Status != entity.ShopApproved {
+		return nil, errors.New("coffee shop is not selling passes yet")
+	}
+	ratePercent, err := currentCommissionRate(u.coffeeShopRepo, shop.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userPass := &entity.UserPass{
+		ID:             uuid.New(),
+		UserID:         userID,
+		PassProductID:  product.ID,
+		CoffeeShopID:   product.CoffeeShopID,
+		Name:           product.Name,
+		Type:           product.Type,
+		TotalHours:     product.Hours,
+		RemainingHours: product.Hours,
+		Price:          product.Price,
+		Status:         entity.UserPassActive,
+		StartsAt:       now,
+		ExpiresAt:      now.AddDate(0, 0, product.ValidityDays),
+		PurchasedAt:    now,
+	}
+	transaction := &entity.Transaction{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ServiceID:    entity.ServicePass,
+		ServiceRefID: userPass.ID,
+		Amount:       product.Price,
+		PaidAt:       now,
+		Status:       "completed",
+	}
+
+	// Passes are not refundable, so the shop earns the sale right away
+	earning := newEarning(shop, ratePercent, entity.ServicePass, userPass.ID, userPass.Price, 0, "")
+
+	if err := u.passRepo.PurchasePass(userPass, transaction, earning); err != nil {
+		if errors.Is(err, repository.ErrInsufficientBalance) {
+			return nil, errors.New("insufficient balance")
+		}
+		return nil, err
+	}
+
+	return toUserPassResponse(userPass), nil
+}
+
+func (u *passUsecase) GetMyPasses(ctx context.Context, userID uuid.UUID) ([]response.UserPassResponse, error) {
+	if err := u.passRepo.ExpirePasses(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	userPasses, err := u.passRepo.GetUserPassesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.UserPassResponse, 0, len(userPasses))
+	for _, userPass := range userPasses {
+		result = append(result, *toUserPassResponse(&userPass))
+	}
+
+	return result, nil
+}
+
+func (u *passUsecase) GetMyPass(ctx context.Context, userID uuid.UUID, userPassID uuid.UUID) (*response.UserPassResponse, error) {
+	if err := u.passRepo.ExpirePasses(userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	userPass, err := u.getUserPass(userID, userPassID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := u.passRepo.GetUsagesByUserPass(userPass.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUserPassResponse(userPass)
+	for _, usage := range usages {
+		result.Usages = append(result.Usages, response.PassUsageResponse{
+			ID:        usage.ID,
+			BookingID: usage.BookingID,
+			StartTime: usage.StartTime,
+			EndTime:   usage.EndTime,
+			Hours:     usage.Hours,
+			Status:    usage.Status,
+		})
+	}
+
+	return result, nil
+}
+
+// ChargeBooking creates a booking paid for with a pass of the customer instead of the wallet
+func (u *passUsecase) ChargeBooking(ctx context.Context, userID uuid.UUID, userPassID uuid.UUID, room *entity.MeetingRoom, booking *entity.Booking) error {
+	logger.EnhanceWith(ctx).Info("ChargeBooking usecase called")
+
+	if err := u.passRepo.ExpirePasses(userID, time.Now()); err != nil {
+		return err
+	}
+
+	userPass, err := u.getUserPass(userID, userPassID)
+	if err != nil {
+		return err
+	}
+	if userPass.CoffeeShopID != room.CoffeeShopID {
+		return errors.New("pass is not valid at this coffee shop")
+	}
+	if userPass.Status != entity.UserPassActive {
+		return errors.New("pass has expired")
+	}
+	if booking.EndTime.After(userPass.ExpiresAt) {
+		return errors.New("booking ends after the pass expires")
+	}
+
+	usage := &entity.PassUsage{
+		ID:         uuid.New(),
+		UserPassID: userPass.ID,
+		BookingID:  booking.ID,
+		StartTime:  booking.StartTime,
+		EndTime:    booking.EndTime,
+		Hours:      mathutil.RoundToFloat(booking.EndTime.Sub(booking.StartTime).Hours(), 2),
+		Status:     entity.PassUsageUsed,
+		CreatedAt:  time.Now(),
+	}
+	consumed, err := u.passRepo.ConsumePass(booking, userPass, usage)
+	if err != nil {
+		if errors.Is(err, repository.ErrPassBookingOverlap) {
+			return errors.New("pass is already used for an overlapping booking")
+		}
+		return err
+	}
+	if !consumed {
+		if userPass.Type == entity.PassHours {
+			return errors.New("not enough hours left on the pass")
+		}
+		return errors.New("pass cannot be used for this booking")
+	}
+
+	return nil
+}
+
+// RestoreBooking gives the hours of a cancelled booking back to its pass
+func (u *passUsecase) RestoreBooking(ctx context.Context, bookingID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("RestoreBooking usecase called")
+
+	_, err := u.passRepo.RestorePassUsage(bookingID)
+	return err
+}
+
+func (u *passUsecase) getUserPass(userID uuid.UUID, userPassID uuid.UUID) (*entity.UserPass, error) {
+	userPass, err := u.passRepo.GetUserPassByID(userPassID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("pass not found")
+		}
+		return nil, err
+	}
+	if userPass.UserID != userID {
+		return nil, errors.New("pass not found")
+	}
+	return userPass, nil
+}
+
+func toPassProductResponse(product *entity.PassProduct) *response.PassProductResponse {
+	return &response.PassProductResponse{
+		ID:           product.ID,
+		CoffeeShopID: product.CoffeeShopID,
+		Name:         product.Name,
+		Description:  product.Description,
+		Type:         product.Type,
+		Hours:        product.Hours,
+		ValidityDays: product.ValidityDays,
+		Price:        product.Price,
+		Active:       product.Active,
+		CreatedAt:    product.CreatedAt,
+	}
+}
+
+func toUserPassResponse(userPass *entity.UserPass) *response.UserPassResponse {
+	return &response.UserPassResponse{
+		ID:             userPass.ID,
+		PassProductID:  userPass.PassProductID,
+		CoffeeShopID:   userPass.CoffeeShopID,
+		Name:           userPass.Name,
+		Type:           userPass.Type,
+		TotalHours:     userPass.TotalHours,
+		RemainingHours: userPass.RemainingHours,
+		Price:          userPass.Price,
+		Status:         userPass.Status,
+		StartsAt:       userPass.StartsAt,
+		ExpiresAt:      userPass.ExpiresAt,
+		PurchasedAt:    userPass.PurchasedAt,
+	}
+}
@@ -36,17 +36,23 @@ type postUsecase struct {
 	postRepo       repository.IPostRepo
 	coffeeShopRepo repository.ICoffeeShopRepo
 	userRepo       repository.IUserRepo
+	mediaRepo      repository.IMediaRepo
+	staffRepo      repository.IStaffRepo
 }
 
 func NewPostUsecase(
 	postRepo repository.IPostRepo,
 	coffeeShopRepo repository.ICoffeeShopRepo,
 	userRepo repository.IUserRepo,
+	mediaRepo repository.IMediaRepo,
+	staffRepo repository.IStaffRepo,
 ) IPostUsecase {
 	return &postUsecase{
 		postRepo:       postRepo,
 		coffeeShopRepo: coffeeShopRepo,
 		userRepo:       userRepo,
+		mediaRepo:      mediaRepo,
+		staffRepo:      staffRepo,
 	}
 }
 
@@ -63,7 +69,11 @@ func (u *postUsecase) CreateShopPost(ctx context.Context, ownerID uuid.UUID, req
 		return nil, err
 	}
 
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageMenu)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
 		return nil, errors.New("unauthorized to create post for this coffee shop")
 	}
 
@@ -104,6 +114,11 @@ func (u *postUsecase) GetShopPost(ctx context.Context, postID uuid.UUID) (*respo
 		shopName = shop.Name
 	}
 
+	media, err := u.mediaRepo.GetMediaByOwner(entity.MediaOwnerShopPost, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &response.ShopPostResponse{
 		ID:           post.ID,
 		CoffeeShopID: post.CoffeeShopID,
@@ -111,6 +126,7 @@ func (u *postUsecase) GetShopPost(ctx context.Context, postID uuid.UUID) (*respo
 		Title:        post.Title,
 		Content:      post.Content,
 		PublishedAt:  post.PublishedAt,
+		Media:        toMediaResponses(media),
 	}, nil
 }
 
@@ -182,7 +198,11 @@ func (u *postUsecase) UpdateShopPost(ctx context.Context, ownerID uuid.UUID, req
 	if err != nil {
 		return err
 	}
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageMenu)
+	if err != nil {
+		return err
+	}
+	if !allowed {
 		return errors.New("unauthorized to update this post")
 	}
 
@@ -210,7 +230,11 @@ func (u *postUsecase) DeleteShopPost(ctx context.Context, ownerID uuid.UUID, pos
 	if err != nil {
 		return err
 	}
-	if shop.OwnerID != ownerID {
+	allowed, err := authorizeShop(u.staffRepo, shop, ownerID, entity.PermissionManageMenu)
+	if err != nil {
+		return err
+	}
+	if !allowed {
 		return errors.New("unauthorized to delete this post")
 	}
 
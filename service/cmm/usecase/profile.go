@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/mailer"
+	"github.com/leehai1107/cmm_server/pkg/storage"
+	"github.com/leehai1107/cmm_server/pkg/utils/imageutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// emailChangeTTL is how long an email change confirmation stays valid
+	emailChangeTTL = 24 * time.Hour
+	// avatarWidth is the width avatars are resized to
+	avatarWidth = 256
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+
+type IProfileUsecase interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*response.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req request.UpdateProfile) (*response.ProfileResponse, error)
+	UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*response.ProfileResponse, error)
+	ChangePassword(ctx context.Context, userID uuid.UUID, req request.ChangePassword) error
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, req request.RequestEmailChange) error
+	ConfirmEmailChange(ctx context.Context, req request.ConfirmEmailChange) error
+}
+
+type profileUsecase struct {
+	userRepo      repository.IUserRepo
+	userTokenRepo repository.IUserTokenRepo
+	storage       storage.Storage
+	mailer        mailer.Mailer
+	mailCfg       config.MailCfg
+}
+
+func NewProfileUsecase(
+	userRepo repository.IUserRepo,
+	userTokenRepo repository.IUserTokenRepo,
+	storage storage.Storage,
+	mailer mailer.Mailer,
+	mailCfg config.MailCfg,
+) IProfileUsecase {
+	return &profileUsecase{
+		userRepo:      userRepo,
+		userTokenRepo: userTokenRepo,
+		storage:       storage,
+		mailer:        mailer,
+		mailCfg:       mailCfg,
+	}
+}
+
+func (u *profileUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*response.ProfileResponse, error) {
+	user, err := u.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.toProfileResponse(user)
+}
+
+func (u *profileUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req request.UpdateProfile) (*response.ProfileResponse, error) {
+	logger.EnhanceWith(ctx).Info("UpdateProfile usecase called")
+
+	user, err := u.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != nil {
+		fullName := strings.TrimSpace(*req.FullName)
+		if fullName == "" {
+			return nil, errors.New("full name cannot be empty")
+		}
+		user.FullName = fullName
+	}
+	if req.Phone != nil {
+		phone := strings.ReplaceAll(strings.TrimSpace(*req.Phone), " ", "")
+		if phone != "" && !phonePattern.MatchString(phone) {
+			return nil, errors.New("invalid phone number")
+		}
+		user.Phone = phone
+	}
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" && !strings.HasPrefix(avatarURL, "https://") && !strings.HasPrefix(avatarURL, "http://") {
+			return nil, errors.New("avatar url must be an http or https url")
+		}
+		u.deleteAvatar(ctx, user)
+		user.AvatarURL = avatarURL
+	}
+	if req.PreferredLanguage != nil {
+		user.PreferredLanguage = *req.PreferredLanguage
+	}
+
+	if err := u.userRepo.UpdateUserProfile(user); err != nil {
+		return nil, err
+	}
+	return u.toProfileResponse(user)
+}
+
+// UploadAvatar stores a resized copy of the image as the user's avatar
+func (u *profileUsecase) UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*response.ProfileResponse, error) {
+	logger.EnhanceWith(ctx).Info("UploadAvatar usecase called")
+
+	cfg := config.StorageConfig()
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if int64(len(data)) > cfg.MaxUploadBytes {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", cfg.MaxUploadBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return nil, errors.New("unsupported file type, only JPEG, PNG and GIF images are allowed")
+	}
+
+	user, err := u.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imageutil.Decode(data, cfg.MaxPixels)
+	if errors.Is(err, imageutil.ErrTooManyPixels) {
+		return nil, fmt.Errorf("image exceeds the %d pixel limit", cfg.MaxPixels)
+	}
+	if err != nil {
+		return nil, errors.New("invalid image file")
+	}
+	avatar, err := imageutil.Thumbnail(img, avatarWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new key per upload keeps cached copies of the old avatar from being served
+	key := fmt.Sprintf("%s%s.jpg", avatarPrefix(userID), uuid.New())
+	if err := u.storage.Put(ctx, key, avatar, "image/jpeg"); err != nil {
+		return nil, err
+	}
+
+	u.deleteAvatar(ctx, user)
+	user.AvatarURL = u.storage.URL(key)
+	if err := u.userRepo.UpdateUserProfile(user); err != nil {
+		if deleteErr := u.storage.Delete(ctx, key); deleteErr != nil {
+			logger.EnhanceWith(ctx).Errorw("Failed to delete stored avatar", "key", key, "error", deleteErr)
+		}
+		return nil, err
+	}
+	return u.toProfileResponse(user)
+}
+
+func (u *profileUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, req request.ChangePassword) error {
+	logger.EnhanceWith(ctx).Info("ChangePassword usecase called")
+
+	user, err := u.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return errors.New("current password is incorrect")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return errors.New("new password must be different from the current password")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return u.userRepo.UpdatePassword(user.ID, string(hashedPassword))
+}
+
+// RequestEmailChange sends a confirmation token to the new address. The email is only
+// changed once the token is confirmed; requesting again invalidates the previous token.
+func (u *profileUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, req request.RequestEmailChange) error {
+	logger.EnhanceWith(ctx).Info("RequestEmailChange usecase called")
+
+	user, err := u.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return errors.New("current password is incorrect")
+	}
+	if strings.EqualFold(req.NewEmail, user.Email) {
+		return errors.New("new email is the same as the current email")
+	}
+
+	_, err = u.userRepo.GetUserByEmail(req.NewEmail)
+	if err == nil {
+		return errors.New("email already registered")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := u.userTokenRepo.DeleteUserTokens(user.ID, entity.TokenEmailChange); err != nil {
+		return err
+	}
+	token, plain, err := newUserToken(user.ID, entity.TokenEmailChange, req.NewEmail, emailChangeTTL)
+	if err != nil {
+		return err
+	}
+	if err := u.userTokenRepo.CreateUserToken(token); err != nil {
+		return err
+	}
+
+	if err := sendUserTokenEmail(ctx, u.mailer, u.mailCfg.AppURL, user, token, plain, mailer.TemplateChangeEmail, confirmEmailPath); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send email change confirmation", "user_id", user.ID, "error", err)
+		return errors.New("failed to send confirmation email, please try again")
+	}
+	return nil
+}
+
+// ConfirmEmailChange switches the account to the address the token was sent to
+func (u *profileUsecase) ConfirmEmailChange(ctx context.Context, req request.ConfirmEmailChange) error {
+	logger.EnhanceWith(ctx).Info("ConfirmEmailChange usecase called")
+
+	token, err := u.userTokenRepo.ConsumeUserToken(hashUserToken(req.Token), entity.TokenEmailChange, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired token")
+		}
+		return err
+	}
+
+	// The address may have been registered by someone else since the change was requested
+	existing, err := u.userRepo.GetUserByEmail(token.Email)
+	if err == nil && existing.ID != token.UserID {
+		return errors.New("email already registered")
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return u.userRepo.UpdateEmail(token.UserID, token.Email, time.Now())
+}
+
+func (u *profileUsecase) getUser(userID uuid.UUID) (*entity.User, error) {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// deleteAvatar removes the current avatar from storage if it was uploaded here rather than linked
+func (u *profileUsecase) deleteAvatar(ctx context.Context, user *entity.User) {
+	prefixURL := u.storage.URL(avatarPrefix(user.ID))
+	if user.AvatarURL == "" || !strings.HasPrefix(user.AvatarURL, prefixURL) {
+		return
+	}
+	key := avatarPrefix(user.ID) + strings.TrimPrefix(user.AvatarURL, prefixURL)
+	if err := u.storage.Delete(ctx, key); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to delete stored avatar", "key", key, "error", err)
+	}
+}
+
+func avatarPrefix(userID uuid.UUID) string {
+	return fmt.Sprintf("avatars/%s/", userID)
+}
+
+func (u *profileUsecase) toProfileResponse(user *entity.User) (*response.ProfileResponse, error) {
+	result := &response.ProfileResponse{
+		ID:                user.ID,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		Phone:             user.Phone,
+		AvatarURL:         user.AvatarURL,
+		PreferredLanguage: user.PreferredLanguage,
+		EmailVerified:     user.EmailVerifiedAt != nil,
+		Role:              string(user.Role),
+		ReferralCode:      user.ReferralCode,
+		CreatedAt:         user.CreatedAt,
+	}
+
+	pending, err := u.userTokenRepo.GetPendingUserToken(user.ID, entity.TokenEmailChange, time.Now())
+	if err == nil {
+		result.PendingEmail = pending.Email
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/tools/random"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	referralCodeLength = 8
+	// maxReferralCodeAttempts bounds the retries when a generated referral code is taken
+	maxReferralCodeAttempts = 5
+)
+
+type IReferralUsecase interface {
+	NewReferralCode(ctx context.Context) (string, error)
+	ResolveReferrer(ctx context.Context, code string, refereeEmail string) (*entity.User, error)
+	RecordReferral(ctx context.Context, referee *entity.User) error
+	RewardReferral(ctx context.Context, refereeID uuid.UUID, serviceID entity.ServiceType, serviceRefID uuid.UUID, amount float64) error
+	GetMyReferrals(ctx context.Context, userID uuid.UUID) (*response.ReferralSummaryResponse, error)
+}
+
+type referralUsecase struct {
+	referralRepo repository.IReferralRepo
+	userRepo     repository.IUserRepo
+	cfg          config.ReferralCfg
+}
+
+func NewReferralUsecase(
+	referralRepo repository.IReferralRepo,
+	userRepo repository.IUserRepo,
+	cfg config.ReferralCfg,
+) IReferralUsecase {
+	return &referralUsecase{
+		referralRepo: referralRepo,
+		userRepo:     userRepo,
+		cfg:          cfg,
+	}
+}
+
+// NewReferralCode returns a referral code that no user has yet
+func (u *referralUsecase) NewReferralCode(ctx context.Context) (string, error) {
+	for i := 0; i < maxReferralCodeAttempts; i++ {
+		code, err := random.RandFromAlphabet(defaultCodeAlphabet, referralCodeLength)
+		if err != nil {
+			return "", err
+		}
+		_, err = u.userRepo.GetUserByReferralCode(code)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return code, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", errors.New("could not generate a referral code")
+}
+
+// ResolveReferrer finds the owner of a referral code and rejects codes used on the referrer's own email
+func (u *referralUsecase) ResolveReferrer(ctx context.Context, code string, refereeEmail string) (*entity.User, error) {
+	referrer, err := u.userRepo.GetUserByReferralCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid referral code")
+		}
+		return nil, err
+	}
+
+	if normalizeEmail(referrer.Email) == normalizeEmail(refereeEmail) {
+		return nil, errors.New("cannot use your own referral code")
+	}
+
+	return referrer, nil
+}
+
+// RecordReferral stores a pending referral for a newly registered user
+func (u *referralUsecase) RecordReferral(ctx context.Context, referee *entity.User) error {
+	if referee.ReferredBy == nil {
+		return nil
+	}
+	if *referee.ReferredBy == referee.ID {
+		return errors.New("cannot use your own referral code")
+	}
+
+	return u.referralRepo.CreateReferral(&entity.Referral{
+		ID:         uuid.New(),
+		ReferrerID: *referee.ReferredBy,
+		RefereeID:  referee.ID,
+		Status:     entity.ReferralPending,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// RewardReferral credits both users once the referee's first top-up or booking of at least
+// MinQualifyingAmount has cleared. Smaller payments leave the referral pending, while later
+// payments, users without a referral and referrers over the cap are ignored.
+func (u *referralUsecase) RewardReferral(ctx context.Context, refereeID uuid.UUID, serviceID entity.ServiceType, serviceRefID uuid.UUID, amount float64) error {
+	log := logger.EnhanceWith(ctx)
+
+	if amount < u.cfg.MinQualifyingAmount {
+		return nil
+	}
+
+	referral, err := u.referralRepo.GetReferralByReferee(refereeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if referral.Status != entity.ReferralPending {
+		return nil
+	}
+
+	referral.TriggerServiceID = serviceID
+	referral.TriggerRefID = &serviceRefID
+	referral.ReferrerReward = u.cfg.ReferrerReward
+	referral.RefereeReward = u.cfg.RefereeReward
+
+	now := time.Now()
+	credits := appendRewardCredit(nil, referral.ReferrerID, referral.ID, referral.ReferrerReward, now)
+	credits = appendRewardCredit(credits, referral.RefereeID, referral.ID, referral.RefereeReward, now)
+
+	// The repository stops paying a referrer once they reach the cap
+	claimed, err := u.referralRepo.ClaimReferral(referral, u.cfg.MaxRewardsPerReferrer, credits, now)
+	if err != nil {
+		return err
+	}
+	if claimed && referral.Status == entity.ReferralRewarded {
+		log.Infow("Rewarded referral", "referral_id", referral.ID, "referrer_id", referral.ReferrerID, "referee_id", referral.RefereeID)
+	}
+	return nil
+}
+
+func (u *referralUsecase) GetMyReferrals(ctx context.Context, userID uuid.UUID) (*response.ReferralSummaryResponse, error) {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	referrals, err := u.referralRepo.GetReferralsByReferrer(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.ReferralSummaryResponse{
+		Referred:  len(referrals),
+		Referrals: make([]response.ReferralResponse, 0, len(referrals)),
+	}
+	if user.ReferralCode != nil {
+		result.ReferralCode = *user.ReferralCode
+	}
+	for _, referral := range referrals {
+		switch referral.Status {
+		case entity.ReferralRewarded:
+			result.Rewarded++
+			result.TotalEarned += referral.ReferrerReward
+		case entity.ReferralPending:
+			result.Pending++
+		}
+		result.Referrals = append(result.Referrals, response.ReferralResponse{
+			ID:             referral.ID,
+			RefereeID:      referral.RefereeID,
+			Status:         referral.Status,
+			ReferrerReward: referral.ReferrerReward,
+			CreatedAt:      referral.CreatedAt,
+			RewardedAt:     referral.RewardedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// appendRewardCredit adds the wallet credit of a referral reward, skipping empty rewards
+func appendRewardCredit(credits []entity.Transaction, userID uuid.UUID, referralID uuid.UUID, amount float64, paidAt time.Time) []entity.Transaction {
+	if amount <= 0 {
+		return credits
+	}
+	return append(credits, entity.Transaction{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ServiceID:    entity.ServiceReferral,
+		ServiceRefID: referralID,
+		Amount:       amount,
+		PaidAt:       paidAt,
+		Status:       "completed",
+	})
+}
+
+// normalizeEmail lower-cases an email and drops any "+tag" so aliases of one inbox compare equal
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return email
+	}
+	if i := strings.IndexByte(local, '+'); i >= 0 {
+		local = local[:i]
+	}
+	return local + "@" + domain
+}
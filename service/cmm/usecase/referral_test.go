@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+// fakeReferralRepo holds a single pending referral and counts claims. Methods the tests do
+// not need are left to the embedded interface.
+type fakeReferralRepo struct {
+	repository.IReferralRepo
+	referral *entity.Referral
+	claims   int
+}
+
+func (r *fakeReferralRepo) GetReferralByReferee(refereeID uuid.UUID) (*entity.Referral, error) {
+	return r.referral, nil
+}
+
+func (r *fakeReferralRepo) ClaimReferral(referral *entity.Referral, maxRewards int, credits []entity.Transaction, claimedAt time.Time) (bool, error) {
+	r.claims++
+	referral.Status = entity.ReferralRewarded
+	return true, nil
+}
+
+func TestRewardReferralMinQualifyingAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     float64
+		wantClaims int
+	}{
+		{name: "below the minimum", amount: 49999, wantClaims: 0},
+		{name: "at the minimum", amount: 50000, wantClaims: 1},
+		{name: "above the minimum", amount: 200000, wantClaims: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refereeID := uuid.New()
+			repo := &fakeReferralRepo{referral: &entity.Referral{
+				ID:         uuid.New(),
+				ReferrerID: uuid.New(),
+				RefereeID:  refereeID,
+				Status:     entity.ReferralPending,
+			}}
+			u := NewReferralUsecase(repo, nil, config.ReferralCfg{
+				ReferrerReward:        50000,
+				RefereeReward:         20000,
+				MaxRewardsPerReferrer: 20,
+				MinQualifyingAmount:   50000,
+			})
+
+			if err := u.RewardReferral(context.Background(), refereeID, entity.ServiceTopup, uuid.New(), tt.amount); err != nil {
+				t.Fatalf("RewardReferral() error = %v", err)
+			}
+			if repo.claims != tt.wantClaims {
+				t.Errorf("RewardReferral(%v) claimed %d times, want %d", tt.amount, repo.claims, tt.wantClaims)
+			}
+		})
+	}
+}
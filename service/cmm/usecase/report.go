@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+const (
+	// defaultReportLimit is the row count of ranked reports when no limit is given
+	defaultReportLimit = 10
+	// maxReportRange bounds the period a report aggregates over, so one request cannot scan
+	// the whole history
+	maxReportRange = 366 * 24 * time.Hour
+)
+
+type IReportUsecase interface {
+	GetPlatformOverview(ctx context.Context, req request.ReportQuery) (*response.PlatformOverviewResponse, error)
+	GetTopShops(ctx context.Context, req request.ReportQuery) ([]response.TopShopResponse, error)
+	GetTopupVolume(ctx context.Context, req request.ReportQuery) ([]response.TopupMethodResponse, error)
+}
+
+type reportUsecase struct {
+	analyticsRepo repository.IAnalyticsRepo
+}
+
+func NewReportUsecase(analyticsRepo repository.IAnalyticsRepo) IReportUsecase {
+	return &reportUsecase{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+func (u *reportUsecase) GetPlatformOverview(ctx context.Context, req request.ReportQuery) (*response.PlatformOverviewResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetPlatformOverview usecase called")
+
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.analyticsRepo.GetPlatformOverview(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PlatformOverviewResponse{
+		From:             req.From,
+		To:               req.To,
+		GMV:              mathutil.RoundToFloat(stats.GMV, 2),
+		NetRevenue:       mathutil.RoundToFloat(stats.NetRevenue, 2),
+		Bookings:         stats.Bookings,
+		RefundRate:       mathutil.Percent(float64(stats.CancelledBookings), float64(stats.Bookings), 2),
+		RefundAmount:     mathutil.RoundToFloat(stats.RefundAmount, 2),
+		VoucherCost:      mathutil.RoundToFloat(stats.VoucherCost, 2),
+		ActiveUsers:      stats.ActiveUsers,
+		NewRegistrations: stats.NewRegistrations,
+		TopupVolume:      mathutil.RoundToFloat(stats.TopupVolume, 2),
+	}, nil
+}
+
+func (u *reportUsecase) GetTopShops(ctx context.Context, req request.ReportQuery) ([]response.TopShopResponse, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultReportLimit
+	}
+
+	stats, err := u.analyticsRepo.GetTopShops(req.From, req.To, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.TopShopResponse, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, response.TopShopResponse{
+			CoffeeShopID: stat.CoffeeShopID,
+			ShopName:     stat.ShopName,
+			Bookings:     stat.Bookings,
+			GMV:          mathutil.RoundToFloat(stat.GMV, 2),
+			VoucherCost:  mathutil.RoundToFloat(stat.VoucherCost, 2),
+		})
+	}
+
+	return result, nil
+}
+
+func (u *reportUsecase) GetTopupVolume(ctx context.Context, req request.ReportQuery) ([]response.TopupMethodResponse, error) {
+	if err := validateReportRange(req); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.analyticsRepo.GetTopupVolumeByMethod(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.TopupMethodResponse, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, response.TopupMethodResponse{
+			Method: stat.Method,
+			Count:  stat.Count,
+			Amount: mathutil.RoundToFloat(stat.Amount, 2),
+		})
+	}
+
+	return result, nil
+}
+
+func validateReportRange(req request.ReportQuery) error {
+	if !req.To.After(req.From) {
+		return errors.New("to must be after from")
+	}
+	if req.To.Sub(req.From) > maxReportRange {
+		return fmt.Errorf("report range cannot be longer than %d days", int(maxReportRange.Hours()/24))
+	}
+	return nil
+}
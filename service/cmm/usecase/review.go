@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IReviewUsecase interface {
+	// Customer methods
+	CreateReview(ctx context.Context, customerID uuid.UUID, req request.CreateReview) (*response.ReviewResponse, error)
+	GetMyReviews(ctx context.Context, customerID uuid.UUID) ([]response.ReviewResponse, error)
+
+	// Public methods
+	GetShopReviews(ctx context.Context, shopID uuid.UUID) ([]response.ReviewResponse, error)
+	GetRoomReviews(ctx context.Context, roomID uuid.UUID) ([]response.ReviewResponse, error)
+
+	// Owner methods
+	ReplyToReview(ctx context.Context, ownerID uuid.UUID, reviewID uuid.UUID, req request.ReplyToReview) error
+
+	// Admin methods
+	GetReviews(ctx context.Context, status string) ([]response.ReviewResponse, error)
+	HideReview(ctx context.Context, adminID uuid.UUID, reviewID uuid.UUID, req request.HideReview) error
+	RestoreReview(ctx context.Context, adminID uuid.UUID, reviewID uuid.UUID) error
+}
+
+type reviewUsecase struct {
+	reviewRepo          repository.IReviewRepo
+	bookingRepo         repository.IBookingRepo
+	meetingRoomRepo     repository.IMeetingRoomRepo
+	coffeeShopRepo      repository.ICoffeeShopRepo
+	notificationUsecase INotificationUsecase
+}
+
+func NewReviewUsecase(
+	reviewRepo repository.IReviewRepo,
+	bookingRepo repository.IBookingRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	notificationUsecase INotificationUsecase,
+) IReviewUsecase {
+	return &reviewUsecase{
+		reviewRepo:          reviewRepo,
+		bookingRepo:         bookingRepo,
+		meetingRoomRepo:     meetingRoomRepo,
+		coffeeShopRepo:      coffeeShopRepo,
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// CreateReview rates a booking. Only the customer of a completed booking can review it, once.
+func (u *reviewUsecase) CreateReview(ctx context.Context, customerID uuid.UUID, req request.CreateReview) (*response.ReviewResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreateReview usecase called")
+
+	booking, err := u.bookingRepo.GetBookingByID(req.BookingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking not found")
+		}
+		return nil, err
+	}
+	if booking.CustomerID != customerID {
+		return nil, errors.New("unauthorized to review this booking")
+	}
+	if booking.Status != "completed" {
+		return nil, errors.New("only completed bookings can be reviewed")
+	}
+
+	if _, err := u.reviewRepo.GetReviewByBooking(booking.ID); err == nil {
+		return nil, errors.New("booking already reviewed")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	room, err := u.meetingRoomRepo.GetMeetingRoomByID(booking.MeetingRoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &entity.Review{
+		ID:            uuid.New(),
+		BookingID:     booking.ID,
+		CustomerID:    customerID,
+		CoffeeShopID:  room.CoffeeShopID,
+		MeetingRoomID: room.ID,
+		Rating:        req.Rating,
+		Comment:       req.Comment,
+		Status:        entity.ReviewPublished,
+		CreatedAt:     time.Now(),
+	}
+	if err := u.reviewRepo.CreateReview(review); err != nil {
+		return nil, err
+	}
+
+	if shop, err := u.coffeeShopRepo.GetCoffeeShopByID(room.CoffeeShopID); err == nil {
+		u.notify(ctx, shop.OwnerID, review, "New review",
+			fmt.Sprintf("%s received a %d-star review for %s", shop.Name, review.Rating, room.Name))
+	}
+
+	return toReviewResponse(review), nil
+}
+
+func (u *reviewUsecase) GetMyReviews(ctx context.Context, customerID uuid.UUID) ([]response.ReviewResponse, error) {
+	reviews, err := u.reviewRepo.GetReviewsByCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponses(reviews), nil
+}
+
+func (u *reviewUsecase) GetShopReviews(ctx context.Context, shopID uuid.UUID) ([]response.ReviewResponse, error) {
+	reviews, err := u.reviewRepo.GetReviewsByCoffeeShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponses(reviews), nil
+}
+
+func (u *reviewUsecase) GetRoomReviews(ctx context.Context, roomID uuid.UUID) ([]response.ReviewResponse, error) {
+	reviews, err := u.reviewRepo.GetReviewsByMeetingRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponses(reviews), nil
+}
+
+// ReplyToReview sets the owner's public reply, replacing any earlier one
+func (u *reviewUsecase) ReplyToReview(ctx context.Context, ownerID uuid.UUID, reviewID uuid.UUID, req request.ReplyToReview) error {
+	logger.EnhanceWith(ctx).Info("ReplyToReview usecase called")
+
+	review, err := u.getReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(review.CoffeeShopID)
+	if err != nil {
+		return err
+	}
+	if shop.OwnerID != ownerID {
+		return errors.New("unauthorized to reply to this review")
+	}
+
+	if err := u.reviewRepo.ReplyToReview(review.ID, req.Reply, time.Now()); err != nil {
+		return err
+	}
+
+	u.notify(ctx, review.CustomerID, review, "Reply to your review",
+		fmt.Sprintf("%s replied to your review", shop.Name))
+	return nil
+}
+
+func (u *reviewUsecase) GetReviews(ctx context.Context, status string) ([]response.ReviewResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetReviews usecase called")
+
+	if status != "" && status != entity.ReviewPublished && status != entity.ReviewHidden {
+		return nil, errors.New("invalid review status")
+	}
+
+	reviews, err := u.reviewRepo.GetReviews(status)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponses(reviews), nil
+}
+
+// HideReview takes a review out of public listings and rating aggregates
+func (u *reviewUsecase) HideReview(ctx context.Context, adminID uuid.UUID, reviewID uuid.UUID, req request.HideReview) error {
+	logger.EnhanceWith(ctx).Info("HideReview usecase called")
+
+	review, err := u.getReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := u.reviewRepo.SetReviewStatus(review.ID, entity.ReviewHidden, req.Reason, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("review is already hidden")
+	}
+
+	u.notify(ctx, review.CustomerID, review, "Review hidden",
+		fmt.Sprintf("Your review has been hidden by a moderator: %s", req.Reason))
+	return nil
+}
+
+func (u *reviewUsecase) RestoreReview(ctx context.Context, adminID uuid.UUID, reviewID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("RestoreReview usecase called")
+
+	review, err := u.getReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := u.reviewRepo.SetReviewStatus(review.ID, entity.ReviewPublished, "", adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("review is already published")
+	}
+	return nil
+}
+
+func (u *reviewUsecase) getReview(reviewID uuid.UUID) (*entity.Review, error) {
+	review, err := u.reviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+	return review, nil
+}
+
+func (u *reviewUsecase) notify(ctx context.Context, userID uuid.UUID, review *entity.Review, title string, body string) {
+	if err := u.notificationUsecase.Notify(ctx, userID, entity.NotificationReview, title, body, &review.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send review notification", "error", err)
+	}
+}
+
+func toReviewResponse(review *entity.Review) *response.ReviewResponse {
+	return &response.ReviewResponse{
+		ID:            review.ID,
+		BookingID:     review.BookingID,
+		CustomerID:    review.CustomerID,
+		CoffeeShopID:  review.CoffeeShopID,
+		MeetingRoomID: review.MeetingRoomID,
+		Rating:        review.Rating,
+		Comment:       review.Comment,
+		Status:        review.Status,
+		OwnerReply:    review.OwnerReply,
+		RepliedAt:     review.RepliedAt,
+		HiddenReason:  review.HiddenReason,
+		CreatedAt:     review.CreatedAt,
+	}
+}
+
+func toReviewResponses(reviews []entity.Review) []response.ReviewResponse {
+	result := make([]response.ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		result = append(result, *toReviewResponse(&review))
+	}
+	return result
+}
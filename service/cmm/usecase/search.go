@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+type ISearchUsecase interface {
+	Search(ctx context.Context, req request.Search) (*response.SearchResponse, error)
+}
+
+type searchUsecase struct {
+	searchRepo repository.ISearchRepo
+}
+
+func NewSearchUsecase(searchRepo repository.ISearchRepo) ISearchUsecase {
+	return &searchUsecase{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search runs a ranked full-text query over shops, rooms and posts, or only the requested kind
+func (u *searchUsecase) Search(ctx context.Context, req request.Search) (*response.SearchResponse, error) {
+	logger.EnhanceWith(ctx).Info("Search usecase called")
+
+	query := strings.TrimSpace(req.Q)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	result := &response.SearchResponse{}
+
+	if req.Type == "" || req.Type == "shops" {
+		shops, err := u.searchRepo.SearchCoffeeShops(query, limit)
+		if err != nil {
+			return nil, err
+		}
+		result.Shops = make([]response.ShopSearchResponse, 0, len(shops))
+		for _, shop := range shops {
+			result.Shops = append(result.Shops, response.ShopSearchResponse{
+				CoffeeShopResponse: *toCoffeeShopResponse(&shop.CoffeeShop),
+				Rank:               shop.Rank,
+				Headline:           shop.Headline,
+			})
+		}
+	}
+
+	if req.Type == "" || req.Type == "rooms" {
+		rooms, err := u.searchRepo.SearchMeetingRooms(query, limit)
+		if err != nil {
+			return nil, err
+		}
+		result.Rooms = make([]response.RoomSearchResponse, 0, len(rooms))
+		for _, room := range rooms {
+			result.Rooms = append(result.Rooms, response.RoomSearchResponse{
+				MeetingRoomResponse: *toMeetingRoomResponse(&room.MeetingRoom, room.ShopName),
+				Rank:                room.Rank,
+			})
+		}
+	}
+
+	if req.Type == "" || req.Type == "posts" {
+		posts, err := u.searchRepo.SearchShopPosts(query, limit)
+		if err != nil {
+			return nil, err
+		}
+		result.Posts = make([]response.PostSearchResponse, 0, len(posts))
+		for _, post := range posts {
+			result.Posts = append(result.Posts, response.PostSearchResponse{
+				ShopPostResponse: response.ShopPostResponse{
+					ID:           post.ID,
+					CoffeeShopID: post.CoffeeShopID,
+					ShopName:     post.ShopName,
+					Title:        post.Title,
+					Content:      post.Content,
+					PublishedAt:  post.PublishedAt,
+				},
+				Rank:     post.Rank,
+				Headline: post.Headline,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+)
+
+type ISecurityUsecase interface {
+	GetSecurityEvents(ctx context.Context, req request.SecurityEventQuery) (*response.SecurityEventListResponse, error)
+	ReviewSecurityEvent(ctx context.Context, adminID uuid.UUID, eventID uuid.UUID) error
+}
+
+type securityUsecase struct {
+	loginSecurityRepo repository.ILoginSecurityRepo
+}
+
+func NewSecurityUsecase(
+	loginSecurityRepo repository.ILoginSecurityRepo,
+) ISecurityUsecase {
+	return &securityUsecase{
+		loginSecurityRepo: loginSecurityRepo,
+	}
+}
+
+// GetSecurityEvents pages through lockouts and new-IP logins, newest first
+func (u *securityUsecase) GetSecurityEvents(ctx context.Context, req request.SecurityEventQuery) (*response.SecurityEventListResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetSecurityEvents usecase called")
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+	filter := repository.SecurityEventFilter{
+		Type:       req.Type,
+		Unreviewed: req.Unreviewed,
+		// Fetch one extra event to tell whether there is a next page
+		Limit:  limit + 1,
+		Offset: req.Offset,
+	}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return nil, errors.New("invalid user ID")
+		}
+		filter.UserID = &userID
+	}
+
+	events, err := u.loginSecurityRepo.GetSecurityEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	result := &response.SecurityEventListResponse{
+		Events:  make([]response.SecurityEventResponse, 0, len(events)),
+		Limit:   limit,
+		Offset:  req.Offset,
+		HasMore: hasMore,
+	}
+	for _, event := range events {
+		result.Events = append(result.Events, response.SecurityEventResponse{
+			ID:          event.ID,
+			Type:        event.Type,
+			UserID:      event.UserID,
+			FullName:    event.FullName,
+			Email:       event.Email,
+			IP:          event.IP,
+			UserAgent:   event.UserAgent,
+			Failures:    event.Failures,
+			LockedUntil: event.LockedUntil,
+			ReviewedBy:  event.ReviewedBy,
+			ReviewedAt:  event.ReviewedAt,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// ReviewSecurityEvent marks an event as reviewed by the admin
+func (u *securityUsecase) ReviewSecurityEvent(ctx context.Context, adminID uuid.UUID, eventID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("ReviewSecurityEvent usecase called")
+
+	reviewed, err := u.loginSecurityRepo.ReviewSecurityEvent(eventID, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !reviewed {
+		return errors.New("security event not found or already reviewed")
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+// defaultCommissionRatePercent applies to shops without a configured commission rate
+const defaultCommissionRatePercent = 0
+
+type ISettlementUsecase interface {
+	RunSettlement(ctx context.Context, adminID uuid.UUID, req request.RunSettlement) (*response.SettlementResponse, error)
+	GetSettlement(ctx context.Context, adminID uuid.UUID, settlementID uuid.UUID) (*response.SettlementResponse, error)
+	GetAllSettlements(ctx context.Context, adminID uuid.UUID) ([]response.SettlementResponse, error)
+	GetPayoutStatement(ctx context.Context, adminID uuid.UUID, statementID uuid.UUID) (*response.PayoutStatementResponse, error)
+	GetOwnerPayoutStatements(ctx context.Context, ownerID uuid.UUID) ([]response.PayoutStatementResponse, error)
+	GetOwnerPayoutStatement(ctx context.Context, ownerID uuid.UUID, statementID uuid.UUID) (*response.PayoutStatementResponse, error)
+	MarkStatementPaid(ctx context.Context, adminID uuid.UUID, statementID uuid.UUID) error
+}
+
+type settlementUsecase struct {
+	settlementRepo repository.ISettlementRepo
+	userRepo       repository.IUserRepo
+}
+
+func NewSettlementUsecase(
+	settlementRepo repository.ISettlementRepo,
+	userRepo repository.IUserRepo,
+) ISettlementUsecase {
+	return &settlementUsecase{
+		settlementRepo: settlementRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (u *settlementUsecase) RunSettlement(ctx context.Context, adminID uuid.UUID, req request.RunSettlement) (*response.SettlementResponse, error) {
+	logger.EnhanceWith(ctx).Info("RunSettlement usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+	if !req.PeriodEnd.After(req.PeriodStart) {
+		return nil, errors.New("period_end must be after period_start")
+	}
+
+	earnings, err := u.settlementRepo.GetUnsettledEarnings(req.PeriodStart, req.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	if len(earnings) == 0 {
+		return nil, errors.New("no unsettled earnings in this period")
+	}
+
+	settlement := &entity.Settlement{
+		ID:          uuid.New(),
+		PeriodStart: req.PeriodStart,
+		PeriodEnd:   req.PeriodEnd,
+		CreatedBy:   adminID,
+		CreatedAt:   time.Now(),
+	}
+
+	// Group earnings into one statement per owner
+	statementByOwner := make(map[uuid.UUID]*entity.PayoutStatement)
+	statementEarnings := make(map[uuid.UUID][]uuid.UUID)
+	var owners []uuid.UUID
+	for _, earning := range earnings {
+		statement, ok := statementByOwner[earning.OwnerID]
+		if !ok {
+			statement = &entity.PayoutStatement{
+				ID:           uuid.New(),
+				SettlementID: settlement.ID,
+				OwnerID:      earning.OwnerID,
+				Status:       "pending",
+				CreatedAt:    settlement.CreatedAt,
+			}
+			statementByOwner[earning.OwnerID] = statement
+			owners = append(owners, earning.OwnerID)
+		}
+		statement.GrossAmount += earning.GrossAmount
+		statement.CommissionAmount += earning.CommissionAmount
+		statement.PayoutAmount += earning.OwnerAmount
+		statement.ItemCount++
+		statementEarnings[statement.ID] = append(statementEarnings[statement.ID], earning.ID)
+	}
+
+	statements := make([]entity.PayoutStatement, 0, len(owners))
+	for _, ownerID := range owners {
+		statement := statementByOwner[ownerID]
+		statement.GrossAmount = mathutil.RoundToFloat(statement.GrossAmount, 2)
+		statement.CommissionAmount = mathutil.RoundToFloat(statement.CommissionAmount, 2)
+		statement.PayoutAmount = mathutil.RoundToFloat(statement.PayoutAmount, 2)
+		statements = append(statements, *statement)
+	}
+
+	if err := u.settlementRepo.CreateSettlement(settlement, statements, statementEarnings); err != nil {
+		return nil, err
+	}
+
+	result := toSettlementResponse(settlement)
+	for _, statement := range statements {
+		result.Statements = append(result.Statements, *toPayoutStatementResponse(&statement))
+	}
+	return result, nil
+}
+
+func (u *settlementUsecase) GetSettlement(ctx context.Context, adminID uuid.UUID, settlementID uuid.UUID) (*response.SettlementResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	settlement, err := u.settlementRepo.GetSettlementByID(settlementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("settlement not found")
+		}
+		return nil, err
+	}
+
+	statements, err := u.settlementRepo.GetStatementsBySettlement(settlementID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toSettlementResponse(settlement)
+	for _, statement := range statements {
+		result.Statements = append(result.Statements, *toPayoutStatementResponse(&statement))
+	}
+	return result, nil
+}
+
+func (u *settlementUsecase) GetAllSettlements(ctx context.Context, adminID uuid.UUID) ([]response.SettlementResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	settlements, err := u.settlementRepo.GetAllSettlements()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []response.SettlementResponse
+	for _, settlement := range settlements {
+		result = append(result, *toSettlementResponse(&settlement))
+	}
+	return result, nil
+}
+
+func (u *settlementUsecase) GetPayoutStatement(ctx context.Context, adminID uuid.UUID, statementID uuid.UUID) (*response.PayoutStatementResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	return u.getPayoutStatement(statementID)
+}
+
+// getPayoutStatement returns a payout statement with its line items
+func (u *settlementUsecase) getPayoutStatement(statementID uuid.UUID) (*response.PayoutStatementResponse, error) {
+	statement, err := u.settlementRepo.GetStatementByID(statementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("payout statement not found")
+		}
+		return nil, err
+	}
+
+	earnings, err := u.settlementRepo.GetEarningsByStatement(statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toPayoutStatementResponse(statement)
+	for _, earning := range earnings {
+		result.Items = append(result.Items, toEarningResponse(&earning))
+	}
+	return result, nil
+}
+
+func (u *settlementUsecase) GetOwnerPayoutStatements(ctx context.Context, ownerID uuid.UUID) ([]response.PayoutStatementResponse, error) {
+	statements, err := u.settlementRepo.GetStatementsByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []response.PayoutStatementResponse
+	for _, statement := range statements {
+		result = append(result, *toPayoutStatementResponse(&statement))
+	}
+	return result, nil
+}
+
+func (u *settlementUsecase) GetOwnerPayoutStatement(ctx context.Context, ownerID uuid.UUID, statementID uuid.UUID) (*response.PayoutStatementResponse, error) {
+	statement, err := u.getPayoutStatement(statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if statement.OwnerID != ownerID {
+		return nil, errors.New("unauthorized to view this payout statement")
+	}
+
+	return statement, nil
+}
+
+func (u *settlementUsecase) MarkStatementPaid(ctx context.Context, adminID uuid.UUID, statementID uuid.UUID) error {
+	log := logger.EnhanceWith(ctx)
+	log.Info("MarkStatementPaid usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return err
+	}
+
+	statement, err := u.settlementRepo.GetStatementByID(statementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("payout statement not found")
+		}
+		return err
+	}
+
+	// The owner's wallet is credited with the payout together with the status change
+	transaction := &entity.Transaction{
+		ID:           uuid.New(),
+		UserID:       statement.OwnerID,
+		ServiceID:    entity.ServicePayout,
+		ServiceRefID: statement.ID,
+		Amount:       statement.PayoutAmount,
+		PaidAt:       time.Now(),
+		Status:       "completed",
+	}
+	updated, err := u.settlementRepo.MarkStatementPaid(statementID, transaction)
+	if err != nil {
+		log.Errorw("Failed to pay out statement", "error", err)
+		return errors.New("failed to credit payout")
+	}
+	if !updated {
+		return errors.New("payout statement is not pending")
+	}
+
+	return nil
+}
+
+// newEarning splits a sale into commission and owner share. A voucher discount comes out of
+// the share of whoever funded it: a platform-funded discount is taken from the commission on
+// the list price, while a shop-funded one means commission is only charged on what was paid.
+func newEarning(shop *entity.CoffeeShop, ratePercent int, serviceID entity.ServiceType, serviceRefID uuid.UUID, paidAmount, discount float64, fundedBy string) *entity.Earning {
+	grossAmount := mathutil.RoundToFloat(paidAmount+discount, 2)
+	commissionBase := grossAmount
+	if discount > 0 {
+		if fundedBy != entity.DiscountFundedByShop {
+			fundedBy = entity.DiscountFundedByPlatform
+		} else {
+			commissionBase = paidAmount
+		}
+	} else {
+		fundedBy = ""
+	}
+
+	commission := mathutil.RoundToFloat(commissionBase*float64(ratePercent)/100, 2)
+	ownerAmount := commissionBase - commission
+	if fundedBy == entity.DiscountFundedByPlatform {
+		commission -= discount
+	}
+
+	return &entity.Earning{
+		ID:               uuid.New(),
+		CoffeeShopID:     shop.ID,
+		OwnerID:          shop.OwnerID,
+		ServiceID:        serviceID,
+		ServiceRefID:     serviceRefID,
+		GrossAmount:      grossAmount,
+		DiscountAmount:   discount,
+		DiscountFundedBy: fundedBy,
+		RatePercent:      ratePercent,
+		CommissionAmount: mathutil.RoundToFloat(commission, 2),
+		OwnerAmount:      mathutil.RoundToFloat(ownerAmount, 2),
+		EarnedAt:         time.Now(),
+	}
+}
+
+func currentCommissionRate(coffeeShopRepo repository.ICoffeeShopRepo, shopID uuid.UUID) (int, error) {
+	rate, err := coffeeShopRepo.GetCommissionRate(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultCommissionRatePercent, nil
+		}
+		return 0, err
+	}
+	return rate.RatePercent, nil
+}
+
+func toSettlementResponse(settlement *entity.Settlement) *response.SettlementResponse {
+	return &response.SettlementResponse{
+		ID:          settlement.ID,
+		PeriodStart: settlement.PeriodStart,
+		PeriodEnd:   settlement.PeriodEnd,
+		CreatedBy:   settlement.CreatedBy,
+		CreatedAt:   settlement.CreatedAt,
+	}
+}
+
+func toPayoutStatementResponse(statement *entity.PayoutStatement) *response.PayoutStatementResponse {
+	return &response.PayoutStatementResponse{
+		ID:               statement.ID,
+		SettlementID:     statement.SettlementID,
+		OwnerID:          statement.OwnerID,
+		GrossAmount:      statement.GrossAmount,
+		CommissionAmount: statement.CommissionAmount,
+		PayoutAmount:     statement.PayoutAmount,
+		ItemCount:        statement.ItemCount,
+		Status:           statement.Status,
+		PaidAt:           statement.PaidAt,
+		CreatedAt:        statement.CreatedAt,
+	}
+}
+
+func toEarningResponse(earning *entity.Earning) response.EarningResponse {
+	return response.EarningResponse{
+		ID:               earning.ID,
+		CoffeeShopID:     earning.CoffeeShopID,
+		ServiceID:        int(earning.ServiceID),
+		ServiceRefID:     earning.ServiceRefID,
+		GrossAmount:      earning.GrossAmount,
+		DiscountAmount:   earning.DiscountAmount,
+		DiscountFundedBy: earning.DiscountFundedBy,
+		RatePercent:      earning.RatePercent,
+		CommissionAmount: earning.CommissionAmount,
+		OwnerAmount:      earning.OwnerAmount,
+		EarnedAt:         earning.EarnedAt,
+	}
+}
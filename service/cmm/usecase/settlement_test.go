@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+)
+
+func TestNewEarning(t *testing.T) {
+	shop := &entity.CoffeeShop{ID: uuid.New(), OwnerID: uuid.New()}
+
+	tests := []struct {
+		name           string
+		ratePercent    int
+		paidAmount     float64
+		discount       float64
+		fundedBy       string
+		wantGross      float64
+		wantCommission float64
+		wantOwner      float64
+		wantFundedBy   string
+	}{
+		{
+			name:           "no discount",
+			ratePercent:    15,
+			paidAmount:     200,
+			wantGross:      200,
+			wantCommission: 30,
+			wantOwner:      170,
+		},
+		{
+			name:           "shop-funded percent voucher",
+			ratePercent:    10,
+			paidAmount:     180,
+			discount:       20,
+			fundedBy:       entity.DiscountFundedByShop,
+			wantGross:      200,
+			wantCommission: 18,
+			wantOwner:      162,
+			wantFundedBy:   entity.DiscountFundedByShop,
+		},
+		{
+			name:           "shop-funded fixed voucher",
+			ratePercent:    10,
+			paidAmount:     150,
+			discount:       50,
+			fundedBy:       entity.DiscountFundedByShop,
+			wantGross:      200,
+			wantCommission: 15,
+			wantOwner:      135,
+			wantFundedBy:   entity.DiscountFundedByShop,
+		},
+		{
+			name:           "shop-funded 100% voucher",
+			ratePercent:    10,
+			paidAmount:     0,
+			discount:       200,
+			fundedBy:       entity.DiscountFundedByShop,
+			wantGross:      200,
+			wantCommission: 0,
+			wantOwner:      0,
+			wantFundedBy:   entity.DiscountFundedByShop,
+		},
+		{
+			name:           "platform-funded voucher",
+			ratePercent:    10,
+			paidAmount:     180,
+			discount:       20,
+			fundedBy:       entity.DiscountFundedByPlatform,
+			wantGross:      200,
+			wantCommission: 0,
+			wantOwner:      180,
+			wantFundedBy:   entity.DiscountFundedByPlatform,
+		},
+		{
+			name:           "platform-funded 100% voucher",
+			ratePercent:    10,
+			paidAmount:     0,
+			discount:       200,
+			wantGross:      200,
+			wantCommission: -180,
+			wantOwner:      180,
+			wantFundedBy:   entity.DiscountFundedByPlatform,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earning := newEarning(shop, tt.ratePercent, entity.ServiceBooking, uuid.New(), tt.paidAmount, tt.discount, tt.fundedBy)
+
+			if earning.GrossAmount != tt.wantGross || earning.CommissionAmount != tt.wantCommission || earning.OwnerAmount != tt.wantOwner {
+				t.Errorf("newEarning() gross/commission/owner = %v/%v/%v, want %v/%v/%v",
+					earning.GrossAmount, earning.CommissionAmount, earning.OwnerAmount,
+					tt.wantGross, tt.wantCommission, tt.wantOwner)
+			}
+			if earning.DiscountFundedBy != tt.wantFundedBy {
+				t.Errorf("newEarning() funded by = %q, want %q", earning.DiscountFundedBy, tt.wantFundedBy)
+			}
+			if earning.OwnerAmount < 0 {
+				t.Errorf("newEarning() owner amount = %v, want it not negative", earning.OwnerAmount)
+			}
+			// Whatever was paid is split between the platform and the owner
+			if got := earning.CommissionAmount + earning.OwnerAmount; got != tt.paidAmount {
+				t.Errorf("newEarning() commission + owner = %v, want the paid amount %v", got, tt.paidAmount)
+			}
+		})
+	}
+}
+
+func TestNewEarningCommissionSplit(t *testing.T) {
+	shop := &entity.CoffeeShop{ID: uuid.New(), OwnerID: uuid.New()}
+	bookingID := uuid.New()
+
+	tests := []struct {
+		name           string
+		ratePercent    int
+		paidAmount     float64
+		wantCommission float64
+		wantOwner      float64
+	}{
+		{name: "default rate", ratePercent: defaultCommissionRatePercent, paidAmount: 150, wantCommission: 0, wantOwner: 150},
+		{name: "rounded to cents", ratePercent: 12, paidAmount: 99.99, wantCommission: 12, wantOwner: 87.99},
+		{name: "small amount", ratePercent: 15, paidAmount: 0.03, wantCommission: 0, wantOwner: 0.03},
+		{name: "full rate", ratePercent: 100, paidAmount: 80, wantCommission: 80, wantOwner: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earning := newEarning(shop, tt.ratePercent, entity.ServiceBooking, bookingID, tt.paidAmount, 0, "")
+
+			if earning.CommissionAmount != tt.wantCommission || earning.OwnerAmount != tt.wantOwner {
+				t.Errorf("newEarning() commission/owner = %v/%v, want %v/%v",
+					earning.CommissionAmount, earning.OwnerAmount, tt.wantCommission, tt.wantOwner)
+			}
+			if earning.GrossAmount != tt.paidAmount || earning.RatePercent != tt.ratePercent {
+				t.Errorf("newEarning() gross/rate = %v/%d, want %v/%d", earning.GrossAmount, earning.RatePercent, tt.paidAmount, tt.ratePercent)
+			}
+			if earning.CoffeeShopID != shop.ID || earning.OwnerID != shop.OwnerID ||
+				earning.ServiceID != entity.ServiceBooking || earning.ServiceRefID != bookingID {
+				t.Errorf("newEarning() does not reference the shop, owner and booking: %+v", earning)
+			}
+			if earning.PayoutStatementID != nil {
+				t.Error("newEarning() is already on a payout statement")
+			}
+		})
+	}
+}
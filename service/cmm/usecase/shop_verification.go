@@ -0,0 +1,367 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/storage"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+// maxShopDocuments caps the verification documents of a single shop
+const maxShopDocuments = 10
+
+// documentExtensions maps the accepted document types, sniffed from the file content, to file extensions
+var documentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type IShopVerificationUsecase interface {
+	// Owner
+	UploadShopDocument(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.UploadShopDocument, fileName string, data []byte) (*response.ShopDocumentResponse, error)
+	DeleteShopDocument(ctx context.Context, ownerID uuid.UUID, documentID uuid.UUID) error
+	SubmitShopVerification(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.SubmitShopVerification) (*response.ShopVerificationResponse, error)
+	GetMyShopVerification(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) (*response.ShopVerificationResponse, error)
+
+	// Admin
+	GetShopVerifications(ctx context.Context, req request.ShopVerificationQuery) ([]response.ShopVerificationResponse, error)
+	GetShopVerification(ctx context.Context, shopID uuid.UUID) (*response.ShopVerificationResponse, error)
+	ApproveShop(ctx context.Context, adminID uuid.UUID, shopID uuid.UUID) error
+	RejectShop(ctx context.Context, adminID uuid.UUID, shopID uuid.UUID, req request.RejectShop) error
+}
+
+type shopVerificationUsecase struct {
+	shopVerificationRepo repository.IShopVerificationRepo
+	coffeeShopRepo       repository.ICoffeeShopRepo
+	notificationUsecase  INotificationUsecase
+	storage              storage.Storage
+}
+
+func NewShopVerificationUsecase(
+	shopVerificationRepo repository.IShopVerificationRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	notificationUsecase INotificationUsecase,
+	storage storage.Storage,
+) IShopVerificationUsecase {
+	return &shopVerificationUsecase{
+		shopVerificationRepo: shopVerificationRepo,
+		coffeeShopRepo:       coffeeShopRepo,
+		notificationUsecase:  notificationUsecase,
+		storage:              storage,
+	}
+}
+
+// UploadShopDocument attaches a JPEG, PNG or PDF document to a shop that is not yet under review
+func (u *shopVerificationUsecase) UploadShopDocument(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.UploadShopDocument, fileName string, data []byte) (*response.ShopDocumentResponse, error) {
+	logger.EnhanceWith(ctx).Info("UploadShopDocument usecase called")
+
+	maxSize := config.StorageConfig().MaxUploadBytes
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", maxSize)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := documentExtensions[contentType]
+	if !ok {
+		return nil, errors.New("unsupported file type, only JPEG, PNG and PDF documents are allowed")
+	}
+
+	shop, err := u.getEditableShop(ownerID, shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := u.shopVerificationRepo.GetShopDocuments(shop.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) >= maxShopDocuments {
+		return nil, fmt.Errorf("at most %d documents can be uploaded", maxShopDocuments)
+	}
+
+	document := &entity.ShopDocument{
+		ID:           uuid.New(),
+		CoffeeShopID: shop.ID,
+		DocumentType: req.DocumentType,
+		FileName:     filepath.Base(fileName),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		CreatedAt:    time.Now(),
+	}
+	// Keys are random so documents cannot be guessed from the shop ID alone
+	document.StorageKey = fmt.Sprintf("documents/%s/%s%s", shop.ID, document.ID, extension)
+
+	if err := u.storage.Put(ctx, document.StorageKey, data, contentType); err != nil {
+		return nil, err
+	}
+	document.URL = u.storage.URL(document.StorageKey)
+
+	if err := u.shopVerificationRepo.CreateShopDocument(document); err != nil {
+		u.deleteObject(ctx, document.StorageKey)
+		return nil, err
+	}
+
+	return toShopDocumentResponse(document), nil
+}
+
+func (u *shopVerificationUsecase) DeleteShopDocument(ctx context.Context, ownerID uuid.UUID, documentID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeleteShopDocument usecase called")
+
+	document, err := u.shopVerificationRepo.GetShopDocumentByID(documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("document not found")
+		}
+		return err
+	}
+
+	if _, err := u.getEditableShop(ownerID, document.CoffeeShopID); err != nil {
+		return err
+	}
+
+	if err := u.shopVerificationRepo.DeleteShopDocument(document.ID); err != nil {
+		return err
+	}
+
+	u.deleteObject(ctx, document.StorageKey)
+	return nil
+}
+
+// SubmitShopVerification sends a pending or rejected shop to the admins for review
+func (u *shopVerificationUsecase) SubmitShopVerification(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.SubmitShopVerification) (*response.ShopVerificationResponse, error) {
+	logger.EnhanceWith(ctx).Info("SubmitShopVerification usecase called")
+
+	shop, err := u.getEditableShop(ownerID, shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := u.shopVerificationRepo.GetShopDocuments(shop.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return nil, errors.New("upload at least one document before submitting")
+	}
+
+	verification := &entity.ShopVerification{
+		CoffeeShopID:    shop.ID,
+		BusinessName:    req.BusinessName,
+		TaxCode:         req.TaxCode,
+		BusinessAddress: req.BusinessAddress,
+		ContactPhone:    req.ContactPhone,
+		SubmittedAt:     time.Now(),
+	}
+
+	submitted, err := u.shopVerificationRepo.SubmitVerification(verification)
+	if err != nil {
+		return nil, err
+	}
+	if !submitted {
+		return nil, errors.New("coffee shop is already submitted or approved")
+	}
+
+	shop.Status = entity.ShopSubmitted
+	return toShopVerificationResponse(shop, verification, documents), nil
+}
+
+func (u *shopVerificationUsecase) GetMyShopVerification(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) (*response.ShopVerificationResponse, error) {
+	shop, err := u.getShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+	if shop.OwnerID != ownerID {
+		return nil, errors.New("unauthorized to view this coffee shop's verification")
+	}
+
+	return u.buildVerificationResponse(shop)
+}
+
+// GetShopVerifications lists shops in a verification status, submitted shops by default
+func (u *shopVerificationUsecase) GetShopVerifications(ctx context.Context, req request.ShopVerificationQuery) ([]response.ShopVerificationResponse, error) {
+	status := req.Status
+	if status == "" {
+		status = entity.ShopSubmitted
+	}
+
+	shops, err := u.shopVerificationRepo.GetCoffeeShopsByStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	shopIDs := make([]uuid.UUID, 0, len(shops))
+	for _, shop := range shops {
+		shopIDs = append(shopIDs, shop.ID)
+	}
+	verifications, err := u.shopVerificationRepo.GetVerifications(shopIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ShopVerificationResponse, 0, len(shops))
+	for i := range shops {
+		var verification *entity.ShopVerification
+		if v, ok := verifications[shops[i].ID]; ok {
+			verification = &v
+		}
+		result = append(result, *toShopVerificationResponse(&shops[i], verification, nil))
+	}
+	return result, nil
+}
+
+func (u *shopVerificationUsecase) GetShopVerification(ctx context.Context, shopID uuid.UUID) (*response.ShopVerificationResponse, error) {
+	shop, err := u.getShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildVerificationResponse(shop)
+}
+
+func (u *shopVerificationUsecase) ApproveShop(ctx context.Context, adminID uuid.UUID, shopID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("ApproveShop usecase called")
+
+	shop, err := u.getShop(shopID)
+	if err != nil {
+		return err
+	}
+
+	reviewed, err := u.shopVerificationRepo.ReviewShop(shop.ID, entity.ShopApproved, "", adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !reviewed {
+		return errors.New("coffee shop is not waiting for review")
+	}
+
+	u.notify(ctx, shop, "Coffee shop approved",
+		fmt.Sprintf("%s has been approved and is now listed and open for bookings", shop.Name))
+	return nil
+}
+
+func (u *shopVerificationUsecase) RejectShop(ctx context.Context, adminID uuid.UUID, shopID uuid.UUID, req request.RejectShop) error {
+	logger.EnhanceWith(ctx).Info("RejectShop usecase called")
+
+	shop, err := u.getShop(shopID)
+	if err != nil {
+		return err
+	}
+
+	reviewed, err := u.shopVerificationRepo.ReviewShop(shop.ID, entity.ShopRejected, req.Reason, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !reviewed {
+		return errors.New("coffee shop is not waiting for review")
+	}
+
+	u.notify(ctx, shop, "Coffee shop verification rejected",
+		fmt.Sprintf("%s was not approved: %s. Update your details and submit again.", shop.Name, req.Reason))
+	return nil
+}
+
+func (u *shopVerificationUsecase) getShop(shopID uuid.UUID) (*entity.CoffeeShop, error) {
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	return shop, nil
+}
+
+// getEditableShop returns an owned shop whose verification can still be changed
+func (u *shopVerificationUsecase) getEditableShop(ownerID uuid.UUID, shopID uuid.UUID) (*entity.CoffeeShop, error) {
+	shop, err := u.getShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+	if shop.OwnerID != ownerID {
+		return nil, errors.New("unauthorized to verify this coffee shop")
+	}
+	if shop.Status != entity.ShopPending && shop.Status != entity.ShopRejected {
+		return nil, errors.New("coffee shop is already submitted or approved")
+	}
+	return shop, nil
+}
+
+func (u *shopVerificationUsecase) buildVerificationResponse(shop *entity.CoffeeShop) (*response.ShopVerificationResponse, error) {
+	verification, err := u.shopVerificationRepo.GetVerification(shop.ID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		verification = nil
+	}
+
+	documents, err := u.shopVerificationRepo.GetShopDocuments(shop.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toShopVerificationResponse(shop, verification, documents), nil
+}
+
+func (u *shopVerificationUsecase) notify(ctx context.Context, shop *entity.CoffeeShop, title string, body string) {
+	if err := u.notificationUsecase.Notify(ctx, shop.OwnerID, entity.NotificationShopStatus, title, body, &shop.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send shop status notification", "error", err)
+	}
+}
+
+// deleteObject removes a stored document; a failure only leaves an orphaned file behind, so it is logged
+func (u *shopVerificationUsecase) deleteObject(ctx context.Context, key string) {
+	if err := u.storage.Delete(ctx, key); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to delete stored document", "key", key, "error", err)
+	}
+}
+
+func toShopVerificationResponse(shop *entity.CoffeeShop, verification *entity.ShopVerification, documents []entity.ShopDocument) *response.ShopVerificationResponse {
+	result := &response.ShopVerificationResponse{
+		CoffeeShopID: shop.ID,
+		ShopName:     shop.Name,
+		OwnerID:      shop.OwnerID,
+		Status:       shop.Status,
+	}
+	if verification != nil {
+		result.BusinessName = verification.BusinessName
+		result.TaxCode = verification.TaxCode
+		result.BusinessAddress = verification.BusinessAddress
+		result.ContactPhone = verification.ContactPhone
+		submittedAt := verification.SubmittedAt
+		result.SubmittedAt = &submittedAt
+		result.RejectionReason = verification.RejectionReason
+		result.ReviewedAt = verification.ReviewedAt
+	}
+	for i := range documents {
+		result.Documents = append(result.Documents, *toShopDocumentResponse(&documents[i]))
+	}
+	return result
+}
+
+func toShopDocumentResponse(document *entity.ShopDocument) *response.ShopDocumentResponse {
+	return &response.ShopDocumentResponse{
+		ID:           document.ID,
+		DocumentType: document.DocumentType,
+		FileName:     document.FileName,
+		URL:          document.URL,
+		ContentType:  document.ContentType,
+		Size:         document.Size,
+		CreatedAt:    document.CreatedAt,
+	}
+}
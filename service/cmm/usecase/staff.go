@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IStaffUsecase interface {
+	// Owner
+	InviteStaff(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.InviteStaff) (*response.StaffResponse, error)
+	GetShopStaff(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) ([]response.StaffResponse, error)
+	UpdateStaffPermissions(ctx context.Context, ownerID uuid.UUID, staffID uuid.UUID, req request.UpdateStaffPermissions) error
+	RemoveStaff(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error
+
+	// Staff
+	GetMyInvitations(ctx context.Context, userID uuid.UUID) ([]response.StaffResponse, error)
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error
+	DeclineInvitation(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error
+	GetMyStaffShops(ctx context.Context, userID uuid.UUID) ([]response.StaffResponse, error)
+}
+
+type staffUsecase struct {
+	staffRepo           repository.IStaffRepo
+	coffeeShopRepo      repository.ICoffeeShopRepo
+	userRepo            repository.IUserRepo
+	notificationUsecase INotificationUsecase
+}
+
+func NewStaffUsecase(
+	staffRepo repository.IStaffRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	userRepo repository.IUserRepo,
+	notificationUsecase INotificationUsecase,
+) IStaffUsecase {
+	return &staffUsecase{
+		staffRepo:           staffRepo,
+		coffeeShopRepo:      coffeeShopRepo,
+		userRepo:            userRepo,
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// InviteStaff invites a registered user by email; the invitation takes effect once accepted
+func (u *staffUsecase) InviteStaff(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.InviteStaff) (*response.StaffResponse, error) {
+	logger.EnhanceWith(ctx).Info("InviteStaff usecase called")
+
+	shop, err := u.getOwnedShop(ownerID, shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no user is registered with this email")
+		}
+		return nil, err
+	}
+	if user.ID == shop.OwnerID {
+		return nil, errors.New("the owner cannot be invited as staff")
+	}
+
+	if _, err := u.staffRepo.GetStaffMember(shop.ID, user.ID); err == nil {
+		return nil, errors.New("user is already staff or invited")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	staff := &entity.ShopStaff{
+		ID:           uuid.New(),
+		CoffeeShopID: shop.ID,
+		UserID:       user.ID,
+		Permissions:  normalizePermissions(req.Permissions),
+		Status:       entity.StaffInvited,
+		InvitedBy:    ownerID,
+		CreatedAt:    time.Now(),
+	}
+	if err := u.staffRepo.CreateStaff(staff); err != nil {
+		return nil, err
+	}
+
+	if err := u.notificationUsecase.Notify(ctx, user.ID, entity.NotificationStaffInvite, "Staff invitation",
+		fmt.Sprintf("You have been invited to join the staff of %s", shop.Name), &staff.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send staff invitation notification", "error", err)
+	}
+
+	result := toStaffResponse(staff)
+	result.ShopName = shop.Name
+	result.FullName = user.FullName
+	result.Email = user.Email
+	return result, nil
+}
+
+func (u *staffUsecase) GetShopStaff(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) ([]response.StaffResponse, error) {
+	if _, err := u.getOwnedShop(ownerID, shopID); err != nil {
+		return nil, err
+	}
+
+	members, err := u.staffRepo.GetStaffByShop(shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.StaffResponse, 0, len(members))
+	for _, member := range members {
+		staff := toStaffResponse(&member.ShopStaff)
+		staff.FullName = member.FullName
+		staff.Email = member.Email
+		result = append(result, *staff)
+	}
+	return result, nil
+}
+
+func (u *staffUsecase) UpdateStaffPermissions(ctx context.Context, ownerID uuid.UUID, staffID uuid.UUID, req request.UpdateStaffPermissions) error {
+	logger.EnhanceWith(ctx).Info("UpdateStaffPermissions usecase called")
+
+	staff, err := u.getStaff(staffID)
+	if err != nil {
+		return err
+	}
+	if _, err := u.getOwnedShop(ownerID, staff.CoffeeShopID); err != nil {
+		return err
+	}
+
+	return u.staffRepo.UpdateStaffPermissions(staff.ID, normalizePermissions(req.Permissions))
+}
+
+// RemoveStaff lets the owner remove a staff member or cancel an invitation, and staff leave a shop
+func (u *staffUsecase) RemoveStaff(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("RemoveStaff usecase called")
+
+	staff, err := u.getStaff(staffID)
+	if err != nil {
+		return err
+	}
+	if staff.UserID != userID {
+		if _, err := u.getOwnedShop(userID, staff.CoffeeShopID); err != nil {
+			return err
+		}
+	}
+
+	return u.staffRepo.DeleteStaff(staff.ID)
+}
+
+func (u *staffUsecase) GetMyInvitations(ctx context.Context, userID uuid.UUID) ([]response.StaffResponse, error) {
+	return u.getStaffShops(userID, entity.StaffInvited)
+}
+
+func (u *staffUsecase) AcceptInvitation(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("AcceptInvitation usecase called")
+
+	accepted, err := u.staffRepo.AcceptInvitation(staffID, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return errors.New("invitation not found")
+	}
+	return nil
+}
+
+func (u *staffUsecase) DeclineInvitation(ctx context.Context, userID uuid.UUID, staffID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeclineInvitation usecase called")
+
+	staff, err := u.staffRepo.GetStaffByID(staffID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if staff == nil || staff.UserID != userID || staff.Status != entity.StaffInvited {
+		return errors.New("invitation not found")
+	}
+
+	return u.staffRepo.DeleteStaff(staff.ID)
+}
+
+// GetMyStaffShops lists the shops the user works at, with the permissions granted at each
+func (u *staffUsecase) GetMyStaffShops(ctx context.Context, userID uuid.UUID) ([]response.StaffResponse, error) {
+	return u.getStaffShops(userID, entity.StaffActive)
+}
+
+func (u *staffUsecase) getStaffShops(userID uuid.UUID, status string) ([]response.StaffResponse, error) {
+	shops, err := u.staffRepo.GetStaffByUser(userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.StaffResponse, 0, len(shops))
+	for _, shop := range shops {
+		staff := toStaffResponse(&shop.ShopStaff)
+		staff.ShopName = shop.ShopName
+		result = append(result, *staff)
+	}
+	return result, nil
+}
+
+func (u *staffUsecase) getStaff(staffID uuid.UUID) (*entity.ShopStaff, error) {
+	staff, err := u.staffRepo.GetStaffByID(staffID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("staff member not found")
+		}
+		return nil, err
+	}
+	return staff, nil
+}
+
+// getOwnedShop returns a shop only to its owner; staff cannot manage other staff
+func (u *staffUsecase) getOwnedShop(ownerID uuid.UUID, shopID uuid.UUID) (*entity.CoffeeShop, error) {
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	if shop.OwnerID != ownerID {
+		return nil, errors.New("unauthorized to manage staff of this coffee shop")
+	}
+	return shop, nil
+}
+
+// authorizeShop reports whether the user may act on the shop. The owner always may;
+// active staff only with the given permission. An empty permission is reserved for the owner.
+func authorizeShop(staffRepo repository.IStaffRepo, shop *entity.CoffeeShop, userID uuid.UUID, permission string) (bool, error) {
+	if shop.OwnerID == userID {
+		return true, nil
+	}
+	if permission == "" {
+		return false, nil
+	}
+
+	staff, err := staffRepo.GetStaffMember(shop.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if staff.Status != entity.StaffActive {
+		return false, nil
+	}
+	for _, granted := range staff.Permissions {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// normalizePermissions drops duplicates and sorts the permissions
+func normalizePermissions(permissions []string) []string {
+	seen := make(map[string]bool, len(permissions))
+	result := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if !seen[permission] {
+			seen[permission] = true
+			result = append(result, permission)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func toStaffResponse(staff *entity.ShopStaff) *response.StaffResponse {
+	return &response.StaffResponse{
+		ID:           staff.ID,
+		CoffeeShopID: staff.CoffeeShopID,
+		UserID:       staff.UserID,
+		Permissions:  staff.Permissions,
+		Status:       staff.Status,
+		AcceptedAt:   staff.AcceptedAt,
+		CreatedAt:    staff.CreatedAt,
+	}
+}
@@ -3,10 +3,15 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/mailer"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/repository"
@@ -15,41 +20,200 @@ import (
 )
 
 type IUserUsecase interface {
-	Login(ctx context.Context, req request.Login) (string, error)
+	Login(ctx context.Context, req request.Login, ip string, userAgent string) (string, error)
 	Register(ctx context.Context, req request.Register) error
+	VerifyEmail(ctx context.Context, req request.VerifyEmail) error
+	ResendVerification(ctx context.Context, req request.ResendVerification) error
+	ForgotPassword(ctx context.Context, req request.ForgotPassword) error
+	ResetPassword(ctx context.Context, req request.ResetPassword) error
 }
 
+// ErrLoginLocked is returned while the account or client IP is locked out after too many failed logins
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// maxLockoutDoublings caps the exponent of the lockout backoff so the shift cannot overflow
+const maxLockoutDoublings = 20
+
 type userUsecase struct {
-	repo repository.IUserRepo
+	repo              repository.IUserRepo
+	referralUsecase   IReferralUsecase
+	userTokenRepo     repository.IUserTokenRepo
+	mailer            mailer.Mailer
+	loginSecurityRepo repository.ILoginSecurityRepo
+	mailCfg           config.MailCfg
+	securityCfg       config.SecurityCfg
 }
 
 func NewUserUsecase(
 	repo repository.IUserRepo,
+	referralUsecase IReferralUsecase,
+	userTokenRepo repository.IUserTokenRepo,
+	mailer mailer.Mailer,
+	loginSecurityRepo repository.ILoginSecurityRepo,
+	mailCfg config.MailCfg,
+	securityCfg config.SecurityCfg,
 ) IUserUsecase {
 	return &userUsecase{
-		repo: repo,
+		repo:              repo,
+		referralUsecase:   referralUsecase,
+		userTokenRepo:     userTokenRepo,
+		mailer:            mailer,
+		loginSecurityRepo: loginSecurityRepo,
+		mailCfg:           mailCfg,
+		securityCfg:       securityCfg,
 	}
 }
 
-func (u *userUsecase) Login(ctx context.Context, req request.Login) (string, error) {
+// Login checks the credentials unless the account or client IP is locked out. Failed attempts
+// are counted per account and per IP, and each lockout lasts twice as long as the previous one.
+func (u *userUsecase) Login(ctx context.Context, req request.Login, ip string, userAgent string) (string, error) {
+	now := time.Now()
+	// Case variants of the email must share one counter
+	account := strings.ToLower(strings.TrimSpace(req.Email))
+
+	locked, err := u.loginSecurityRepo.GetLockedThrottles([]entity.LoginThrottle{
+		{Scope: entity.ThrottleAccount, Identifier: account},
+		{Scope: entity.ThrottleIP, Identifier: ip},
+	}, now)
+	if err != nil {
+		return "", err
+	}
+	if len(locked) > 0 {
+		return "", lockoutError(locked, now)
+	}
+
 	user, err := u.repo.GetUserByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			u.recordLoginFailure(ctx, nil, account, ip, userAgent, now)
 			return "", errors.New("invalid credentials") // avoid revealing that user doesn't exist
 		}
 		return "", err
 	}
 
-	// Compare password hash
+	// Compare password hash. An unverified account fails like a wrong password, so a correct
+	// guess cannot be told apart before the owner of the email has verified it.
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
-	if err != nil {
+	if err != nil || user.EmailVerifiedAt == nil {
+		u.recordLoginFailure(ctx, user, account, ip, userAgent, now)
 		return "", errors.New("invalid credentials")
 	}
 
+	// The IP counter is left alone, so one valid account cannot reset it for guesses at others
+	if err := u.loginSecurityRepo.ClearThrottle(entity.ThrottleAccount, account); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to clear login throttle", "user_id", user.ID, "error", err)
+	}
+
+	u.recordLoginIP(ctx, user, ip, userAgent, now)
+
 	// Generate and return token (placeholder)
 	return "jwt_token_placeholder", nil
 }
 
+// recordLoginFailure counts a failed login for the account and the IP and locks out whichever
+// reached its limit. Errors are only logged so the caller still gets the login error.
+func (u *userUsecase) recordLoginFailure(ctx context.Context, user *entity.User, account string, ip string, userAgent string, now time.Time) {
+	log := logger.EnhanceWith(ctx)
+	cfg := u.securityCfg
+	windowStart := now.Add(-time.Duration(cfg.FailureWindowMinutes) * time.Minute)
+
+	scopes := []struct {
+		scope       string
+		identifier  string
+		maxFailures int
+		eventType   string
+	}{
+		{entity.ThrottleAccount, account, cfg.AccountMaxFailures, entity.SecurityAccountLocked},
+		{entity.ThrottleIP, ip, cfg.IPMaxFailures, entity.SecurityIPLocked},
+	}
+	for _, s := range scopes {
+		throttle, err := u.loginSecurityRepo.RecordLoginFailure(s.scope, s.identifier, now, windowStart)
+		if err != nil {
+			log.Errorw("Failed to record login failure", "scope", s.scope, "error", err)
+			continue
+		}
+		if throttle.Failures < s.maxFailures {
+			continue
+		}
+
+		lockedUntil := now.Add(lockoutDuration(cfg, throttle.Failures-s.maxFailures))
+		if err := u.loginSecurityRepo.LockThrottle(s.scope, s.identifier, lockedUntil); err != nil {
+			log.Errorw("Failed to lock login throttle", "scope", s.scope, "error", err)
+			continue
+		}
+
+		event := &entity.SecurityEvent{
+			ID:          uuid.New(),
+			Type:        s.eventType,
+			Email:       account,
+			IP:          ip,
+			UserAgent:   userAgent,
+			Failures:    throttle.Failures,
+			LockedUntil: &lockedUntil,
+			CreatedAt:   now,
+		}
+		if user != nil {
+			event.UserID = &user.ID
+		}
+		if err := u.loginSecurityRepo.CreateSecurityEvent(event); err != nil {
+			log.Errorw("Failed to record security event", "type", s.eventType, "error", err)
+		}
+	}
+}
+
+// recordLoginIP remembers the IP of a successful login and records a security event when a
+// user who has logged in before does so from a new IP
+func (u *userUsecase) recordLoginIP(ctx context.Context, user *entity.User, ip string, userAgent string, now time.Time) {
+	known, firstLogin, err := u.loginSecurityRepo.RecordLoginIP(user.ID, ip, now)
+	if err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to record login IP", "user_id", user.ID, "error", err)
+		return
+	}
+	if known || firstLogin {
+		return
+	}
+
+	event := &entity.SecurityEvent{
+		ID:        uuid.New(),
+		Type:      entity.SecurityNewIPLogin,
+		UserID:    &user.ID,
+		Email:     user.Email,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: now,
+	}
+	if err := u.loginSecurityRepo.CreateSecurityEvent(event); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to record security event", "type", event.Type, "error", err)
+	}
+}
+
+// lockoutDuration doubles the base lockout for each failure past the limit, up to the maximum
+func lockoutDuration(cfg config.SecurityCfg, extraFailures int) time.Duration {
+	if extraFailures > maxLockoutDoublings {
+		extraFailures = maxLockoutDoublings
+	}
+	duration := time.Duration(cfg.LockoutBaseSeconds) * time.Second << extraFailures
+	if maxDuration := time.Duration(cfg.LockoutMaxMinutes) * time.Minute; duration > maxDuration {
+		return maxDuration
+	}
+	return duration
+}
+
+// lockoutError tells the user how long the longest of the lockouts lasts
+func lockoutError(locked []entity.LoginThrottle, now time.Time) error {
+	var until time.Time
+	for _, throttle := range locked {
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(until) {
+			until = *throttle.LockedUntil
+		}
+	}
+	minutes := int(math.Ceil(until.Sub(now).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	return fmt.Errorf("%w, try again in %d minute(s)", ErrLoginLocked, minutes)
+}
+
 func (u *userUsecase) Register(ctx context.Context, req request.Register) error {
 	logger.EnhanceWith(ctx).Info("Register usecase called")
 
@@ -75,12 +239,26 @@ func (u *userUsecase) Register(ctx context.Context, req request.Register) error
 		return err // Return other DB errors
 	}
 
+	// Resolve the referrer before creating anything
+	var referrer *entity.User
+	if req.ReferralCode != "" {
+		referrer, err = u.referralUsecase.ResolveReferrer(ctx, req.ReferralCode, req.Email)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 
+	referralCode, err := u.referralUsecase.NewReferralCode(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Create user entity
 	user := &entity.User{
 		ID:           uuid.New(),
@@ -88,8 +266,12 @@ func (u *userUsecase) Register(ctx context.Context, req request.Register) error
 		Email:        req.Email,
 		PasswordHash: string(hashedPassword),
 		Role:         entity.RoleAdmin, // Adjust role as needed
+		ReferralCode: &referralCode,
 		CreatedAt:    time.Now(),
 	}
+	if referrer != nil {
+		user.ReferredBy = &referrer.ID
+	}
 
 	// Save user
 	if err := u.repo.CreateUser(user); err != nil {
@@ -102,5 +284,156 @@ func (u *userUsecase) Register(ctx context.Context, req request.Register) error
 		Balance: 0,
 	}
 
-	return u.repo.CreateWallet(wallet)
+	if err := u.repo.CreateWallet(wallet); err != nil {
+		return err
+	}
+
+	// The referral is rewarded later, once the new user's first payment clears
+	if err := u.referralUsecase.RecordReferral(ctx, user); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to record referral", "error", err)
+	}
+
+	// The account exists either way; the user can ask for the email again
+	if err := u.sendVerification(ctx, user); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email as verified with the token sent at registration
+func (u *userUsecase) VerifyEmail(ctx context.Context, req request.VerifyEmail) error {
+	logger.EnhanceWith(ctx).Info("VerifyEmail usecase called")
+
+	now := time.Now()
+	token, err := u.userTokenRepo.ConsumeUserToken(hashUserToken(req.Token), entity.TokenEmailVerification, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired token")
+		}
+		return err
+	}
+
+	user, err := u.repo.GetUserByID(token.UserID)
+	if err != nil {
+		return err
+	}
+	// A token sent to an address the user has since changed from no longer proves anything
+	if !strings.EqualFold(user.Email, token.Email) {
+		return errors.New("invalid or expired token")
+	}
+
+	return u.repo.MarkEmailVerified(user.ID, now)
+}
+
+// ResendVerification sends a new verification email. It succeeds whether or not the email
+// belongs to an unverified account, so it cannot be used to find registered addresses.
+func (u *userUsecase) ResendVerification(ctx context.Context, req request.ResendVerification) error {
+	logger.EnhanceWith(ctx).Info("ResendVerification usecase called")
+
+	user, err := u.repo.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := u.sendVerification(ctx, user); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send verification email", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// ForgotPassword emails a password reset link. Like ResendVerification it does not reveal
+// whether the email is registered.
+func (u *userUsecase) ForgotPassword(ctx context.Context, req request.ForgotPassword) error {
+	logger.EnhanceWith(ctx).Info("ForgotPassword usecase called")
+
+	user, err := u.repo.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	ttl := time.Duration(u.mailCfg.PasswordResetTTLMinutes) * time.Minute
+	if err := u.sendUserToken(ctx, user, entity.TokenPasswordReset, ttl, mailer.TemplateResetPassword, resetPasswordPath); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to send password reset email", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with the token from the reset email
+func (u *userUsecase) ResetPassword(ctx context.Context, req request.ResetPassword) error {
+	logger.EnhanceWith(ctx).Info("ResetPassword usecase called")
+
+	now := time.Now()
+	token, err := u.userTokenRepo.ConsumeUserToken(hashUserToken(req.Token), entity.TokenPasswordReset, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired token")
+		}
+		return err
+	}
+
+	user, err := u.repo.GetUserByID(token.UserID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(user.Email, token.Email) {
+		return errors.New("invalid or expired token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.UpdatePassword(user.ID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	// Other reset links are void once the password has changed
+	if err := u.userTokenRepo.DeleteUserTokens(user.ID, entity.TokenPasswordReset); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to delete password reset tokens", "user_id", user.ID, "error", err)
+	}
+	// Receiving the reset email proves the user owns the address
+	if err := u.repo.MarkEmailVerified(user.ID, now); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to mark email verified", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+func (u *userUsecase) sendVerification(ctx context.Context, user *entity.User) error {
+	ttl := time.Duration(u.mailCfg.VerificationTTLHours) * time.Hour
+	return u.sendUserToken(ctx, user, entity.TokenEmailVerification, ttl, mailer.TemplateVerifyEmail, verifyEmailPath)
+}
+
+// sendUserToken replaces the user's pending tokens for the purpose with a new one and emails it.
+// Nothing is sent if the last token was sent moments ago, so the endpoints cannot be used to flood an inbox.
+func (u *userUsecase) sendUserToken(ctx context.Context, user *entity.User, purpose string, ttl time.Duration, templateName string, path string) error {
+	now := time.Now()
+	pending, err := u.userTokenRepo.GetPendingUserToken(user.ID, purpose, now)
+	if err == nil && now.Sub(pending.CreatedAt) < userTokenResendInterval {
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err := u.userTokenRepo.DeleteUserTokens(user.ID, purpose); err != nil {
+		return err
+	}
+	token, plain, err := newUserToken(user.ID, purpose, user.Email, ttl)
+	if err != nil {
+		return err
+	}
+	if err := u.userTokenRepo.CreateUserToken(token); err != nil {
+		return err
+	}
+	return sendUserTokenEmail(ctx, u.mailer, u.mailCfg.AppURL, user, token, plain, templateName, path)
 }
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/mailer"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var testSecurityCfg = config.SecurityCfg{
+	AccountMaxFailures:   5,
+	IPMaxFailures:        20,
+	LockoutBaseSeconds:   60,
+	LockoutMaxMinutes:    60,
+	FailureWindowMinutes: 15,
+}
+
+var testMailCfg = config.MailCfg{
+	AppURL:                  "https://app.example.com",
+	VerificationTTLHours:    48,
+	PasswordResetTTLMinutes: 30,
+}
+
+// fakeUserRepo keeps users in memory
+type fakeUserRepo struct {
+	mu    sync.Mutex
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *fakeUserRepo) GetUserByEmail(email string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) CreateUser(user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *fakeUserRepo) CreateWallet(wallet *entity.Wallet) error {
+	return nil
+}
+
+func (r *fakeUserRepo) GetUserByID(id uuid.UUID) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepo) GetUserByReferralCode(code string) (*entity.User, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) UpdateUserProfile(user *entity.User) error {
+	return r.CreateUser(user)
+}
+
+func (r *fakeUserRepo) UpdatePassword(id uuid.UUID, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[id].PasswordHash = passwordHash
+	return nil
+}
+
+func (r *fakeUserRepo) UpdateEmail(id uuid.UUID, email string, verifiedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[id].Email = email
+	r.users[id].EmailVerifiedAt = &verifiedAt
+	return nil
+}
+
+func (r *fakeUserRepo) MarkEmailVerified(id uuid.UUID, verifiedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.users[id].EmailVerifiedAt == nil {
+		r.users[id].EmailVerifiedAt = &verifiedAt
+	}
+	return nil
+}
+
+// fakeUserTokenRepo keeps tokens in memory with the same rules as the database queries
+type fakeUserTokenRepo struct {
+	mu     sync.Mutex
+	tokens []*entity.UserToken
+}
+
+func (r *fakeUserTokenRepo) CreateUserToken(token *entity.UserToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *token
+	r.tokens = append(r.tokens, &copied)
+	return nil
+}
+
+func (r *fakeUserTokenRepo) GetPendingUserToken(userID uuid.UUID, purpose string, now time.Time) (*entity.UserToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *entity.UserToken
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.Purpose == purpose && token.UsedAt == nil && token.ExpiresAt.After(now) &&
+			(latest == nil || token.CreatedAt.After(latest.CreatedAt)) {
+			latest = token
+		}
+	}
+	if latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *latest
+	return &copied, nil
+}
+
+func (r *fakeUserTokenRepo) ConsumeUserToken(tokenHash string, purpose string, now time.Time) (*entity.UserToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash && token.Purpose == purpose && token.UsedAt == nil && token.ExpiresAt.After(now) {
+			token.UsedAt = &now
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserTokenRepo) DeleteUserTokens(userID uuid.UUID, purpose string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.tokens[:0]
+	for _, token := range r.tokens {
+		if token.UserID != userID || token.Purpose != purpose || token.UsedAt != nil {
+			kept = append(kept, token)
+		}
+	}
+	r.tokens = kept
+	return nil
+}
+
+// age moves the user's tokens for the purpose back in time, as if they were sent d ago
+func (r *fakeUserTokenRepo) age(userID uuid.UUID, purpose string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.Purpose == purpose {
+			token.CreatedAt = token.CreatedAt.Add(-d)
+			token.ExpiresAt = token.ExpiresAt.Add(-d)
+		}
+	}
+}
+
+// fakeLoginSecurityRepo counts failed logins and never locks anyone out. Methods the tests do
+// not need are left to the embedded interface.
+type fakeLoginSecurityRepo struct {
+	repository.ILoginSecurityRepo
+	mu       sync.Mutex
+	failures map[string]int
+	cleared  map[string]bool
+}
+
+func (r *fakeLoginSecurityRepo) GetLockedThrottles(keys []entity.LoginThrottle, now time.Time) ([]entity.LoginThrottle, error) {
+	return nil, nil
+}
+
+func (r *fakeLoginSecurityRepo) RecordLoginFailure(scope string, identifier string, now time.Time, windowStart time.Time) (*entity.LoginThrottle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[scope+":"+identifier]++
+	return &entity.LoginThrottle{Scope: scope, Identifier: identifier, Failures: r.failures[scope+":"+identifier]}, nil
+}
+
+func (r *fakeLoginSecurityRepo) ClearThrottle(scope string, identifier string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cleared[scope+":"+identifier] = true
+	return nil
+}
+
+func (r *fakeLoginSecurityRepo) RecordLoginIP(userID uuid.UUID, ip string, now time.Time) (bool, bool, error) {
+	return false, true, nil
+}
+
+// fakeReferralUsecase hands out fixed referral codes and records nothing
+type fakeReferralUsecase struct{}
+
+func (fakeReferralUsecase) NewReferralCode(ctx context.Context) (string, error) {
+	return uuid.NewString()[:8], nil
+}
+
+func (fakeReferralUsecase) ResolveReferrer(ctx context.Context, code string, refereeEmail string) (*entity.User, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (fakeReferralUsecase) RecordReferral(ctx context.Context, referee *entity.User) error {
+	return nil
+}
+
+func (fakeReferralUsecase) RewardReferral(ctx context.Context, refereeID uuid.UUID, serviceID entity.ServiceType, serviceRefID uuid.UUID, amount float64) error {
+	return nil
+}
+
+func (fakeReferralUsecase) GetMyReferrals(ctx context.Context, userID uuid.UUID) (*response.ReferralSummaryResponse, error) {
+	return &response.ReferralSummaryResponse{}, nil
+}
+
+type userTestEnv struct {
+	usecase  IUserUsecase
+	users    *fakeUserRepo
+	tokens   *fakeUserTokenRepo
+	security *fakeLoginSecurityRepo
+	mailer   *mailer.MemoryMailer
+}
+
+func newUserTestEnv() *userTestEnv {
+	env := &userTestEnv{
+		users:    &fakeUserRepo{users: make(map[uuid.UUID]*entity.User)},
+		tokens:   &fakeUserTokenRepo{},
+		security: &fakeLoginSecurityRepo{failures: make(map[string]int), cleared: make(map[string]bool)},
+		mailer:   mailer.NewMemoryMailer(),
+	}
+	env.usecase = NewUserUsecase(env.users, fakeReferralUsecase{}, env.tokens, env.mailer, env.security, testMailCfg, testSecurityCfg)
+	return env
+}
+
+func (env *userTestEnv) register(t *testing.T, email string) *entity.User {
+	t.Helper()
+	err := env.usecase.Register(context.Background(), request.Register{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     email,
+		Password:  "password123",
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	user, err := env.users.GetUserByEmail(email)
+	if err != nil {
+		t.Fatalf("registered user not found: %v", err)
+	}
+	return user
+}
+
+var tokenLinkPattern = regexp.MustCompile(`[?&]token=([A-Za-z0-9]+)`)
+
+// lastToken returns the token in the link of the last email sent, checking it went to the address
+func (env *userTestEnv) lastToken(t *testing.T, to string, path string) string {
+	t.Helper()
+	messages := env.mailer.Messages()
+	if len(messages) == 0 {
+		t.Fatal("no email was sent")
+	}
+	msg := messages[len(messages)-1]
+	if msg.To != to {
+		t.Fatalf("email sent to %q, want %q", msg.To, to)
+	}
+	if !strings.Contains(msg.Text, testMailCfg.AppURL+path+"?token=") {
+		t.Fatalf("email does not link to %s:\n%s", path, msg.Text)
+	}
+	match := tokenLinkPattern.FindStringSubmatch(msg.Text)
+	if match == nil {
+		t.Fatalf("no token in email:\n%s", msg.Text)
+	}
+	return match[1]
+}
+
+func TestRegisterAndVerifyEmail(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	user := env.register(t, "jane@example.com")
+
+	if user.EmailVerifiedAt != nil {
+		t.Fatal("email is verified before the link was opened")
+	}
+	if n := len(env.mailer.Messages()); n != 1 {
+		t.Fatalf("Register() sent %d emails, want 1", n)
+	}
+	token := env.lastToken(t, "jane@example.com", verifyEmailPath)
+
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: token}); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	user, _ = env.users.GetUserByID(user.ID)
+	if user.EmailVerifiedAt == nil {
+		t.Error("email is not verified after VerifyEmail()")
+	}
+
+	// Verified accounts get no further verification emails
+	if err := env.usecase.ResendVerification(ctx, request.ResendVerification{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ResendVerification() error = %v", err)
+	}
+	if n := len(env.mailer.Messages()); n != 1 {
+		t.Errorf("ResendVerification() for a verified account sent an email, %d emails in total", n)
+	}
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	user := env.register(t, "jane@example.com")
+	env.mailer.Reset()
+
+	if err := env.usecase.ForgotPassword(ctx, request.ForgotPassword{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ForgotPassword() error = %v", err)
+	}
+	token := env.lastToken(t, "jane@example.com", resetPasswordPath)
+
+	if err := env.usecase.ResetPassword(ctx, request.ResetPassword{Token: token, NewPassword: "newpassword123"}); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	user, _ = env.users.GetUserByID(user.ID)
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("newpassword123")); err != nil {
+		t.Errorf("password was not changed: %v", err)
+	}
+	if user.EmailVerifiedAt == nil {
+		t.Error("email is not verified after a password reset")
+	}
+
+	// Unknown addresses succeed without sending anything
+	env.mailer.Reset()
+	if err := env.usecase.ForgotPassword(ctx, request.ForgotPassword{Email: "nobody@example.com"}); err != nil {
+		t.Fatalf("ForgotPassword() for an unknown email error = %v", err)
+	}
+	if n := len(env.mailer.Messages()); n != 0 {
+		t.Errorf("ForgotPassword() for an unknown email sent %d emails, want 0", n)
+	}
+}
+
+func TestUserTokenSingleUse(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	env.register(t, "jane@example.com")
+	verifyToken := env.lastToken(t, "jane@example.com", verifyEmailPath)
+
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: verifyToken}); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: verifyToken}); err == nil {
+		t.Error("VerifyEmail() with a used token error = nil, want an error")
+	}
+
+	if err := env.usecase.ForgotPassword(ctx, request.ForgotPassword{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ForgotPassword() error = %v", err)
+	}
+	resetToken := env.lastToken(t, "jane@example.com", resetPasswordPath)
+
+	// A token only works for the purpose it was sent for
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: resetToken}); err == nil {
+		t.Error("VerifyEmail() with a password reset token error = nil, want an error")
+	}
+	if err := env.usecase.ResetPassword(ctx, request.ResetPassword{Token: resetToken, NewPassword: "newpassword123"}); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	if err := env.usecase.ResetPassword(ctx, request.ResetPassword{Token: resetToken, NewPassword: "otherpassword123"}); err == nil {
+		t.Error("ResetPassword() with a used token error = nil, want an error")
+	}
+}
+
+func TestUserTokenExpiry(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	user := env.register(t, "jane@example.com")
+	verifyToken := env.lastToken(t, "jane@example.com", verifyEmailPath)
+
+	if err := env.usecase.ForgotPassword(ctx, request.ForgotPassword{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ForgotPassword() error = %v", err)
+	}
+	resetToken := env.lastToken(t, "jane@example.com", resetPasswordPath)
+
+	env.tokens.age(user.ID, entity.TokenEmailVerification, time.Duration(testMailCfg.VerificationTTLHours)*time.Hour)
+	env.tokens.age(user.ID, entity.TokenPasswordReset, time.Duration(testMailCfg.PasswordResetTTLMinutes)*time.Minute)
+
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: verifyToken}); err == nil {
+		t.Error("VerifyEmail() with an expired token error = nil, want an error")
+	}
+	if err := env.usecase.ResetPassword(ctx, request.ResetPassword{Token: resetToken, NewPassword: "newpassword123"}); err == nil {
+		t.Error("ResetPassword() with an expired token error = nil, want an error")
+	}
+	user, _ = env.users.GetUserByID(user.ID)
+	if user.EmailVerifiedAt != nil {
+		t.Error("email was verified with an expired token")
+	}
+}
+
+func TestResendVerificationThrottle(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	user := env.register(t, "jane@example.com")
+	firstToken := env.lastToken(t, "jane@example.com", verifyEmailPath)
+
+	// Asking again right away sends nothing and keeps the first link working
+	if err := env.usecase.ResendVerification(ctx, request.ResendVerification{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ResendVerification() error = %v", err)
+	}
+	if n := len(env.mailer.Messages()); n != 1 {
+		t.Fatalf("ResendVerification() within the resend interval sent an email, %d emails in total", n)
+	}
+
+	env.tokens.age(user.ID, entity.TokenEmailVerification, userTokenResendInterval)
+	if err := env.usecase.ResendVerification(ctx, request.ResendVerification{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("ResendVerification() error = %v", err)
+	}
+	if n := len(env.mailer.Messages()); n != 2 {
+		t.Fatalf("ResendVerification() after the resend interval sent no email, %d emails in total", n)
+	}
+	secondToken := env.lastToken(t, "jane@example.com", verifyEmailPath)
+
+	// The new link replaces the old one
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: firstToken}); err == nil {
+		t.Error("VerifyEmail() with a replaced token error = nil, want an error")
+	}
+	if err := env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: secondToken}); err != nil {
+		t.Errorf("VerifyEmail() with the new token error = %v", err)
+	}
+}
+
+func TestLoginUnverifiedAccount(t *testing.T) {
+	env := newUserTestEnv()
+	ctx := context.Background()
+	env.register(t, "jane@example.com")
+	login := func(password string) error {
+		_, err := env.usecase.Login(ctx, request.Login{Email: "jane@example.com", Password: password}, "203.0.113.7", "test")
+		return err
+	}
+
+	// The right password on an unverified account looks exactly like a wrong one
+	wrongErr := login("wrongpassword")
+	rightErr := login("password123")
+	if wrongErr == nil || rightErr == nil || wrongErr.Error() != rightErr.Error() {
+		t.Fatalf("Login() errors = %v (wrong password) and %v (unverified), want the same error", wrongErr, rightErr)
+	}
+	if got := env.security.failures[entity.ThrottleAccount+":jane@example.com"]; got != 2 {
+		t.Errorf("recorded %d failures for the account, want 2", got)
+	}
+	if env.security.cleared[entity.ThrottleAccount+":jane@example.com"] {
+		t.Error("Login() cleared the account throttle for an unverified account")
+	}
+
+	env.usecase.VerifyEmail(ctx, request.VerifyEmail{Token: env.lastToken(t, "jane@example.com", verifyEmailPath)})
+	if err := login("password123"); err != nil {
+		t.Fatalf("Login() after verification error = %v", err)
+	}
+	if !env.security.cleared[entity.ThrottleAccount+":jane@example.com"] {
+		t.Error("Login() did not clear the account throttle")
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	cfg := config.SecurityCfg{LockoutBaseSeconds: 60, LockoutMaxMinutes: 10}
+
+	tests := []struct {
+		extraFailures int
+		want          time.Duration
+	}{
+		{extraFailures: 0, want: time.Minute},
+		{extraFailures: 1, want: 2 * time.Minute},
+		{extraFailures: 3, want: 8 * time.Minute},
+		{extraFailures: 4, want: 10 * time.Minute},
+		{extraFailures: 1000, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := lockoutDuration(cfg, tt.extraFailures); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", tt.extraFailures, got, tt.want)
+		}
+	}
+}
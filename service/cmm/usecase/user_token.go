@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/mailer"
+	"github.com/leehai1107/cmm_server/pkg/tools/random"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+)
+
+const (
+	// userTokenLength gives tokens of about 256 bits of entropy
+	userTokenLength = 43
+	// userTokenResendInterval is how long to wait before another token email is sent to the same user
+	userTokenResendInterval = time.Minute
+)
+
+// Web app pages the links in token emails open
+const (
+	verifyEmailPath   = "/verify-email"
+	resetPasswordPath = "/reset-password"
+	confirmEmailPath  = "/confirm-email"
+)
+
+// newUserToken creates a token for the user and returns it with the plain token, which is
+// only ever sent to the user. The entity keeps just the hash.
+func newUserToken(userID uuid.UUID, purpose string, email string, ttl time.Duration) (*entity.UserToken, string, error) {
+	plain, err := random.RandFromAlphabet(random.Numeral+random.Letters, userTokenLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	token := &entity.UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashUserToken(plain),
+		Email:     email,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	return token, plain, nil
+}
+
+func hashUserToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendUserTokenEmail emails a link into the web app at appURL carrying the plain token to the
+// token's address, in the user's preferred language
+func sendUserTokenEmail(ctx context.Context, m mailer.Mailer, appURL string, user *entity.User, token *entity.UserToken, plain string, templateName string, path string) error {
+	link := strings.TrimRight(appURL, "/") + path + "?token=" + url.QueryEscape(plain)
+	msg, err := mailer.Render(templateName, user.PreferredLanguage, mailer.TemplateData{
+		Name:      user.FullName,
+		Link:      link,
+		ExpiresIn: token.ExpiresAt.Sub(token.CreatedAt),
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = token.Email
+	return m.Send(ctx, msg)
+}
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/model/response"
@@ -21,16 +23,38 @@ type IVoucherUsecase interface {
 	GetValidVouchers(ctx context.Context) ([]response.VoucherResponse, error)
 	UpdateVoucher(ctx context.Context, req request.UpdateVoucher) error
 	DeleteVoucher(ctx context.Context, voucherID uuid.UUID) error
-	ApplyVoucher(ctx context.Context, req request.ApplyVoucher) (*response.VoucherCalculation, error)
+	ApplyVoucher(ctx context.Context, userID uuid.UUID, req request.ApplyVoucher) (*response.VoucherCalculation, error)
+	GetVoucherRedemptions(ctx context.Context, voucherID uuid.UUID) ([]response.VoucherRedemptionResponse, error)
+	CreateShopVoucher(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreateShopVoucher) (*response.VoucherResponse, error)
+	GetShopVouchers(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) ([]response.VoucherResponse, error)
+	UpdateShopVoucher(ctx context.Context, ownerID uuid.UUID, voucherID uuid.UUID, req request.UpdateShopVoucher) (*response.VoucherResponse, error)
+	DeactivateShopVoucher(ctx context.Context, ownerID uuid.UUID, voucherID uuid.UUID) error
 }
 
 type voucherUsecase struct {
-	voucherRepo repository.IVoucherRepo
+	voucherRepo     repository.IVoucherRepo
+	serviceRepo     repository.IServiceRepo
+	transactionRepo repository.ITransactionRepo
+	coffeeShopRepo  repository.ICoffeeShopRepo
+	meetingRoomRepo repository.IMeetingRoomRepo
+	staffRepo       repository.IStaffRepo
 }
 
-func NewVoucherUsecase(voucherRepo repository.IVoucherRepo) IVoucherUsecase {
+func NewVoucherUsecase(
+	voucherRepo repository.IVoucherRepo,
+	serviceRepo repository.IServiceRepo,
+	transactionRepo repository.ITransactionRepo,
+	coffeeShopRepo repository.ICoffeeShopRepo,
+	meetingRoomRepo repository.IMeetingRoomRepo,
+	staffRepo repository.IStaffRepo,
+) IVoucherUsecase {
 	return &voucherUsecase{
-		voucherRepo: voucherRepo,
+		voucherRepo:     voucherRepo,
+		serviceRepo:     serviceRepo,
+		transactionRepo: transactionRepo,
+		coffeeShopRepo:  coffeeShopRepo,
+		meetingRoomRepo: meetingRoomRepo,
+		staffRepo:       staffRepo,
 	}
 }
 
@@ -54,28 +78,42 @@ func (u *voucherUsecase) CreateVoucher(ctx context.Context, req request.CreateVo
 	voucher := &entity.Voucher{
 		ID:              uuid.New(),
 		Code:            req.Code,
+		DiscountType:    req.DiscountType,
 		DiscountPercent: req.DiscountPercent,
+		DiscountAmount:  req.DiscountAmount,
+		MaxDiscount:     req.MaxDiscount,
+		MinOrderAmount:  req.MinOrderAmount,
 		MaxUses:         req.MaxUses,
 		UsedCount:       0,
-		ServiceID:       req.ServiceID,
+		PerUserLimit:    req.PerUserLimit,
+		FirstTimeOnly:   req.FirstTimeOnly,
 		ValidFrom:       req.ValidFrom,
 		ValidTo:         req.ValidTo,
 	}
+	if voucher.DiscountType == "" {
+		voucher.DiscountType = entity.VoucherDiscountPercent
+	}
+	if err := validateVoucherDiscount(voucher); err != nil {
+		return nil, err
+	}
+
+	// Restrict the voucher to a service if one was given
+	if req.ServiceID != 0 {
+		service, err := u.serviceRepo.GetServiceByID(entity.ServiceType(req.ServiceID))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("service not found")
+			}
+			return nil, err
+		}
+		voucher.ServiceID = &service.ID
+	}
 
 	if err := u.voucherRepo.CreateVoucher(voucher); err != nil {
 		return nil, err
 	}
 
-	return &response.VoucherResponse{
-		ID:              voucher.ID,
-		Code:            voucher.Code,
-		DiscountPercent: voucher.DiscountPercent,
-		MaxUses:         voucher.MaxUses,
-		UsedCount:       voucher.UsedCount,
-		ServiceID:       voucher.ServiceID,
-		ValidFrom:       voucher.ValidFrom,
-		ValidTo:         voucher.ValidTo,
-	}, nil
+	return toVoucherResponse(voucher), nil
 }
 
 func (u *voucherUsecase) GetVoucher(ctx context.Context, voucherID uuid.UUID) (*response.VoucherResponse, error) {
@@ -87,16 +125,7 @@ func (u *voucherUsecase) GetVoucher(ctx context.Context, voucherID uuid.UUID) (*
 		return nil, err
 	}
 
-	return &response.VoucherResponse{
-		ID:              voucher.ID,
-		Code:            voucher.Code,
-		DiscountPercent: voucher.DiscountPercent,
-		MaxUses:         voucher.MaxUses,
-		UsedCount:       voucher.UsedCount,
-		ServiceID:       voucher.ServiceID,
-		ValidFrom:       voucher.ValidFrom,
-		ValidTo:         voucher.ValidTo,
-	}, nil
+	return toVoucherResponse(voucher), nil
 }
 
 func (u *voucherUsecase) GetAllVouchers(ctx context.Context) ([]response.VoucherResponse, error) {
@@ -107,16 +136,7 @@ func (u *voucherUsecase) GetAllVouchers(ctx context.Context) ([]response.Voucher
 
 	var result []response.VoucherResponse
 	for _, voucher := range vouchers {
-		result = append(result, response.VoucherResponse{
-			ID:              voucher.ID,
-			Code:            voucher.Code,
-			DiscountPercent: voucher.DiscountPercent,
-			MaxUses:         voucher.MaxUses,
-			UsedCount:       voucher.UsedCount,
-			ServiceID:       voucher.ServiceID,
-			ValidFrom:       voucher.ValidFrom,
-			ValidTo:         voucher.ValidTo,
-		})
+		result = append(result, *toVoucherResponse(&voucher))
 	}
 
 	return result, nil
@@ -130,16 +150,7 @@ func (u *voucherUsecase) GetValidVouchers(ctx context.Context) ([]response.Vouch
 
 	var result []response.VoucherResponse
 	for _, voucher := range vouchers {
-		result = append(result, response.VoucherResponse{
-			ID:              voucher.ID,
-			Code:            voucher.Code,
-			DiscountPercent: voucher.DiscountPercent,
-			MaxUses:         voucher.MaxUses,
-			UsedCount:       voucher.UsedCount,
-			ServiceID:       voucher.ServiceID,
-			ValidFrom:       voucher.ValidFrom,
-			ValidTo:         voucher.ValidTo,
-		})
+		result = append(result, *toVoucherResponse(&voucher))
 	}
 
 	return result, nil
@@ -153,6 +164,10 @@ func (u *voucherUsecase) UpdateVoucher(ctx context.Context, req request.UpdateVo
 		}
 		return err
 	}
+	// Shop vouchers are funded by the shop, so only its owner changes them
+	if voucher.CoffeeShopID != nil {
+		return errors.New("shop vouchers are managed by their coffee shop")
+	}
 
 	if req.Code != "" {
 		// Check if new code conflicts with existing vouchers
@@ -162,12 +177,30 @@ func (u *voucherUsecase) UpdateVoucher(ctx context.Context, req request.UpdateVo
 		}
 		voucher.Code = req.Code
 	}
+	if req.DiscountType != "" {
+		voucher.DiscountType = req.DiscountType
+	}
 	if req.DiscountPercent > 0 {
 		voucher.DiscountPercent = req.DiscountPercent
 	}
+	if req.DiscountAmount != nil {
+		voucher.DiscountAmount = *req.DiscountAmount
+	}
+	if req.MaxDiscount != nil {
+		voucher.MaxDiscount = *req.MaxDiscount
+	}
+	if req.MinOrderAmount != nil {
+		voucher.MinOrderAmount = *req.MinOrderAmount
+	}
 	if req.MaxUses >= 0 {
 		voucher.MaxUses = req.MaxUses
 	}
+	if req.PerUserLimit != nil {
+		voucher.PerUserLimit = *req.PerUserLimit
+	}
+	if req.FirstTimeOnly != nil {
+		voucher.FirstTimeOnly = *req.FirstTimeOnly
+	}
 	if req.ValidFrom != nil {
 		voucher.ValidFrom = *req.ValidFrom
 	}
@@ -179,23 +212,37 @@ func (u *voucherUsecase) UpdateVoucher(ctx context.Context, req request.UpdateVo
 	if voucher.ValidTo.Before(voucher.ValidFrom) {
 		return errors.New("valid_to must be after valid_from")
 	}
+	if err := validateVoucherDiscount(voucher); err != nil {
+		return err
+	}
 
 	return u.voucherRepo.UpdateVoucher(voucher)
 }
 
 func (u *voucherUsecase) DeleteVoucher(ctx context.Context, voucherID uuid.UUID) error {
-	_, err := u.voucherRepo.GetVoucherByID(voucherID)
+	voucher, err := u.voucherRepo.GetVoucherByID(voucherID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("voucher not found")
 		}
 		return err
 	}
+	if voucher.CoffeeShopID != nil {
+		return errors.New("shop vouchers are managed by their coffee shop")
+	}
+	// Redemptions are kept for the discounts already given, so a redeemed voucher stays
+	redemptions, err := u.voucherRepo.GetRedemptionsByVoucher(voucherID)
+	if err != nil {
+		return err
+	}
+	if len(redemptions) > 0 {
+		return errors.New("voucher has been redeemed and cannot be deleted")
+	}
 
 	return u.voucherRepo.DeleteVoucher(voucherID)
 }
 
-func (u *voucherUsecase) ApplyVoucher(ctx context.Context, req request.ApplyVoucher) (*response.VoucherCalculation, error) {
+func (u *voucherUsecase) ApplyVoucher(ctx context.Context, userID uuid.UUID, req request.ApplyVoucher) (*response.VoucherCalculation, error) {
 	voucher, err := u.voucherRepo.GetVoucherByCode(req.VoucherCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -204,24 +251,477 @@ func (u *voucherUsecase) ApplyVoucher(ctx context.Context, req request.ApplyVouc
 		return nil, err
 	}
 
+	// Clients that predate service IDs only apply vouchers to bookings
+	service := entity.ServiceType(req.ServiceID)
+	if service == 0 {
+		service = entity.ServiceBooking
+	}
+
 	// Validate voucher
+	if err := checkVoucherUsable(voucher, service); err != nil {
+		return nil, err
+	}
+
+	// Shop vouchers need to know where they are being used
+	if req.MeetingRoomID != uuid.Nil {
+		room, err := u.meetingRoomRepo.GetMeetingRoomByID(req.MeetingRoomID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("meeting room not found")
+			}
+			return nil, err
+		}
+		if err := checkVoucherScope(u.voucherRepo, voucher, room); err != nil {
+			return nil, err
+		}
+	} else if voucher.CoffeeShopID != nil {
+		return nil, errors.New("meeting_room_id is required for shop vouchers")
+	}
+
+	// Per-user rules can only be checked for a signed-in customer; checkout enforces them again
+	if userID != uuid.Nil {
+		if err := checkVoucherEligibility(u.voucherRepo, u.transactionRepo, voucher, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return calculateVoucherDiscount(voucher, req.Amount)
+}
+
+func (u *voucherUsecase) GetVoucherRedemptions(ctx context.Context, voucherID uuid.UUID) ([]response.VoucherRedemptionResponse, error) {
+	logger.EnhanceWith(ctx).Info("GetVoucherRedemptions usecase called")
+
+	if _, err := u.voucherRepo.GetVoucherByID(voucherID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("voucher not found")
+		}
+		return nil, err
+	}
+
+	redemptions, err := u.voucherRepo.GetRedemptionsByVoucher(voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VoucherRedemptionResponse, 0, len(redemptions))
+	for _, redemption := range redemptions {
+		result = append(result, response.VoucherRedemptionResponse{
+			ID:             redemption.ID,
+			VoucherID:      redemption.VoucherID,
+			UserID:         redemption.UserID,
+			ServiceID:      int(redemption.ServiceID),
+			ServiceRefID:   redemption.ServiceRefID,
+			DiscountAmount: redemption.DiscountAmount,
+			Status:         redemption.Status,
+			RedeemedAt:     redemption.RedeemedAt,
+			ReleasedAt:     redemption.ReleasedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (u *voucherUsecase) CreateShopVoucher(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID, req request.CreateShopVoucher) (*response.VoucherResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreateShopVoucher usecase called")
+
+	shop, err := u.getVoucherShop(shopID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if shop.Status != entity.ShopApproved {
+		return nil, errors.New("coffee shop is not approved yet")
+	}
+
+	// Check if voucher code already exists
+	_, err = u.voucherRepo.GetVoucherByCode(req.Code)
+	if err == nil {
+		return nil, errors.New("voucher code already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Validate dates
+	if req.ValidTo.Before(req.ValidFrom) {
+		return nil, errors.New("valid_to must be after valid_from")
+	}
+
+	roomIDs, err := u.checkShopVoucherRooms(shop.ID, req.MeetingRoomIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	service := entity.ServiceBooking
+	voucher := &entity.Voucher{
+		ID:              uuid.New(),
+		Code:            req.Code,
+		DiscountType:    req.DiscountType,
+		DiscountPercent: req.DiscountPercent,
+		DiscountAmount:  req.DiscountAmount,
+		MaxDiscount:     req.MaxDiscount,
+		MinOrderAmount:  req.MinOrderAmount,
+		MaxUses:         req.MaxUses,
+		PerUserLimit:    req.PerUserLimit,
+		FirstTimeOnly:   req.FirstTimeOnly,
+		ServiceID:       &service,
+		CoffeeShopID:    &shop.ID,
+		ValidFrom:       req.ValidFrom,
+		ValidTo:         req.ValidTo,
+	}
+	if voucher.DiscountType == "" {
+		voucher.DiscountType = entity.VoucherDiscountPercent
+	}
+	if err := validateVoucherDiscount(voucher); err != nil {
+		return nil, err
+	}
+
+	if err := u.voucherRepo.CreateShopVoucher(voucher, roomIDs); err != nil {
+		return nil, err
+	}
+
+	resp := toVoucherResponse(voucher)
+	resp.MeetingRoomIDs = roomIDs
+	return resp, nil
+}
+
+func (u *voucherUsecase) GetShopVouchers(ctx context.Context, ownerID uuid.UUID, shopID uuid.UUID) ([]response.VoucherResponse, error) {
+	shop, err := u.getVoucherShop(shopID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers, err := u.voucherRepo.GetVouchersByCoffeeShop(shop.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		resp := toVoucherResponse(&voucher)
+		resp.MeetingRoomIDs, err = u.voucherRepo.GetVoucherMeetingRoomIDs(voucher.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *resp)
+	}
+
+	return result, nil
+}
+
+// UpdateShopVoucher changes a voucher of the owner's coffee shop
+func (u *voucherUsecase) UpdateShopVoucher(ctx context.Context, ownerID uuid.UUID, voucherID uuid.UUID, req request.UpdateShopVoucher) (*response.VoucherResponse, error) {
+	logger.EnhanceWith(ctx).Info("UpdateShopVoucher usecase called")
+
+	voucher, err := u.getShopVoucher(voucherID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != "" && req.Code != voucher.Code {
+		_, err := u.voucherRepo.GetVoucherByCode(req.Code)
+		if err == nil {
+			return nil, errors.New("voucher code already exists")
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		voucher.Code = req.Code
+	}
+	if req.DiscountType != "" {
+		voucher.DiscountType = req.DiscountType
+	}
+	if req.DiscountPercent > 0 {
+		voucher.DiscountPercent = req.DiscountPercent
+	}
+	if req.DiscountAmount != nil {
+		voucher.DiscountAmount = *req.DiscountAmount
+	}
+	if req.MaxDiscount != nil {
+		voucher.MaxDiscount = *req.MaxDiscount
+	}
+	if req.MinOrderAmount != nil {
+		voucher.MinOrderAmount = *req.MinOrderAmount
+	}
+	if req.MaxUses != nil {
+		voucher.MaxUses = *req.MaxUses
+	}
+	if req.PerUserLimit != nil {
+		voucher.PerUserLimit = *req.PerUserLimit
+	}
+	if req.FirstTimeOnly != nil {
+		voucher.FirstTimeOnly = *req.FirstTimeOnly
+	}
+	if req.ValidFrom != nil {
+		voucher.ValidFrom = *req.ValidFrom
+	}
+	if req.ValidTo != nil {
+		voucher.ValidTo = *req.ValidTo
+	}
+
+	if voucher.ValidTo.Before(voucher.ValidFrom) {
+		return nil, errors.New("valid_to must be after valid_from")
+	}
+	if err := validateVoucherDiscount(voucher); err != nil {
+		return nil, err
+	}
+
+	var roomIDs []uuid.UUID
+	if req.MeetingRoomIDs != nil {
+		if roomIDs, err = u.checkShopVoucherRooms(*voucher.CoffeeShopID, req.MeetingRoomIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.voucherRepo.UpdateShopVoucher(voucher, roomIDs); err != nil {
+		return nil, err
+	}
+
+	resp := toVoucherResponse(voucher)
+	resp.MeetingRoomIDs, err = u.voucherRepo.GetVoucherMeetingRoomIDs(voucher.ID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeactivateShopVoucher ends a shop voucher now. It is kept rather than deleted so its redemptions stay on record.
+func (u *voucherUsecase) DeactivateShopVoucher(ctx context.Context, ownerID uuid.UUID, voucherID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeactivateShopVoucher usecase called")
+
+	voucher, err := u.getShopVoucher(voucherID, ownerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if voucher.ValidTo.Before(now) {
+		return errors.New("voucher is already inactive")
+	}
+	voucher.ValidTo = now
+	if voucher.ValidFrom.After(now) {
+		voucher.ValidFrom = now
+	}
+	return u.voucherRepo.UpdateShopVoucher(voucher, nil)
+}
+
+// getVoucherShop loads a coffee shop whose vouchers the user manages. Shop vouchers are funded
+// from the owner share, so staff permissions do not extend to them.
+func (u *voucherUsecase) getVoucherShop(shopID uuid.UUID, userID uuid.UUID) (*entity.CoffeeShop, error) {
+	shop, err := u.coffeeShopRepo.GetCoffeeShopByID(shopID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coffee shop not found")
+		}
+		return nil, err
+	}
+	allowed, err := authorizeShop(u.staffRepo, shop, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized to manage vouchers of this coffee shop")
+	}
+	return shop, nil
+}
+
+// getShopVoucher loads a shop voucher of a coffee shop the user manages
+func (u *voucherUsecase) getShopVoucher(voucherID uuid.UUID, userID uuid.UUID) (*entity.Voucher, error) {
+	voucher, err := u.voucherRepo.GetVoucherByID(voucherID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("voucher not found")
+		}
+		return nil, err
+	}
+	if voucher.CoffeeShopID == nil {
+		return nil, errors.New("voucher not found")
+	}
+	if _, err := u.getVoucherShop(*voucher.CoffeeShopID, userID); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// checkShopVoucherRooms verifies the rooms belong to the shop and drops duplicates
+func (u *voucherUsecase) checkShopVoucherRooms(shopID uuid.UUID, roomIDs []uuid.UUID) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(roomIDs))
+	result := make([]uuid.UUID, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		if seen[roomID] {
+			continue
+		}
+		seen[roomID] = true
+
+		room, err := u.meetingRoomRepo.GetMeetingRoomByID(roomID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("meeting room not found")
+			}
+			return nil, err
+		}
+		if room.CoffeeShopID != shopID {
+			return nil, errors.New("meeting room does not belong to this coffee shop")
+		}
+		result = append(result, roomID)
+	}
+	return result, nil
+}
+
+// validateVoucherDiscount checks that the discount fields match the voucher's discount type
+func validateVoucherDiscount(voucher *entity.Voucher) error {
+	switch voucher.DiscountType {
+	case entity.VoucherDiscountPercent:
+		if voucher.DiscountPercent < 1 || voucher.DiscountPercent > 100 {
+			return errors.New("discount_percent must be between 1 and 100")
+		}
+	case entity.VoucherDiscountFixed:
+		if voucher.DiscountAmount <= 0 {
+			return errors.New("discount_amount must be greater than 0")
+		}
+		if voucher.MaxDiscount > 0 {
+			return errors.New("max_discount only applies to percent vouchers")
+		}
+	default:
+		return errors.New("invalid discount type")
+	}
+	return nil
+}
+
+// checkVoucherUsable checks the voucher's validity window, usage limit and service scope
+func checkVoucherUsable(voucher *entity.Voucher, service entity.ServiceType) error {
 	now := time.Now()
 	if now.Before(voucher.ValidFrom) || now.After(voucher.ValidTo) {
-		return nil, errors.New("voucher is not valid at this time")
+		return errors.New("voucher is not valid at this time")
 	}
 	if voucher.MaxUses > 0 && voucher.UsedCount >= voucher.MaxUses {
-		return nil, errors.New("voucher has reached maximum uses")
+		return errors.New("voucher has reached maximum uses")
+	}
+	return validateVoucherService(voucher, service)
+}
+
+// checkVoucherScope limits shop vouchers to the issuing coffee shop and, when set, to its listed meeting rooms
+func checkVoucherScope(voucherRepo repository.IVoucherRepo, voucher *entity.Voucher, room *entity.MeetingRoom) error {
+	if voucher.CoffeeShopID == nil {
+		return nil
+	}
+	if *voucher.CoffeeShopID != room.CoffeeShopID {
+		return errors.New("voucher is not valid for this coffee shop")
+	}
+
+	roomIDs, err := voucherRepo.GetVoucherMeetingRoomIDs(voucher.ID)
+	if err != nil {
+		return err
+	}
+	if len(roomIDs) == 0 {
+		return nil
+	}
+	for _, roomID := range roomIDs {
+		if roomID == room.ID {
+			return nil
+		}
+	}
+	return errors.New("voucher is not valid for this meeting room")
+}
+
+// firstTimeServices are the purchases after which a customer no longer counts as first-time
+var firstTimeServices = []entity.ServiceType{entity.ServiceBooking, entity.ServiceFoodOrder, entity.ServiceEventTicket}
+
+// checkVoucherEligibility enforces the voucher's per-user limit and first-time customer flag
+func checkVoucherEligibility(voucherRepo repository.IVoucherRepo, transactionRepo repository.ITransactionRepo, voucher *entity.Voucher, userID uuid.UUID) error {
+	if voucher.PerUserLimit > 0 {
+		count, err := voucherRepo.CountUserRedemptions(voucher.ID, userID)
+		if err != nil {
+			return err
+		}
+		if count >= int64(voucher.PerUserLimit) {
+			return errors.New("you have already used this voucher the maximum number of times")
+		}
+	}
+	if voucher.FirstTimeOnly {
+		count, err := transactionRepo.CountCompletedByServices(userID, firstTimeServices)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("voucher is only valid for first-time customers")
+		}
+	}
+	return nil
+}
+
+// calculateVoucherDiscount applies a voucher to an order amount and describes the rule that decided the discount
+func calculateVoucherDiscount(voucher *entity.Voucher, amount float64) (*response.VoucherCalculation, error) {
+	if amount < voucher.MinOrderAmount {
+		return nil, fmt.Errorf("order amount must be at least %.2f to use this voucher", voucher.MinOrderAmount)
+	}
+
+	var discount float64
+	var rule string
+	switch voucher.DiscountType {
+	case entity.VoucherDiscountFixed:
+		discount = voucher.DiscountAmount
+		rule = fmt.Sprintf("fixed discount of %.2f", voucher.DiscountAmount)
+	default:
+		discount = amount * float64(voucher.DiscountPercent) / 100
+		rule = fmt.Sprintf("%d%% off", voucher.DiscountPercent)
+		if voucher.MaxDiscount > 0 && discount > voucher.MaxDiscount {
+			discount = voucher.MaxDiscount
+			rule = fmt.Sprintf("%d%% off, capped at %.2f", voucher.DiscountPercent, voucher.MaxDiscount)
+		}
+	}
+
+	// A discount never makes the order negative
+	if discount > amount {
+		discount = amount
+		rule += ", limited to the order amount"
 	}
+	if voucher.MinOrderAmount > 0 {
+		rule += fmt.Sprintf(" (minimum order %.2f)", voucher.MinOrderAmount)
+	}
+	discount = mathutil.RoundToFloat(discount, 2)
 
-	// Calculate discount
-	discountAmount := req.Amount * float64(voucher.DiscountPercent) / 100
-	finalAmount := req.Amount - discountAmount
+	discountType := voucher.DiscountType
+	if discountType == "" {
+		discountType = entity.VoucherDiscountPercent
+	}
 
 	return &response.VoucherCalculation{
-		OriginalAmount:  req.Amount,
-		DiscountAmount:  discountAmount,
-		FinalAmount:     finalAmount,
+		OriginalAmount:  amount,
+		DiscountAmount:  discount,
+		FinalAmount:     mathutil.RoundToFloat(amount-discount, 2),
+		DiscountType:    discountType,
 		DiscountPercent: voucher.DiscountPercent,
 		VoucherCode:     voucher.Code,
+		AppliedRule:     rule,
 	}, nil
 }
+
+// validateVoucherService checks that a voucher issued for a specific service is only used for it
+func validateVoucherService(voucher *entity.Voucher, service entity.ServiceType) error {
+	if voucher.ServiceID != nil && *voucher.ServiceID != service {
+		return errors.New("voucher is not valid for this service")
+	}
+	return nil
+}
+
+func toVoucherResponse(voucher *entity.Voucher) *response.VoucherResponse {
+	resp := &response.VoucherResponse{
+		ID:              voucher.ID,
+		Code:            voucher.Code,
+		DiscountType:    voucher.DiscountType,
+		DiscountPercent: voucher.DiscountPercent,
+		DiscountAmount:  voucher.DiscountAmount,
+		MaxDiscount:     voucher.MaxDiscount,
+		MinOrderAmount:  voucher.MinOrderAmount,
+		MaxUses:         voucher.MaxUses,
+		UsedCount:       voucher.UsedCount,
+		PerUserLimit:    voucher.PerUserLimit,
+		FirstTimeOnly:   voucher.FirstTimeOnly,
+		ValidFrom:       voucher.ValidFrom,
+		ValidTo:         voucher.ValidTo,
+	}
+	if voucher.ServiceID != nil {
+		resp.ServiceID = int(*voucher.ServiceID)
+	}
+	resp.CoffeeShopID = voucher.CoffeeShopID
+	return resp
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/tools/random"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultCodeAlphabet leaves out characters that are easily confused such as 0/O and 1/I
+	defaultCodeAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	defaultCodeLength     = 8
+	maxCodeAlphabetLength = 62
+	// minCodeSpaceFactor is how many possible codes there must be per requested code,
+	// which keeps collisions rare and generation fast
+	minCodeSpaceFactor = 100
+	// maxCodeGenerationRounds bounds the retries needed to replace codes that already exist
+	maxCodeGenerationRounds = 5
+)
+
+type IVoucherCampaignUsecase interface {
+	CreateCampaign(ctx context.Context, adminID uuid.UUID, req request.CreateVoucherCampaign) (*response.VoucherCampaignResponse, error)
+	GetCampaign(ctx context.Context, adminID uuid.UUID, campaignID uuid.UUID) (*response.VoucherCampaignResponse, error)
+	GetAllCampaigns(ctx context.Context, adminID uuid.UUID) ([]response.VoucherCampaignResponse, error)
+	GetCampaignCodes(ctx context.Context, adminID uuid.UUID, campaignID uuid.UUID) (*response.VoucherCampaignResponse, []response.VoucherResponse, error)
+}
+
+type voucherCampaignUsecase struct {
+	campaignRepo repository.IVoucherCampaignRepo
+	serviceRepo  repository.IServiceRepo
+	userRepo     repository.IUserRepo
+}
+
+func NewVoucherCampaignUsecase(
+	campaignRepo repository.IVoucherCampaignRepo,
+	serviceRepo repository.IServiceRepo,
+	userRepo repository.IUserRepo,
+) IVoucherCampaignUsecase {
+	return &voucherCampaignUsecase{
+		campaignRepo: campaignRepo,
+		serviceRepo:  serviceRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (u *voucherCampaignUsecase) CreateCampaign(ctx context.Context, adminID uuid.UUID, req request.CreateVoucherCampaign) (*response.VoucherCampaignResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreateCampaign usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	if req.ValidTo.Before(req.ValidFrom) {
+		return nil, errors.New("valid_to must be after valid_from")
+	}
+
+	alphabet := req.Alphabet
+	if alphabet == "" {
+		alphabet = defaultCodeAlphabet
+	}
+	if err := validateCodeAlphabet(alphabet); err != nil {
+		return nil, err
+	}
+	if !isCodeText(req.Prefix, "-") {
+		return nil, errors.New("prefix may only contain letters, digits and '-'")
+	}
+	codeLength := req.CodeLength
+	if codeLength == 0 {
+		codeLength = defaultCodeLength
+	}
+	if math.Pow(float64(len(alphabet)), float64(codeLength)) < float64(req.Quantity)*minCodeSpaceFactor {
+		return nil, errors.New("alphabet and code length allow too few codes for this quantity")
+	}
+
+	// Every generated code copies this template
+	template := entity.Voucher{
+		DiscountType:    req.DiscountType,
+		DiscountPercent: req.DiscountPercent,
+		DiscountAmount:  req.DiscountAmount,
+		MaxDiscount:     req.MaxDiscount,
+		MinOrderAmount:  req.MinOrderAmount,
+		MaxUses:         1,
+		PerUserLimit:    1,
+		FirstTimeOnly:   req.FirstTimeOnly,
+		ValidFrom:       req.ValidFrom,
+		ValidTo:         req.ValidTo,
+	}
+	if template.DiscountType == "" {
+		template.DiscountType = entity.VoucherDiscountPercent
+	}
+	if err := validateVoucherDiscount(&template); err != nil {
+		return nil, err
+	}
+	if req.ServiceID != 0 {
+		service, err := u.serviceRepo.GetServiceByID(entity.ServiceType(req.ServiceID))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("service not found")
+			}
+			return nil, err
+		}
+		template.ServiceID = &service.ID
+	}
+
+	codes, err := u.generateUniqueCodes(req.Prefix, alphabet, codeLength, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &entity.VoucherCampaign{
+		ID:         uuid.New(),
+		Name:       req.Name,
+		Prefix:     req.Prefix,
+		Alphabet:   alphabet,
+		CodeLength: codeLength,
+		CodeCount:  len(codes),
+		CreatedBy:  adminID,
+		CreatedAt:  time.Now(),
+	}
+
+	vouchers := make([]entity.Voucher, 0, len(codes))
+	for _, code := range codes {
+		voucher := template
+		voucher.ID = uuid.New()
+		voucher.Code = code
+		voucher.CampaignID = &campaign.ID
+		vouchers = append(vouchers, voucher)
+	}
+
+	if err := u.campaignRepo.CreateCampaign(campaign, vouchers); err != nil {
+		return nil, err
+	}
+
+	return toVoucherCampaignResponse(campaign, &repository.CampaignStats{TotalCodes: int64(len(codes))}), nil
+}
+
+func (u *voucherCampaignUsecase) GetCampaign(ctx context.Context, adminID uuid.UUID, campaignID uuid.UUID) (*response.VoucherCampaignResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	campaign, err := u.campaignRepo.GetCampaignByID(campaignID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("campaign not found")
+		}
+		return nil, err
+	}
+
+	stats, err := u.campaignRepo.GetCampaignStats(campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVoucherCampaignResponse(campaign, stats), nil
+}
+
+func (u *voucherCampaignUsecase) GetAllCampaigns(ctx context.Context, adminID uuid.UUID) ([]response.VoucherCampaignResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	campaigns, err := u.campaignRepo.GetAllCampaigns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VoucherCampaignResponse, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		stats, err := u.campaignRepo.GetCampaignStats(campaign.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *toVoucherCampaignResponse(&campaign, stats))
+	}
+
+	return result, nil
+}
+
+func (u *voucherCampaignUsecase) GetCampaignCodes(ctx context.Context, adminID uuid.UUID, campaignID uuid.UUID) (*response.VoucherCampaignResponse, []response.VoucherResponse, error) {
+	campaign, err := u.GetCampaign(ctx, adminID, campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vouchers, err := u.campaignRepo.GetCampaignVouchers(campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codes := make([]response.VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		codes = append(codes, *toVoucherResponse(&voucher))
+	}
+
+	return campaign, codes, nil
+}
+
+// generateUniqueCodes draws random codes until it has quantity codes that are unique
+// among themselves and not used by any existing voucher
+func (u *voucherCampaignUsecase) generateUniqueCodes(prefix, alphabet string, length, quantity int) ([]string, error) {
+	seen := make(map[string]struct{}, quantity)
+	codes := make([]string, 0, quantity)
+
+	for round := 0; len(codes) < quantity; round++ {
+		if round == maxCodeGenerationRounds {
+			return nil, errors.New("could not generate enough unique codes, use a longer code length")
+		}
+
+		candidates := make([]string, 0, quantity-len(codes))
+		for len(candidates) < cap(candidates) {
+			suffix, err := random.RandFromAlphabet(alphabet, length)
+			if err != nil {
+				return nil, err
+			}
+			code := prefix + suffix
+			if _, ok := seen[code]; ok {
+				continue
+			}
+			seen[code] = struct{}{}
+			candidates = append(candidates, code)
+		}
+
+		existing, err := u.campaignRepo.GetExistingCodes(candidates)
+		if err != nil {
+			return nil, err
+		}
+		taken := make(map[string]struct{}, len(existing))
+		for _, code := range existing {
+			taken[code] = struct{}{}
+		}
+		for _, code := range candidates {
+			if _, ok := taken[code]; !ok {
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	return codes, nil
+}
+
+// validateCodeAlphabet requires at least two distinct letters or digits
+func validateCodeAlphabet(alphabet string) error {
+	if len(alphabet) < 2 || len(alphabet) > maxCodeAlphabetLength {
+		return errors.New("alphabet must have between 2 and 62 characters")
+	}
+	if !isCodeText(alphabet, "") {
+		return errors.New("alphabet may only contain letters and digits")
+	}
+	for i := range alphabet {
+		if strings.IndexByte(alphabet[i+1:], alphabet[i]) >= 0 {
+			return errors.New("alphabet must not repeat characters")
+		}
+	}
+	return nil
+}
+
+// isCodeText reports whether s only has ASCII letters, digits and the given extra characters
+func isCodeText(s, extra string) bool {
+	for _, c := range s {
+		isAlnum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlnum && !strings.ContainsRune(extra, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func toVoucherCampaignResponse(campaign *entity.VoucherCampaign, stats *repository.CampaignStats) *response.VoucherCampaignResponse {
+	return &response.VoucherCampaignResponse{
+		ID:               campaign.ID,
+		Name:             campaign.Name,
+		Prefix:           campaign.Prefix,
+		CodeLength:       campaign.CodeLength,
+		TotalCodes:       stats.TotalCodes,
+		RedeemedCodes:    stats.RedeemedCodes,
+		OutstandingCodes: stats.TotalCodes - stats.RedeemedCodes,
+		Redemptions:      stats.Redemptions,
+		DiscountTotal:    stats.DiscountTotal,
+		CreatedBy:        campaign.CreatedBy,
+		CreatedAt:        campaign.CreatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+)
+
+func TestCalculateVoucherDiscount(t *testing.T) {
+	tests := []struct {
+		name         string
+		voucher      entity.Voucher
+		amount       float64
+		wantErr      bool
+		wantDiscount float64
+		wantFinal    float64
+		wantType     string
+		wantRule     string
+	}{
+		{
+			name:         "percent",
+			voucher:      entity.Voucher{DiscountType: entity.VoucherDiscountPercent, DiscountPercent: 15},
+			amount:       200,
+			wantDiscount: 30,
+			wantFinal:    170,
+			wantType:     entity.VoucherDiscountPercent,
+			wantRule:     "15% off",
+		},
+		{
+			name:         "percent rounds to cents",
+			voucher:      entity.Voucher{DiscountPercent: 15},
+			amount:       33.33,
+			wantDiscount: 5,
+			wantFinal:    28.33,
+			wantType:     entity.VoucherDiscountPercent,
+			wantRule:     "15% off",
+		},
+		{
+			name:         "percent capped",
+			voucher:      entity.Voucher{DiscountPercent: 50, MaxDiscount: 40},
+			amount:       200,
+			wantDiscount: 40,
+			wantFinal:    160,
+			wantType:     entity.VoucherDiscountPercent,
+			wantRule:     "50% off, capped at 40.00",
+		},
+		{
+			name:         "percent under the cap",
+			voucher:      entity.Voucher{DiscountPercent: 10, MaxDiscount: 40},
+			amount:       200,
+			wantDiscount: 20,
+			wantFinal:    180,
+			wantType:     entity.VoucherDiscountPercent,
+			wantRule:     "10% off",
+		},
+		{
+			name:         "fixed",
+			voucher:      entity.Voucher{DiscountType: entity.VoucherDiscountFixed, DiscountAmount: 25},
+			amount:       200,
+			wantDiscount: 25,
+			wantFinal:    175,
+			wantType:     entity.VoucherDiscountFixed,
+			wantRule:     "fixed discount of 25.00",
+		},
+		{
+			name:         "fixed greater than the amount",
+			voucher:      entity.Voucher{DiscountType: entity.VoucherDiscountFixed, DiscountAmount: 50},
+			amount:       30,
+			wantDiscount: 30,
+			wantFinal:    0,
+			wantType:     entity.VoucherDiscountFixed,
+			wantRule:     "fixed discount of 50.00, limited to the order amount",
+		},
+		{
+			name:         "minimum order met",
+			voucher:      entity.Voucher{DiscountType: entity.VoucherDiscountFixed, DiscountAmount: 10, MinOrderAmount: 100},
+			amount:       100,
+			wantDiscount: 10,
+			wantFinal:    90,
+			wantType:     entity.VoucherDiscountFixed,
+			wantRule:     "fixed discount of 10.00 (minimum order 100.00)",
+		},
+		{
+			name:    "below the minimum order",
+			voucher: entity.Voucher{DiscountType: entity.VoucherDiscountFixed, DiscountAmount: 10, MinOrderAmount: 100},
+			amount:  99.99,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculateVoucherDiscount(&tt.voucher, tt.amount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("calculateVoucherDiscount() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("calculateVoucherDiscount() error = %v", err)
+			}
+			if got.DiscountAmount != tt.wantDiscount || got.FinalAmount != tt.wantFinal {
+				t.Errorf("calculateVoucherDiscount() discount/final = %v/%v, want %v/%v",
+					got.DiscountAmount, got.FinalAmount, tt.wantDiscount, tt.wantFinal)
+			}
+			if got.DiscountType != tt.wantType {
+				t.Errorf("calculateVoucherDiscount() type = %q, want %q", got.DiscountType, tt.wantType)
+			}
+			if got.AppliedRule != tt.wantRule {
+				t.Errorf("calculateVoucherDiscount() rule = %q, want %q", got.AppliedRule, tt.wantRule)
+			}
+		})
+	}
+}
@@ -3,10 +3,14 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
 	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/pkg/utils/timeutils"
 	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
 	"github.com/leehai1107/cmm_server/service/cmm/model/request"
 	"github.com/leehai1107/cmm_server/service/cmm/model/response"
@@ -20,20 +24,37 @@ type IWalletUsecase interface {
 	ConfirmTopup(ctx context.Context, req request.ConfirmTopup) error
 	GetTopupHistory(ctx context.Context, userID uuid.UUID) ([]response.TopupResponse, error)
 	GetTransactionHistory(ctx context.Context, userID uuid.UUID) ([]response.TransactionResponse, error)
+	Transfer(ctx context.Context, senderID uuid.UUID, req request.TransferWallet) (*response.WalletTransferResponse, error)
+	GetTransferHistory(ctx context.Context, userID uuid.UUID) ([]response.WalletTransferResponse, error)
 }
 
 type walletUsecase struct {
-	walletRepo      repository.IWalletRepo
-	transactionRepo repository.ITransactionRepo
+	walletRepo          repository.IWalletRepo
+	transactionRepo     repository.ITransactionRepo
+	userRepo            repository.IUserRepo
+	referralUsecase     IReferralUsecase
+	notificationUsecase INotificationUsecase
+	loyaltyUsecase      ILoyaltyUsecase
+	cfg                 config.WalletCfg
 }
 
 func NewWalletUsecase(
 	walletRepo repository.IWalletRepo,
 	transactionRepo repository.ITransactionRepo,
+	userRepo repository.IUserRepo,
+	referralUsecase IReferralUsecase,
+	notificationUsecase INotificationUsecase,
+	loyaltyUsecase ILoyaltyUsecase,
+	cfg config.WalletCfg,
 ) IWalletUsecase {
 	return &walletUsecase{
-		walletRepo:      walletRepo,
-		transactionRepo: transactionRepo,
+		walletRepo:          walletRepo,
+		transactionRepo:     transactionRepo,
+		userRepo:            userRepo,
+		referralUsecase:     referralUsecase,
+		notificationUsecase: notificationUsecase,
+		loyaltyUsecase:      loyaltyUsecase,
+		cfg:                 cfg,
 	}
 }
 
@@ -46,9 +67,21 @@ func (u *walletUsecase) GetWallet(ctx context.Context, userID uuid.UUID) (*respo
 		return nil, err
 	}
 
+	points, err := u.loyaltyUsecase.GetPointsBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tier, err := u.loyaltyUsecase.GetTier(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &response.WalletResponse{
-		UserID:  wallet.UserID,
-		Balance: wallet.Balance,
+		UserID:      wallet.UserID,
+		Balance:     wallet.Balance,
+		HeldBalance: wallet.HeldBalance,
+		Points:      points,
+		Tier:        tier,
 	}, nil
 }
 
@@ -118,7 +151,7 @@ func (u *walletUsecase) ConfirmTopup(ctx context.Context, req request.ConfirmTop
 	transaction := &entity.Transaction{
 		ID:           uuid.New(),
 		UserID:       topup.UserID,
-		ServiceID:    2, // 2 for topup
+		ServiceID:    entity.ServiceTopup,
 		ServiceRefID: topup.ID,
 		Amount:       topup.Amount,
 		PaidAt:       time.Now(),
@@ -128,6 +161,11 @@ func (u *walletUsecase) ConfirmTopup(ctx context.Context, req request.ConfirmTop
 		log.Errorw("Failed to create transaction", "error", err)
 	}
 
+	// A confirmed top-up may be the payment that unlocks a referral reward
+	if err := u.referralUsecase.RewardReferral(ctx, topup.UserID, entity.ServiceTopup, topup.ID, topup.Amount); err != nil {
+		log.Errorw("Failed to reward referral", "error", err)
+	}
+
 	return nil
 }
 
@@ -163,7 +201,7 @@ func (u *walletUsecase) GetTransactionHistory(ctx context.Context, userID uuid.U
 		result = append(result, response.TransactionResponse{
 			ID:              transaction.ID,
 			UserID:          transaction.UserID,
-			ServiceID:       transaction.ServiceID,
+			ServiceID:       int(transaction.ServiceID),
 			ServiceRefID:    transaction.ServiceRefID,
 			Amount:          transaction.Amount,
 			PaymentMethodID: transaction.PaymentMethodID,
@@ -174,3 +212,112 @@ func (u *walletUsecase) GetTransactionHistory(ctx context.Context, userID uuid.U
 
 	return result, nil
 }
+
+func (u *walletUsecase) Transfer(ctx context.Context, senderID uuid.UUID, req request.TransferWallet) (*response.WalletTransferResponse, error) {
+	log := logger.EnhanceWith(ctx)
+	log.Info("Transfer usecase called")
+
+	amount := mathutil.RoundToFloat(req.Amount, 2)
+	if amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	recipient, err := u.userRepo.GetUserByEmail(req.RecipientEmail)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipient not found")
+		}
+		return nil, err
+	}
+	if recipient.ID == senderID {
+		return nil, errors.New("cannot transfer to yourself")
+	}
+
+	now := time.Now()
+	transfer := &entity.WalletTransfer{
+		ID:          uuid.New(),
+		SenderID:    senderID,
+		RecipientID: recipient.ID,
+		Amount:      amount,
+		Note:        req.Note,
+		CreatedAt:   now,
+	}
+
+	// Paired debit and credit records
+	transactions := []entity.Transaction{
+		{
+			ID:           uuid.New(),
+			UserID:       senderID,
+			ServiceID:    entity.ServiceTransfer,
+			ServiceRefID: transfer.ID,
+			Amount:       -amount,
+			PaidAt:       now,
+			Status:       "completed",
+		},
+		{
+			ID:           uuid.New(),
+			UserID:       recipient.ID,
+			ServiceID:    entity.ServiceTransfer,
+			ServiceRefID: transfer.ID,
+			Amount:       amount,
+			PaidAt:       now,
+			Status:       "completed",
+		},
+	}
+
+	// Daily limits reset at midnight local time
+	limitSince := timeutils.TimeBeginDayByTime(timeutils.ConvertTimeToGMT07(now))
+	err = u.walletRepo.Transfer(transfer, transactions, limitSince, u.cfg.TransferDailyLimit, u.cfg.TransferDailyCount)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInsufficientBalance):
+			return nil, errors.New("insufficient balance")
+		case errors.Is(err, repository.ErrTransferLimitExceeded):
+			return nil, errors.New("daily transfer limit exceeded")
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.New("wallet not found")
+		}
+		return nil, err
+	}
+
+	body := fmt.Sprintf("You received %.2f from another user", amount)
+	if req.Note != "" {
+		body += ": " + req.Note
+	}
+	if err := u.notificationUsecase.Notify(ctx, recipient.ID, entity.NotificationWalletTransfer, "Money received", body, &transfer.ID); err != nil {
+		log.Errorw("Failed to notify transfer recipient", "error", err)
+	}
+
+	return toWalletTransferResponse(transfer, senderID), nil
+}
+
+func (u *walletUsecase) GetTransferHistory(ctx context.Context, userID uuid.UUID) ([]response.WalletTransferResponse, error) {
+	transfers, err := u.walletRepo.GetTransfersByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.WalletTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		result = append(result, *toWalletTransferResponse(&transfer, userID))
+	}
+
+	return result, nil
+}
+
+// toWalletTransferResponse maps a transfer as seen by the given user
+func toWalletTransferResponse(transfer *entity.WalletTransfer, viewerID uuid.UUID) *response.WalletTransferResponse {
+	direction := "received"
+	if transfer.SenderID == viewerID {
+		direction = "sent"
+	}
+	return &response.WalletTransferResponse{
+		ID:          transfer.ID,
+		SenderID:    transfer.SenderID,
+		RecipientID: transfer.RecipientID,
+		Amount:      transfer.Amount,
+		Note:        transfer.Note,
+		Direction:   direction,
+		CreatedAt:   transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/leehai1107/cmm_server/pkg/config"
+	"github.com/leehai1107/cmm_server/pkg/logger"
+	"github.com/leehai1107/cmm_server/pkg/utils/mathutil"
+	"github.com/leehai1107/cmm_server/service/cmm/model/entity"
+	"github.com/leehai1107/cmm_server/service/cmm/model/request"
+	"github.com/leehai1107/cmm_server/service/cmm/model/response"
+	"github.com/leehai1107/cmm_server/service/cmm/repository"
+	"gorm.io/gorm"
+)
+
+type IWithdrawalUsecase interface {
+	// Owner methods
+	CreatePayoutAccount(ctx context.Context, userID uuid.UUID, req request.CreatePayoutAccount) (*response.PayoutAccountResponse, error)
+	GetMyPayoutAccounts(ctx context.Context, userID uuid.UUID) ([]response.PayoutAccountResponse, error)
+	DeletePayoutAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error
+	RequestWithdrawal(ctx context.Context, userID uuid.UUID, req request.RequestWithdrawal) (*response.WithdrawalResponse, error)
+	GetMyWithdrawals(ctx context.Context, userID uuid.UUID) ([]response.WithdrawalResponse, error)
+
+	// Admin methods
+	GetWithdrawals(ctx context.Context, adminID uuid.UUID, status string) ([]response.WithdrawalResponse, error)
+	GetWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) (*response.WithdrawalResponse, error)
+	ApproveWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) error
+	MarkWithdrawalPaid(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) error
+	RejectWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID, req request.RejectWithdrawal) error
+}
+
+// ErrAdminOnly is returned when a user who is not an admin calls an admin-only usecase
+var ErrAdminOnly = errors.New("only admins can perform this action")
+
+type withdrawalUsecase struct {
+	withdrawalRepo      repository.IWithdrawalRepo
+	userRepo            repository.IUserRepo
+	notificationUsecase INotificationUsecase
+	cfg                 config.WalletCfg
+}
+
+func NewWithdrawalUsecase(
+	withdrawalRepo repository.IWithdrawalRepo,
+	userRepo repository.IUserRepo,
+	notificationUsecase INotificationUsecase,
+	cfg config.WalletCfg,
+) IWithdrawalUsecase {
+	return &withdrawalUsecase{
+		withdrawalRepo:      withdrawalRepo,
+		userRepo:            userRepo,
+		notificationUsecase: notificationUsecase,
+		cfg:                 cfg,
+	}
+}
+
+func (u *withdrawalUsecase) CreatePayoutAccount(ctx context.Context, userID uuid.UUID, req request.CreatePayoutAccount) (*response.PayoutAccountResponse, error) {
+	logger.EnhanceWith(ctx).Info("CreatePayoutAccount usecase called")
+
+	if err := u.requireOwner(userID); err != nil {
+		return nil, err
+	}
+
+	account := &entity.PayoutAccount{
+		ID:            uuid.New(),
+		UserID:        userID,
+		BankName:      strings.TrimSpace(req.BankName),
+		AccountNumber: strings.TrimSpace(req.AccountNumber),
+		AccountHolder: strings.ToUpper(strings.TrimSpace(req.AccountHolder)),
+		CreatedAt:     time.Now(),
+	}
+	if err := u.withdrawalRepo.CreatePayoutAccount(account); err != nil {
+		return nil, err
+	}
+
+	return toPayoutAccountResponse(account), nil
+}
+
+func (u *withdrawalUsecase) GetMyPayoutAccounts(ctx context.Context, userID uuid.UUID) ([]response.PayoutAccountResponse, error) {
+	accounts, err := u.withdrawalRepo.GetPayoutAccountsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.PayoutAccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, *toPayoutAccountResponse(&account))
+	}
+
+	return result, nil
+}
+
+func (u *withdrawalUsecase) DeletePayoutAccount(ctx context.Context, userID uuid.UUID, accountID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("DeletePayoutAccount usecase called")
+
+	account, err := u.withdrawalRepo.GetPayoutAccountByID(accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("payout account not found")
+		}
+		return err
+	}
+	if account.UserID != userID {
+		return errors.New("unauthorized to delete this payout account")
+	}
+
+	deleted, err := u.withdrawalRepo.DeletePayoutAccount(accountID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("payout account has been used for withdrawals and cannot be deleted")
+	}
+
+	return nil
+}
+
+func (u *withdrawalUsecase) RequestWithdrawal(ctx context.Context, userID uuid.UUID, req request.RequestWithdrawal) (*response.WithdrawalResponse, error) {
+	logger.EnhanceWith(ctx).Info("RequestWithdrawal usecase called")
+
+	if err := u.requireOwner(userID); err != nil {
+		return nil, err
+	}
+
+	amount := mathutil.RoundToFloat(req.Amount, 2)
+	if amount < u.cfg.WithdrawalMinAmount {
+		return nil, fmt.Errorf("minimum withdrawal amount is %.2f", u.cfg.WithdrawalMinAmount)
+	}
+
+	account, err := u.withdrawalRepo.GetPayoutAccountByID(req.PayoutAccountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("payout account not found")
+		}
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, errors.New("unauthorized to use this payout account")
+	}
+
+	now := time.Now()
+	withdrawal := &entity.Withdrawal{
+		ID:              uuid.New(),
+		UserID:          userID,
+		PayoutAccountID: account.ID,
+		Amount:          amount,
+		Status:          entity.WithdrawalRequested,
+		CreatedAt:       now,
+	}
+
+	// The transaction stays pending until the withdrawal is paid or rejected
+	transaction := &entity.Transaction{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ServiceID:    entity.ServiceWithdrawal,
+		ServiceRefID: withdrawal.ID,
+		Amount:       -amount,
+		PaidAt:       now,
+		Status:       "pending",
+	}
+
+	if err := u.withdrawalRepo.CreateWithdrawal(withdrawal, transaction); err != nil {
+		if errors.Is(err, repository.ErrInsufficientBalance) {
+			return nil, errors.New("insufficient balance")
+		}
+		return nil, err
+	}
+
+	result := toWithdrawalResponse(withdrawal)
+	result.PayoutAccount = toPayoutAccountResponse(account)
+	return result, nil
+}
+
+func (u *withdrawalUsecase) GetMyWithdrawals(ctx context.Context, userID uuid.UUID) ([]response.WithdrawalResponse, error) {
+	withdrawals, err := u.withdrawalRepo.GetWithdrawalsByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWithdrawalResponses(withdrawals), nil
+}
+
+func (u *withdrawalUsecase) GetWithdrawals(ctx context.Context, adminID uuid.UUID, status string) ([]response.WithdrawalResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := u.withdrawalRepo.GetWithdrawals(status)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWithdrawalResponses(withdrawals), nil
+}
+
+func (u *withdrawalUsecase) GetWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) (*response.WithdrawalResponse, error) {
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return nil, err
+	}
+
+	withdrawal, err := u.getWithdrawal(withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.withdrawalRepo.GetWithdrawalEvents(withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toWithdrawalResponse(withdrawal)
+	result.History = make([]response.WithdrawalEventResponse, 0, len(events))
+	for _, event := range events {
+		result.History = append(result.History, response.WithdrawalEventResponse{
+			Status:    event.Status,
+			ActorID:   event.ActorID,
+			Note:      event.Note,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (u *withdrawalUsecase) ApproveWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("ApproveWithdrawal usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return err
+	}
+
+	withdrawal, err := u.getWithdrawal(withdrawalID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := u.withdrawalRepo.ApproveWithdrawal(withdrawalID, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("withdrawal is not awaiting approval")
+	}
+
+	u.notifyWithdrawal(ctx, withdrawal, "Withdrawal approved",
+		fmt.Sprintf("Your withdrawal of %.2f has been approved and will be transferred shortly", withdrawal.Amount))
+	return nil
+}
+
+func (u *withdrawalUsecase) MarkWithdrawalPaid(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID) error {
+	logger.EnhanceWith(ctx).Info("MarkWithdrawalPaid usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return err
+	}
+
+	withdrawal, err := u.getWithdrawal(withdrawalID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := u.withdrawalRepo.MarkWithdrawalPaid(withdrawalID, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("withdrawal is not approved")
+	}
+
+	u.notifyWithdrawal(ctx, withdrawal, "Withdrawal paid",
+		fmt.Sprintf("Your withdrawal of %.2f has been transferred to your bank account", withdrawal.Amount))
+	return nil
+}
+
+func (u *withdrawalUsecase) RejectWithdrawal(ctx context.Context, adminID uuid.UUID, withdrawalID uuid.UUID, req request.RejectWithdrawal) error {
+	logger.EnhanceWith(ctx).Info("RejectWithdrawal usecase called")
+
+	if err := requireAdmin(u.userRepo, adminID); err != nil {
+		return err
+	}
+
+	withdrawal, err := u.getWithdrawal(withdrawalID)
+	if err != nil {
+		return err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	updated, err := u.withdrawalRepo.RejectWithdrawal(withdrawalID, adminID, reason, time.Now())
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New("withdrawal can no longer be rejected")
+	}
+
+	u.notifyWithdrawal(ctx, withdrawal, "Withdrawal rejected",
+		fmt.Sprintf("Your withdrawal of %.2f was rejected and returned to your wallet: %s", withdrawal.Amount, reason))
+	return nil
+}
+
+func (u *withdrawalUsecase) getWithdrawal(withdrawalID uuid.UUID) (*entity.Withdrawal, error) {
+	withdrawal, err := u.withdrawalRepo.GetWithdrawalByID(withdrawalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("withdrawal not found")
+		}
+		return nil, err
+	}
+	return withdrawal, nil
+}
+
+// requireOwner allows only coffee shop owners to cash out their wallet
+func (u *withdrawalUsecase) requireOwner(userID uuid.UUID) error {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	if user.Role != entity.RoleOwner {
+		return errors.New("only coffee shop owners can withdraw")
+	}
+	return nil
+}
+
+// requireAdmin allows only admins to review withdrawals and other payouts that move money
+func requireAdmin(userRepo repository.IUserRepo, userID uuid.UUID) error {
+	user, err := userRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	if user.Role != entity.RoleAdmin {
+		return ErrAdminOnly
+	}
+	return nil
+}
+
+func (u *withdrawalUsecase) notifyWithdrawal(ctx context.Context, withdrawal *entity.Withdrawal, title string, body string) {
+	if err := u.notificationUsecase.Notify(ctx, withdrawal.UserID, entity.NotificationWithdrawal, title, body, &withdrawal.ID); err != nil {
+		logger.EnhanceWith(ctx).Errorw("Failed to notify withdrawal owner", "error", err)
+	}
+}
+
+func toPayoutAccountResponse(account *entity.PayoutAccount) *response.PayoutAccountResponse {
+	return &response.PayoutAccountResponse{
+		ID:            account.ID,
+		BankName:      account.BankName,
+		AccountNumber: account.AccountNumber,
+		AccountHolder: account.AccountHolder,
+		CreatedAt:     account.CreatedAt,
+	}
+}
+
+func toWithdrawalResponse(withdrawal *entity.Withdrawal) *response.WithdrawalResponse {
+	return &response.WithdrawalResponse{
+		ID:              withdrawal.ID,
+		UserID:          withdrawal.UserID,
+		PayoutAccountID: withdrawal.PayoutAccountID,
+		Amount:          withdrawal.Amount,
+		Status:          withdrawal.Status,
+		RejectReason:    withdrawal.RejectReason,
+		ProcessedBy:     withdrawal.ProcessedBy,
+		ProcessedAt:     withdrawal.ProcessedAt,
+		PaidAt:          withdrawal.PaidAt,
+		CreatedAt:       withdrawal.CreatedAt,
+	}
+}
+
+func toWithdrawalResponses(withdrawals []entity.Withdrawal) []response.WithdrawalResponse {
+	result := make([]response.WithdrawalResponse, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		result = append(result, *toWithdrawalResponse(&withdrawal))
+	}
+	return result
+}